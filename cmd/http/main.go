@@ -16,6 +16,7 @@ import (
 	"github.com/rafaeltg/goports/internal/adapters/handler/http"
 	"github.com/rafaeltg/goports/internal/adapters/repository/memory"
 	"github.com/rafaeltg/goports/internal/core/config"
+	"github.com/rafaeltg/goports/internal/core/rules"
 	"github.com/rafaeltg/goports/internal/core/service"
 	"github.com/rafaeltg/goports/pkg/logging"
 	"golang.org/x/sync/errgroup"
@@ -28,6 +29,21 @@ func main() {
 		log.Fatalf("failed to load configuration: %v", err)
 	}
 
+	svcOpts := []service.PortServiceOption{
+		service.WithKeepRaw(cfg.KeepRawPorts),
+		service.WithTileCacheSize(cfg.TileCacheSize),
+		service.WithDistanceMatrixMaxCells(cfg.DistanceMatrixMaxCells),
+	}
+
+	if len(cfg.RulesPath) > 0 {
+		engine, err := rules.Load(cfg.RulesPath)
+		if err != nil {
+			log.Fatalf("failed to load rules: %v", err)
+		}
+
+		svcOpts = append(svcOpts, service.WithRules(engine))
+	}
+
 	// Setup logger
 	logger := logging.NewLogger(
 		logging.WithLevel(cfg.LogLevel),
@@ -48,7 +64,7 @@ func main() {
 	// Dependency injection
 	memDB := memory.NewDatabase()
 	portRepo := memory.NewPortRepository(memDB, logger)
-	portSvc := service.NewPortService(portRepo, logger)
+	portSvc := service.NewPortService(portRepo, logger, svcOpts...)
 
 	router := mux.NewRouter()
 	srv := &gohttp.Server{
@@ -79,12 +95,84 @@ func main() {
 	})
 
 	g.Go(func() error {
+		// lookups first, as /ports/{id} would match /ports/resolve
+		http.WithLookupHandlers(
+			router,
+			portSvc,
+			logger,
+		)
+
 		http.WithPortHandlers(
 			router,
 			portSvc,
 			logger,
 		)
 
+		http.WithGeoJSONHandlers(
+			router,
+			portSvc,
+			logger,
+		)
+
+		http.WithCountryHandlers(
+			router,
+			portSvc,
+			logger,
+		)
+
+		http.WithRegionHandlers(
+			router,
+			portSvc,
+			logger,
+		)
+
+		http.WithTimezoneHandlers(
+			router,
+			portSvc,
+			logger,
+		)
+
+		http.WithStatsHandlers(
+			router,
+			portSvc,
+			logger,
+		)
+
+		http.WithTileHandlers(
+			router,
+			portSvc,
+			logger,
+		)
+
+		http.WithRouteHandlers(
+			router,
+			portSvc,
+			logger,
+		)
+
+		http.WithDistanceHandlers(
+			router,
+			portSvc,
+			logger,
+		)
+
+		http.WithAdminHandlers(
+			router,
+			portSvc,
+			logger,
+		)
+
+		http.WithImportHandlers(
+			router,
+			http.NewImports(gCtx, portSvc, logger,
+				http.WithImportMaxBytes(cfg.ImportMaxBytes),
+				http.WithImportMaxInputBytes(cfg.ImportMaxInputBytes),
+				http.WithImportMaxJobs(cfg.ImportMaxJobs),
+				http.WithImportJobsTTL(cfg.ImportJobsTTL),
+			),
+			logger,
+		)
+
 		err := srv.ListenAndServe()
 		if err != gohttp.ErrServerClosed {
 			logger.ErrorContext(ctx,
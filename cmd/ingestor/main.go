@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"log"
 	"log/slog"
 	"os"
@@ -11,9 +12,26 @@ import (
 	"github.com/rafaeltg/goports/internal/adapters/client/http"
 	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
 	"github.com/rafaeltg/goports/internal/core/config"
+	"github.com/rafaeltg/goports/internal/core/rules"
 	"github.com/rafaeltg/goports/pkg/logging"
 )
 
+const (
+	// modeIngest ingests a single file and exits.
+	modeIngest = "ingest"
+	// modeWatch watches a directory, ingesting the files dropped into it.
+	modeWatch = "watch"
+	// modeSync compares a single file against the server ports, printing
+	// the differences and optionally writing them.
+	modeSync = "sync"
+	// modeDryRun checks a single file without ingesting it, printing a report
+	// and exiting with a non-zero code when problems are found.
+	modeDryRun = "dry-run"
+	// modeQuality analyses the data quality of a single file, printing a
+	// JSON or HTML report.
+	modeQuality = "quality"
+)
+
 type Config struct {
 	config.Configuration
 }
@@ -25,8 +43,46 @@ func main() {
 		log.Fatalf("failed to load configuration: %v", err)
 	}
 
-	if len(cfg.Ingestor.Filepath) == 0 {
-		log.Fatalf("missing name of the file to process")
+	// The mode may be given as a subcommand, overriding the env var.
+	mode := cfg.Ingestor.Mode
+	if len(os.Args) > 1 {
+		mode = os.Args[1]
+	}
+
+	switch mode {
+	case modeIngest, modeDryRun, modeSync, modeQuality:
+		if len(cfg.Ingestor.Filepath) == 0 {
+			log.Fatalf("missing name of the file to process")
+		}
+	case modeWatch:
+		if len(cfg.Ingestor.WatchDir) == 0 {
+			log.Fatalf("missing name of the directory to watch")
+		}
+	default:
+		log.Fatalf("unknown ingestor mode: %s", mode)
+	}
+
+	format, err := ingest.ParseFormat(cfg.Ingestor.Format)
+	if err != nil {
+		log.Fatalf("invalid ingestor format: %v", err)
+	}
+
+	var mapping *ingest.Mapping
+
+	if len(cfg.Ingestor.MappingPath) > 0 {
+		mapping, err = ingest.LoadMapping(cfg.Ingestor.MappingPath)
+		if err != nil {
+			log.Fatalf("failed to load mapping: %v", err)
+		}
+	}
+
+	var engine *rules.Engine
+
+	if len(cfg.RulesPath) > 0 {
+		engine, err = rules.Load(cfg.RulesPath)
+		if err != nil {
+			log.Fatalf("failed to load rules: %v", err)
+		}
 	}
 
 	// Setup logger
@@ -37,6 +93,15 @@ func main() {
 		logging.WithField("version", cfg.Application.Version),
 	)
 
+	var script *ingest.Script
+
+	if len(cfg.Ingestor.ScriptPath) > 0 {
+		script, err = ingest.LoadScript(cfg.Ingestor.ScriptPath, logger)
+		if err != nil {
+			log.Fatalf("failed to load script: %v", err)
+		}
+	}
+
 	ctx, cancel := signal.NotifyContext(
 		context.Background(),
 		os.Interrupt,
@@ -49,23 +114,147 @@ func main() {
 	// Dependency injection
 	httpClient := http.NewCient(cfg.Server.Host())
 	portClient := http.NewPortClient(httpClient, logger)
-	portIngestor := ingest.NewPortIngestor(
-		portClient,
-		logger,
+	ingestorOpts := []ingest.PortIngestorOption{
 		ingest.WithBatchSize(cfg.Ingestor.BatchSize),
-	)
+		ingest.WithFormat(format),
+		ingest.WithReportPath(cfg.Ingestor.ReportPath),
+		ingest.WithProgress(func(p ingest.Progress) {
+			logger.Info("ingestion progress",
+				slog.Int64("bytesRead", p.BytesRead),
+				slog.Int64("bytesTotal", p.BytesTotal),
+				slog.Int64("portsDecoded", p.PortsDecoded),
+				slog.Int64("batchesAcked", p.BatchesAcked),
+				slog.Float64("portsPerSecond", p.PortsPerSecond),
+				slog.Duration("eta", p.ETA),
+				slog.Int("batchSize", p.BatchSize),
+				slog.Int("concurrency", p.Concurrency),
+				slog.Float64("rateLimit", p.RateLimit),
+			)
+		}, cfg.Ingestor.ProgressInterval),
+		ingest.WithRateLimit(cfg.Ingestor.RateLimit),
+		ingest.WithDecoders(cfg.Ingestor.Decoders),
+		ingest.WithMapping(mapping),
+		ingest.WithScript(script),
+		ingest.WithRules(engine),
+	}
+
+	if cfg.Ingestor.Lenient {
+		ingestorOpts = append(ingestorOpts, ingest.WithLenient(cfg.Ingestor.MaxErrors))
+	}
+
+	if a := cfg.Ingestor.Adaptive; a.Enabled {
+		ingestorOpts = append(ingestorOpts, ingest.WithAdaptive(ingest.AdaptiveOptions{
+			MinBatchSize:   a.MinBatchSize,
+			MaxBatchSize:   a.MaxBatchSize,
+			MaxConcurrency: a.MaxConcurrency,
+			TargetLatency:  a.TargetLatency,
+			MaxRetries:     a.MaxRetries,
+		}))
+	}
+
+	portIngestor := ingest.NewPortIngestor(portClient, logger, ingestorOpts...)
 
 	logger.Info("running ingestor",
+		slog.String("mode", mode),
 		slog.Any("config", cfg),
 	)
 
-	err = portIngestor.Process(ctx, cfg.Ingestor.Filepath)
-	if err != nil {
-		logger.Error(
-			"error importing ports data",
-			logging.Error(err),
+	switch mode {
+	case modeWatch:
+		watcher := ingest.NewWatcher(
+			portIngestor,
+			cfg.Ingestor.WatchDir,
+			logger,
+			ingest.WithSettle(cfg.Ingestor.WatchSettle),
 		)
-	} else {
-		logger.Info("done importing ports data")
+
+		err = watcher.Run(ctx)
+		if err != nil {
+			logger.Error(
+				"error watching directory",
+				logging.Error(err),
+			)
+		} else {
+			logger.Info("stopped watching directory")
+		}
+	case modeDryRun:
+		report, err := portIngestor.DryRun(ctx, cfg.Ingestor.Filepath)
+		if err != nil {
+			logger.Error(
+				"error checking ports data",
+				logging.Error(err),
+			)
+
+			cancel()
+			os.Exit(2)
+		}
+
+		printReport(report)
+
+		if !report.Clean() {
+			cancel()
+			os.Exit(1)
+		}
+	case modeQuality:
+		report, err := portIngestor.Quality(ctx, cfg.Ingestor.Filepath)
+		if err != nil {
+			logger.Error(
+				"error analysing ports data",
+				logging.Error(err),
+			)
+
+			cancel()
+			os.Exit(1)
+		}
+
+		if cfg.Ingestor.QualityFormat == "html" {
+			err = report.WriteHTML(os.Stdout)
+		} else {
+			printReport(report)
+		}
+
+		if err != nil {
+			logger.Error(
+				"error writing quality report",
+				logging.Error(err),
+			)
+		}
+	case modeSync:
+		report, err := portIngestor.Sync(ctx, cfg.Ingestor.Filepath, ingest.SyncOptions{
+			Apply:            cfg.Ingestor.Sync.Apply,
+			Prune:            cfg.Ingestor.Sync.Prune,
+			MaxDeletePercent: cfg.Ingestor.Sync.MaxDeletePercent,
+		})
+
+		if report != nil {
+			printReport(report)
+		}
+
+		if err != nil {
+			logger.Error(
+				"error syncing ports data",
+				logging.Error(err),
+			)
+
+			cancel()
+			os.Exit(1)
+		}
+	default:
+		err = portIngestor.Process(ctx, cfg.Ingestor.Filepath)
+		if err != nil {
+			logger.Error(
+				"error importing ports data",
+				logging.Error(err),
+			)
+		} else {
+			logger.Info("done importing ports data")
+		}
 	}
 }
+
+// printReport writes a report as indented JSON to the standard output.
+func printReport(report any) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(report)
+}
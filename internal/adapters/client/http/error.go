@@ -1,14 +1,25 @@
 package http
 
+import (
+	"net/http"
+
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/rules"
+)
+
 type (
 	// ApiError represents the error data.
 	ApiError struct {
 		Message string `json:"message"`
+		// Violations holds the rules failed by the sent ports, if any.
+		Violations []rules.Violation `json:"violations,omitempty"`
 	}
 
 	// ApiErrorResponse represents the error response payload.
 	ApiErrorResponse struct {
 		Err ApiError `json:"error"`
+		// StatusCode is the status code of the response.
+		StatusCode int `json:"-"`
 	}
 )
 
@@ -19,3 +30,21 @@ func (e ApiError) Error() string {
 func (r ApiErrorResponse) Error() string {
 	return r.Err.Error()
 }
+
+// Unwrap returns port.ErrPortsRejected for the client errors, other than
+// timeouts and throttling, along with the rules violations as a
+// *rules.ViolationError, if any.
+func (r ApiErrorResponse) Unwrap() []error {
+	var errs []error
+
+	if r.StatusCode >= http.StatusBadRequest && r.StatusCode < http.StatusInternalServerError &&
+		r.StatusCode != http.StatusRequestTimeout && r.StatusCode != http.StatusTooManyRequests {
+		errs = append(errs, port.ErrPortsRejected)
+	}
+
+	if len(r.Err.Violations) > 0 {
+		errs = append(errs, &rules.ViolationError{Violations: r.Err.Violations})
+	}
+
+	return errs
+}
@@ -67,6 +67,10 @@ func (c *Client) Do(req *Request, res *Response) error {
 	if httpRes.StatusCode() != res.StatusCode {
 		err = json.Unmarshal(httpRes.Body(), res.OutError)
 		if err == nil {
+			if r, ok := res.OutError.(*ApiErrorResponse); ok {
+				r.StatusCode = httpRes.StatusCode()
+			}
+
 			return res.OutError
 		}
 	} else if res.Out != nil {
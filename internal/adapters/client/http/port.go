@@ -15,6 +15,7 @@ import (
 const (
 	portsPath           = "/ports"
 	bulkUpsertPortsPath = portsPath + "/bulk-upsert"
+	bulkDeletePortsPath = portsPath + "/bulk-delete"
 )
 
 type (
@@ -74,6 +75,85 @@ func (p *PortClient) BulkUpsert(ctx context.Context, ports domain.Ports) error {
 	return err
 }
 
+func (p *PortClient) List(ctx context.Context) (domain.Ports, error) {
+	p.logger.DebugContext(ctx, "[PortClient.List] executing")
+
+	req := &Request{
+		Path:   portsPath,
+		Method: http.MethodGet,
+	}
+
+	corrId, ok := cid.FromContext(ctx)
+	if !ok {
+		id, _ := uuid.NewV4()
+		corrId = id.String()
+	}
+
+	req.Headers = map[string]string{
+		"Content-Type": "application/json",
+		"X-Request-Id": corrId,
+	}
+
+	var ports domain.Ports
+
+	res := &Response{
+		StatusCode: http.StatusOK,
+		Out:        &ports,
+		OutError:   &ApiErrorResponse{},
+	}
+
+	err := p.client.Do(req, res)
+	if err != nil {
+		p.logger.ErrorContext(ctx,
+			"[PortClient.List] failed to execute request",
+			logging.Error(err),
+		)
+
+		return nil, err
+	}
+
+	return ports, nil
+}
+
+func (p *PortClient) BulkDelete(ctx context.Context, ids []string) error {
+	p.logger.DebugContext(ctx,
+		"[PortClient.BulkDelete] executing",
+		slog.Int("ids.length", len(ids)),
+	)
+
+	req := &Request{
+		Path:   bulkDeletePortsPath,
+		Method: http.MethodPost,
+		Body:   ids,
+	}
+
+	corrId, ok := cid.FromContext(ctx)
+	if !ok {
+		id, _ := uuid.NewV4()
+		corrId = id.String()
+	}
+
+	req.Headers = map[string]string{
+		"Content-Type": "application/json",
+		"X-Request-Id": corrId,
+	}
+
+	res := &Response{
+		StatusCode: http.StatusNoContent,
+		OutError:   &ApiErrorResponse{},
+	}
+
+	err := p.client.Do(req, res)
+	if err != nil {
+		p.logger.ErrorContext(ctx,
+			"[PortClient.BulkDelete] failed to execute request",
+			logging.Error(err),
+		)
+	}
+
+	return err
+}
+
 func (p *PortClient) Get(ctx context.Context, id string) (*domain.Port, error) {
 	p.logger.DebugContext(ctx,
 		"[PortClient.Get] executing",
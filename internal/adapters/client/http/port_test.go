@@ -0,0 +1,142 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	gohttp "net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/adapters/client/http"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/cid"
+	"github.com/stretchr/testify/assert"
+)
+
+var loggerTest = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+func TestPortClient_List(t *testing.T) {
+	tcs := []struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedPorts domain.Ports
+		expectedErr   string
+	}{
+		{
+			name:       "success",
+			statusCode: gohttp.StatusOK,
+			body:       `[{"id": "AEAJM", "name": "Ajman", "coordinates": [55.5136433, 25.4052165]}, {"id": "AEAUH"}]`,
+			expectedPorts: domain.Ports{
+				{ID: "AEAJM", Name: "Ajman", Coordinates: domain.Coordinates{55.5136433, 25.4052165}},
+				{ID: "AEAUH"},
+			},
+		},
+		{
+			name:          "empty",
+			statusCode:    gohttp.StatusOK,
+			body:          `[]`,
+			expectedPorts: domain.Ports{},
+		},
+		{
+			name:        "api error",
+			statusCode:  gohttp.StatusInternalServerError,
+			body:        `{"error": {"message": "internal"}}`,
+			expectedErr: "internal",
+		},
+		{
+			name:        "invalid body",
+			statusCode:  gohttp.StatusOK,
+			body:        `{"id": "AEAJM"}`,
+			expectedErr: "expect [ or n, but found {",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+				assert.Equal(t, gohttp.MethodGet, r.Method)
+				assert.Equal(t, "/ports", r.URL.Path)
+				assert.Equal(t, "abc", r.Header.Get("X-Request-Id"))
+
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			client := http.NewPortClient(http.NewCient(srv.URL), loggerTest)
+
+			ports, err := client.List(cid.NewContext(context.Background(), "abc"))
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				assert.Nil(t, ports)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedPorts, ports)
+		})
+	}
+}
+
+func TestPortClient_BulkUpsert(t *testing.T) {
+	tcs := []struct {
+		name             string
+		statusCode       int
+		body             string
+		expectedErr      string
+		expectedRejected bool
+	}{
+		{
+			name:       "success",
+			statusCode: gohttp.StatusCreated,
+		},
+		{
+			name:             "rejected",
+			statusCode:       gohttp.StatusUnprocessableEntity,
+			body:             `{"error": {"message": "invalid ports: port 'AEAJM': name: is required"}}`,
+			expectedErr:      "invalid ports: port 'AEAJM': name: is required",
+			expectedRejected: true,
+		},
+		{
+			name:        "throttled",
+			statusCode:  gohttp.StatusTooManyRequests,
+			body:        `{"error": {"message": "too many requests"}}`,
+			expectedErr: "too many requests",
+		},
+		{
+			name:        "unavailable",
+			statusCode:  gohttp.StatusServiceUnavailable,
+			body:        `{"error": {"message": "unavailable"}}`,
+			expectedErr: "unavailable",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+				assert.Equal(t, gohttp.MethodPost, r.Method)
+				assert.Equal(t, "/ports/bulk-upsert", r.URL.Path)
+
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			client := http.NewPortClient(http.NewCient(srv.URL), loggerTest)
+
+			err := client.BulkUpsert(context.Background(), domain.Ports{{ID: "AEAJM"}})
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedRejected, errors.Is(err, port.ErrPortsRejected))
+		})
+	}
+}
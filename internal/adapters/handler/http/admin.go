@@ -0,0 +1,79 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/logging"
+)
+
+func qualityHandler(
+	qualitySvc port.QualityService,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		report, err := qualitySvc.Quality(ctx)
+		if err != nil {
+			logger.ErrorContext(ctx,
+				"failed to analyse ports quality",
+				logging.Error(err),
+			)
+
+			writeResponse(
+				w,
+				withStatusCode(http.StatusInternalServerError),
+				withError(err),
+			)
+
+			return
+		}
+
+		if !wantsHTML(r) {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusOK),
+				withBody(report),
+			)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+
+		if err = report.WriteHTML(w); err != nil {
+			logger.ErrorContext(ctx,
+				"failed to write quality report",
+				logging.Error(err),
+			)
+		}
+	})
+}
+
+// wantsHTML reports whether an HTML response was asked for, either by the
+// format query parameter or by the Accept header.
+func wantsHTML(r *http.Request) bool {
+	if f := r.URL.Query().Get("format"); f != "" {
+		return f == "html"
+	}
+
+	accept := r.Header.Get("Accept")
+
+	return strings.Contains(accept, "text/html") && !strings.Contains(accept, "application/json")
+}
+
+// WithAdminHandlers setup admin API handlers.
+func WithAdminHandlers(
+	router *mux.Router,
+	qualitySvc port.QualityService,
+	logger *slog.Logger,
+) {
+	router.Handle("/admin/quality", qualityHandler(qualitySvc, logger)).
+		Methods(http.MethodGet).
+		Name("getQuality")
+}
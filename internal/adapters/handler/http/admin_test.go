@@ -0,0 +1,122 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	gohttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/adapters/handler/http"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/rafaeltg/goports/internal/core/quality"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetQuality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	report := quality.Analyse(domain.Ports{
+		{ID: "AEAJM", Name: "Ajman", Country: "United Arab Emirates", Timezone: "Asia/Dubai"},
+	})
+
+	tcs := []struct {
+		name                string
+		query               string
+		accept              string
+		svcError            error
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "internal server error",
+			svcError:            errors.New("internal"),
+			expectedStatusCode:  gohttp.StatusInternalServerError,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":{"message":"internal"}}`,
+		},
+		{
+			name:                "json",
+			expectedStatusCode:  gohttp.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        `"missing-coordinates":1`,
+		},
+		{
+			name:                "html format",
+			query:               "?format=html",
+			expectedStatusCode:  gohttp.StatusOK,
+			expectedContentType: "text/html; charset=utf-8",
+			expectedBody:        "<td>AEAJM</td><td>missing-coordinates</td>",
+		},
+		{
+			name:                "html accept",
+			accept:              "text/html,application/xhtml+xml",
+			expectedStatusCode:  gohttp.StatusOK,
+			expectedContentType: "text/html; charset=utf-8",
+			expectedBody:        "<h1>Ports data quality report</h1>",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedQualitySvc := porttest.NewMockQualityService(ctrl)
+
+			if tc.svcError != nil {
+				mockedQualitySvc.EXPECT().
+					Quality(gomock.Any()).
+					Return(nil, tc.svcError)
+			} else {
+				mockedQualitySvc.EXPECT().
+					Quality(gomock.Any()).
+					Return(report, nil)
+			}
+
+			router := mux.NewRouter()
+			http.WithAdminHandlers(
+				router,
+				mockedQualitySvc,
+				loggerTest,
+			)
+
+			srv := httptest.NewServer(router)
+			defer srv.Close()
+
+			req, err := gohttp.NewRequestWithContext(
+				context.Background(),
+				gohttp.MethodGet,
+				fmt.Sprintf("%s/admin/quality%s", srv.URL, tc.query),
+				nil,
+			)
+			require.NoError(t, err)
+
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+
+			resp, err := gohttp.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, tc.expectedContentType, resp.Header.Get("Content-Type"))
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), tc.expectedBody)
+
+			if tc.expectedContentType == "application/json" {
+				assert.True(t, json.Valid(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/logging"
+)
+
+func listCountriesHandler(
+	countrySvc port.CountryService,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		countries, err := countrySvc.Countries(ctx)
+		if err != nil {
+			logger.ErrorContext(ctx,
+				"failed to list countries",
+				logging.Error(err),
+			)
+
+			writeResponse(
+				w,
+				withStatusCode(http.StatusInternalServerError),
+				withError(err),
+			)
+
+			return
+		}
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusOK),
+			withBody(countries),
+		)
+	})
+}
+
+func listCountryPortsHandler(
+	countrySvc port.CountryService,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		view, err := portViewOptions(r)
+		if err != nil {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(err),
+			)
+
+			return
+		}
+
+		code := mux.Vars(r)["code"]
+
+		ports, err := countrySvc.CountryPorts(ctx, code)
+		if err != nil {
+			switch err {
+			case port.ErrCountryNotFound:
+				writeResponse(
+					w,
+					withStatusCode(http.StatusNotFound),
+					withError(err),
+				)
+			default:
+				logger.ErrorContext(ctx,
+					"failed to list country ports",
+					logging.Error(err),
+				)
+
+				writeResponse(
+					w,
+					withStatusCode(http.StatusInternalServerError),
+					withError(err),
+				)
+			}
+
+			return
+		}
+
+		if wantsGeoJSON(r) {
+			writeGeoJSON(w, r, eachPort(ports), logger)
+			return
+		}
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusOK),
+			withBody(ports),
+			withPortView(view),
+		)
+	})
+}
+
+// WithCountryHandlers setup country API handlers.
+func WithCountryHandlers(
+	router *mux.Router,
+	countrySvc port.CountryService,
+	logger *slog.Logger,
+) {
+	router.Handle("/countries", listCountriesHandler(countrySvc, logger)).
+		Methods(http.MethodGet).
+		Name("listCountries")
+
+	router.Handle("/countries/{code}/ports", listCountryPortsHandler(countrySvc, logger)).
+		Methods(http.MethodGet).
+		Name("listCountryPorts")
+}
@@ -0,0 +1,153 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	gohttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/adapters/handler/http"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCountryPorts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	code := "AE"
+
+	tcs := []struct {
+		name               string
+		ports              domain.Ports
+		svcError           error
+		expectedStatusCode int
+		expectedResponse   any
+	}{
+		{
+			name:               "internal server error",
+			svcError:           errors.New("internal"),
+			expectedStatusCode: gohttp.StatusInternalServerError,
+			expectedResponse: http.ErrorResponse{
+				Error: http.ErrorData{
+					Message: "internal",
+				},
+			},
+		},
+		{
+			name:               "not found",
+			svcError:           port.ErrCountryNotFound,
+			expectedStatusCode: gohttp.StatusNotFound,
+			expectedResponse: http.ErrorResponse{
+				Error: http.ErrorData{
+					Message: port.ErrCountryNotFound.Error(),
+				},
+			},
+		},
+		{
+			name: "success",
+			ports: domain.Ports{
+				{ID: "AEAJM", CountryCode: code},
+			},
+			expectedStatusCode: gohttp.StatusOK,
+			expectedResponse: domain.Ports{
+				{ID: "AEAJM", CountryCode: code},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedCountrySvc := porttest.NewMockCountryService(ctrl)
+			mockedCountrySvc.EXPECT().
+				CountryPorts(gomock.Any(), code).
+				Return(tc.ports, tc.svcError)
+
+			router := mux.NewRouter()
+			http.WithCountryHandlers(
+				router,
+				mockedCountrySvc,
+				loggerTest,
+			)
+
+			srv := httptest.NewServer(router)
+			defer srv.Close()
+
+			client := &gohttp.Client{}
+			req, err := gohttp.NewRequestWithContext(
+				context.Background(),
+				gohttp.MethodGet,
+				fmt.Sprintf("%s/countries/%s/ports", srv.URL, code),
+				nil,
+			)
+			assert.NoError(t, err)
+
+			resp, err := client.Do(req)
+			assert.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			expectedResp, err := json.Marshal(tc.expectedResponse)
+			assert.NoError(t, err)
+
+			actualResp, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+
+			// Read all adds an exta \n at the end
+			assert.Equal(t, string(expectedResp), string(actualResp)[:len(actualResp)-1])
+		})
+	}
+}
+
+func TestListCountries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	countries := []domain.CountrySummary{
+		{Country: domain.Country{Code: "AE", Name: "United Arab Emirates"}, Ports: 2},
+	}
+
+	mockedCountrySvc := porttest.NewMockCountryService(ctrl)
+	mockedCountrySvc.EXPECT().
+		Countries(gomock.Any()).
+		Return(countries, nil)
+
+	router := mux.NewRouter()
+	http.WithCountryHandlers(
+		router,
+		mockedCountrySvc,
+		loggerTest,
+	)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	req, err := gohttp.NewRequestWithContext(
+		context.Background(),
+		gohttp.MethodGet,
+		fmt.Sprintf("%s/countries", srv.URL),
+		nil,
+	)
+	assert.NoError(t, err)
+
+	resp, err := gohttp.DefaultClient.Do(req)
+	assert.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, gohttp.StatusOK, resp.StatusCode)
+
+	actualResp, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"code":"AE","name":"United Arab Emirates","ports":2}]`, string(actualResp))
+}
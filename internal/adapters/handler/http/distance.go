@@ -0,0 +1,101 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/logging"
+)
+
+func distanceMatrixHandler(
+	distanceSvc port.DistanceService,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getRequestContext(r)
+
+		var req domain.DistanceMatrixRequest
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.ErrorContext(ctx,
+				"failed to decode request body",
+				logging.Error(err),
+			)
+
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(errBadRequest),
+			)
+
+			return
+		}
+
+		req.Method, err = domain.ParseDistanceMethod(string(req.Method))
+		if err != nil {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(err),
+			)
+
+			return
+		}
+
+		m, err := distanceSvc.DistanceMatrix(ctx, req)
+		if err != nil {
+			var uErr *port.UnlocatedPortsError
+
+			switch {
+			case errors.As(err, &uErr):
+				writeResponse(
+					w,
+					withStatusCode(http.StatusUnprocessableEntity),
+					withError(err),
+				)
+			case errors.Is(err, port.ErrEmptyDistanceMatrix), errors.Is(err, port.ErrDistanceMatrixTooLarge):
+				writeResponse(
+					w,
+					withStatusCode(http.StatusBadRequest),
+					withError(err),
+				)
+			default:
+				logger.ErrorContext(ctx,
+					"failed to compute distance matrix",
+					logging.Error(err),
+				)
+
+				writeResponse(
+					w,
+					withStatusCode(http.StatusInternalServerError),
+					withError(err),
+				)
+			}
+
+			return
+		}
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusOK),
+			withBody(m),
+		)
+	})
+}
+
+// WithDistanceHandlers setup distances API handlers.
+func WithDistanceHandlers(
+	router *mux.Router,
+	distanceSvc port.DistanceService,
+	logger *slog.Logger,
+) {
+	router.Handle("/ports/distance-matrix", distanceMatrixHandler(distanceSvc, logger)).
+		Methods(http.MethodPost).
+		Name("distanceMatrix")
+}
@@ -0,0 +1,188 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gohttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/adapters/handler/http"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistanceMatrix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	matrix := &domain.DistanceMatrix{
+		Origins:      []string{"AEAJM"},
+		Destinations: []string{"AEDXB", "BRSSZ"},
+		Method:       domain.DistanceVincenty,
+		Distances:    [][]domain.Distance{{{Km: 33.1, NM: 17.87}, {Km: 12236.4, NM: 6607.13}}},
+	}
+
+	tcs := []struct {
+		name               string
+		body               string
+		expectedRequest    *domain.DistanceMatrixRequest
+		matrix             *domain.DistanceMatrix
+		svcError           error
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "success",
+			body: `{"origins":["AEAJM"],"destinations":["AEDXB","BRSSZ"],"method":"Vincenty"}`,
+			expectedRequest: &domain.DistanceMatrixRequest{
+				Origins:      []string{"AEAJM"},
+				Destinations: []string{"AEDXB", "BRSSZ"},
+				Method:       domain.DistanceVincenty,
+			},
+			matrix:             matrix,
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody: `{"origins":["AEAJM"],"destinations":["AEDXB","BRSSZ"],"method":"vincenty",` +
+				`"distances":[[{"km":33.1,"nm":17.87},{"km":12236.4,"nm":6607.13}]]}` + "\n",
+		},
+		{
+			name: "haversine by default",
+			body: `{"origins":["AEAJM"],"destinations":["AEDXB"]}`,
+			expectedRequest: &domain.DistanceMatrixRequest{
+				Origins:      []string{"AEAJM"},
+				Destinations: []string{"AEDXB"},
+				Method:       domain.DistanceHaversine,
+			},
+			matrix: &domain.DistanceMatrix{
+				Origins:      []string{"AEAJM"},
+				Destinations: []string{"AEDXB"},
+				Method:       domain.DistanceHaversine,
+				Distances:    [][]domain.Distance{{{Km: 33.05, NM: 17.85}}},
+			},
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody: `{"origins":["AEAJM"],"destinations":["AEDXB"],"method":"haversine",` +
+				`"distances":[[{"km":33.05,"nm":17.85}]]}` + "\n",
+		},
+		{
+			name:               "invalid body",
+			body:               `{"origins":`,
+			expectedStatusCode: gohttp.StatusBadRequest,
+			expectedBody:       `{"error":{"message":"failed to read request body"}}` + "\n",
+		},
+		{
+			name:               "unknown method",
+			body:               `{"origins":["AEAJM"],"destinations":["AEDXB"],"method":"manhattan"}`,
+			expectedStatusCode: gohttp.StatusBadRequest,
+			expectedBody:       `{"error":{"message":"unknown distance method 'manhattan'"}}` + "\n",
+		},
+		{
+			name:               "empty",
+			body:               `{"origins":["AEAJM"]}`,
+			expectedRequest:    &domain.DistanceMatrixRequest{Origins: []string{"AEAJM"}, Method: domain.DistanceHaversine},
+			svcError:           port.ErrEmptyDistanceMatrix,
+			expectedStatusCode: gohttp.StatusBadRequest,
+			expectedBody:       `{"error":{"message":"both origins and destinations are required"}}` + "\n",
+		},
+		{
+			name:               "too large",
+			body:               `{"origins":["AEAJM"],"destinations":["AEDXB"]}`,
+			svcError:           fmt.Errorf("%w: 2 distances requested, at most 1 allowed", port.ErrDistanceMatrixTooLarge),
+			expectedStatusCode: gohttp.StatusBadRequest,
+			expectedBody:       `{"error":{"message":"distance matrix too large: 2 distances requested, at most 1 allowed"}}` + "\n",
+		},
+		{
+			name: "unlocated ports",
+			body: `{"origins":["AEAJM"],"destinations":["NLXXX","XXXXX"]}`,
+			svcError: &port.UnlocatedPortsError{
+				NotFound:      []string{"NLXXX"},
+				NoCoordinates: []string{"XXXXX"},
+			},
+			expectedStatusCode: gohttp.StatusUnprocessableEntity,
+			expectedBody: `{"error":{"message":"ports not found: NLXXX; ports without coordinates: XXXXX",` +
+				`"notFound":["NLXXX"],"noCoordinates":["XXXXX"]}}` + "\n",
+		},
+		{
+			name:               "internal server error",
+			body:               `{"origins":["AEAJM"],"destinations":["AEDXB"]}`,
+			svcError:           errors.New("internal"),
+			expectedStatusCode: gohttp.StatusInternalServerError,
+			expectedBody:       `{"error":{"message":"internal"}}` + "\n",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedDistanceSvc := porttest.NewMockDistanceService(ctrl)
+
+			if tc.matrix != nil || tc.svcError != nil {
+				req := gomock.Any()
+				if tc.expectedRequest != nil {
+					req = gomock.Eq(*tc.expectedRequest)
+				}
+
+				mockedDistanceSvc.EXPECT().
+					DistanceMatrix(gomock.Any(), req).
+					Return(tc.matrix, tc.svcError)
+			}
+
+			router := mux.NewRouter()
+			http.WithDistanceHandlers(
+				router,
+				mockedDistanceSvc,
+				loggerTest,
+			)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(gohttp.MethodPost, "/ports/distance-matrix", strings.NewReader(tc.body)))
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedBody, rec.Body.String())
+		})
+	}
+
+	t.Run("registered after the port handlers", func(t *testing.T) {
+		mockedDistanceSvc := porttest.NewMockDistanceService(ctrl)
+		mockedDistanceSvc.EXPECT().
+			DistanceMatrix(gomock.Any(), gomock.Any()).
+			Return(matrix, nil)
+
+		router := mux.NewRouter()
+		http.WithPortHandlers(router, porttest.NewMockPortService(ctrl), loggerTest)
+		http.WithDistanceHandlers(router, mockedDistanceSvc, loggerTest)
+
+		body := `{"origins":["AEAJM"],"destinations":["AEDXB","BRSSZ"]}`
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(gohttp.MethodPost, "/ports/distance-matrix", strings.NewReader(body)))
+
+		assert.Equal(t, gohttp.StatusOK, rec.Code)
+	})
+	t.Run("request canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		mockedDistanceSvc := porttest.NewMockDistanceService(ctrl)
+		mockedDistanceSvc.EXPECT().
+			DistanceMatrix(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ domain.DistanceMatrixRequest) (*domain.DistanceMatrix, error) {
+				return nil, ctx.Err()
+			})
+
+		router := mux.NewRouter()
+		http.WithDistanceHandlers(router, mockedDistanceSvc, loggerTest)
+
+		body := `{"origins":["AEAJM"],"destinations":["AEDXB","BRSSZ"]}`
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(gohttp.MethodPost, "/ports/distance-matrix", strings.NewReader(body)).WithContext(ctx))
+
+		assert.Equal(t, gohttp.StatusInternalServerError, rec.Code)
+		assert.Equal(t, `{"error":{"message":"context canceled"}}`+"\n", rec.Body.String())
+	})
+}
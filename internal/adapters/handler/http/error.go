@@ -1,6 +1,11 @@
 package http
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/rules"
+)
 
 type (
 	ErrorResponse struct {
@@ -9,6 +14,22 @@ type (
 
 	ErrorData struct {
 		Message string `json:"message"`
+		// InvalidPorts holds the ports failing validation, if any.
+		InvalidPorts []domain.InvalidPort `json:"invalidPorts,omitempty"`
+		// Violations holds the rules failed by the ports, if any.
+		Violations []rules.Violation `json:"violations,omitempty"`
+		// NotFound holds the IDs of the requested ports which were not found,
+		// if any.
+		NotFound []string `json:"notFound,omitempty"`
+		// NoCoordinates holds the IDs of the requested ports which have no
+		// coordinates, if any.
+		NoCoordinates []string `json:"noCoordinates,omitempty"`
+		// Regions holds the unknown, or still referenced, regions which
+		// failed the request, if any.
+		Regions []string `json:"regions,omitempty"`
+		// Candidates holds the IDs of the ports claiming the key a port was
+		// looked up by, when ambiguous.
+		Candidates []string `json:"candidates,omitempty"`
 	}
 )
 
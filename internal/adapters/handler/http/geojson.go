@@ -0,0 +1,155 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/logging"
+)
+
+// geoJSONContentType is the media type of GeoJSON documents.
+const geoJSONContentType = "application/geo+json"
+
+// geoJSONFlushEvery is the number of features written between flushes of
+// the response.
+const geoJSONFlushEvery = 500
+
+// wantsGeoJSON reports whether the request accepts GeoJSON, and not
+// plain JSON, like GIS tools do.
+func wantsGeoJSON(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err == nil && mediaType == geoJSONContentType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeGeoJSON writes the ports yielded by each as a GeoJSON feature
+// collection, encoding each feature as it is yielded, so that the collection
+// is never built in memory. The response is only started along with the first
+// feature, so that failures before it are still answered with an error.
+func writeGeoJSON(
+	w http.ResponseWriter,
+	r *http.Request,
+	each func(fn func(*domain.Port) error) error,
+	logger *slog.Logger,
+) {
+	ctx := getContext(r)
+
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	written := 0
+	started := false
+
+	start := func() error {
+		started = true
+
+		w.Header().Set("Content-Type", geoJSONContentType)
+		w.WriteHeader(http.StatusOK)
+
+		_, err := w.Write([]byte(`{"type":"` + domain.GeoJSONFeatureCollection + `","features":[`))
+
+		return err
+	}
+
+	err := each(func(p *domain.Port) error {
+		f, err := p.Feature()
+		if err != nil {
+			return err
+		}
+
+		if !started {
+			err = start()
+		} else {
+			_, err = w.Write([]byte{','})
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err = enc.Encode(f); err != nil {
+			return err
+		}
+
+		written++
+
+		if flusher != nil && written%geoJSONFlushEvery == 0 {
+			flusher.Flush()
+		}
+
+		return nil
+	})
+
+	if err == nil && !started {
+		err = start()
+	}
+
+	if err == nil {
+		_, err = w.Write([]byte("]}\n"))
+	}
+
+	if err == nil {
+		return
+	}
+
+	logger.ErrorContext(ctx,
+		"failed to write GeoJSON response",
+		logging.Error(err),
+	)
+
+	// otherwise the status was sent already, so the response is left truncated
+	if !started {
+		writeResponse(
+			w,
+			withStatusCode(http.StatusInternalServerError),
+			withError(err),
+		)
+	}
+}
+
+// eachPort yields the given ports, for writing listed ports as GeoJSON.
+func eachPort(ports domain.Ports) func(fn func(*domain.Port) error) error {
+	return func(fn func(*domain.Port) error) error {
+		for idx := range ports {
+			if err := fn(&ports[idx]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func geoJSONPortsHandler(
+	streamSvc port.StreamService,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		writeGeoJSON(w, r, func(fn func(*domain.Port) error) error {
+			return streamSvc.Each(ctx, fn)
+		}, logger)
+	})
+}
+
+// WithGeoJSONHandlers setup GeoJSON API handlers.
+func WithGeoJSONHandlers(
+	router *mux.Router,
+	streamSvc port.StreamService,
+	logger *slog.Logger,
+) {
+	router.Handle("/ports.geojson", geoJSONPortsHandler(streamSvc, logger)).
+		Methods(http.MethodGet).
+		Name("listPortsGeoJSON")
+}
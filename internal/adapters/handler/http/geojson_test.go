@@ -0,0 +1,179 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	gohttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/adapters/handler/http"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPortsGeoJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ports := domain.Ports{
+		{ID: "AEAJM", Name: "Ajman", Coordinates: domain.Coordinates{55.5136433, 25.4052165}},
+		{ID: "AEAUH", Name: "Abu Dhabi"},
+	}
+
+	tcs := []struct {
+		name   string
+		path   string
+		accept string
+	}{
+		{
+			name: "geojson path",
+			path: "/ports.geojson",
+		},
+		{
+			name:   "accept header",
+			path:   "/ports",
+			accept: "application/json;q=0.5, application/geo+json",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedPortSvc := porttest.NewMockPortService(ctrl)
+			mockedStreamSvc := porttest.NewMockStreamService(ctrl)
+
+			if tc.path == "/ports" {
+				mockedPortSvc.EXPECT().
+					List(gomock.Any()).
+					Return(ports, nil)
+			} else {
+				mockedStreamSvc.EXPECT().
+					Each(gomock.Any(), gomock.Any()).
+					DoAndReturn(eachPort(ports))
+			}
+
+			router := mux.NewRouter()
+			http.WithPortHandlers(
+				router,
+				mockedPortSvc,
+				loggerTest,
+			)
+			http.WithGeoJSONHandlers(
+				router,
+				mockedStreamSvc,
+				loggerTest,
+			)
+
+			srv := httptest.NewServer(router)
+			defer srv.Close()
+
+			req, err := gohttp.NewRequestWithContext(
+				context.Background(),
+				gohttp.MethodGet,
+				srv.URL+tc.path,
+				nil,
+			)
+			require.NoError(t, err)
+
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+
+			resp, err := gohttp.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, gohttp.StatusOK, resp.StatusCode)
+			assert.Equal(t, "application/geo+json", resp.Header.Get("Content-Type"))
+
+			var collection struct {
+				Type     string           `json:"type"`
+				Features []domain.Feature `json:"features"`
+			}
+
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&collection))
+			assert.Equal(t, "FeatureCollection", collection.Type)
+			require.Len(t, collection.Features, 2)
+
+			assert.Equal(t, "AEAJM", collection.Features[0].ID)
+			assert.Equal(t, "Point", collection.Features[0].Geometry.Type)
+			assert.JSONEq(t, `[55.5136433, 25.4052165]`, string(collection.Features[0].Geometry.Coordinates))
+			assert.Nil(t, collection.Features[1].Geometry)
+
+			p, err := collection.Features[1].Port()
+			require.NoError(t, err)
+			assert.Equal(t, ports[1], *p)
+		})
+	}
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockedStreamSvc := porttest.NewMockStreamService(ctrl)
+		mockedStreamSvc.EXPECT().
+			Each(gomock.Any(), gomock.Any()).
+			Return(errors.New("internal"))
+
+		router := mux.NewRouter()
+		http.WithGeoJSONHandlers(
+			router,
+			mockedStreamSvc,
+			loggerTest,
+		)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, "/ports.geojson", nil))
+
+		assert.Equal(t, gohttp.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		mockedStreamSvc := porttest.NewMockStreamService(ctrl)
+		mockedStreamSvc.EXPECT().
+			Each(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, fn func(*domain.Port) error) error {
+				if err := fn(&ports[0]); err != nil {
+					return err
+				}
+
+				// the first feature is written before the next port is yielded
+				assert.Contains(t, rec.Body.String(), `"id":"AEAJM"`)
+
+				return errors.New("internal")
+			})
+
+		router := mux.NewRouter()
+		http.WithGeoJSONHandlers(
+			router,
+			mockedStreamSvc,
+			loggerTest,
+		)
+
+		router.ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, "/ports.geojson", nil))
+
+		// the status was sent along with the first feature
+		assert.Equal(t, gohttp.StatusOK, rec.Code)
+		assert.Equal(t, "application/geo+json", rec.Header().Get("Content-Type"))
+		assert.False(t, json.Valid(rec.Body.Bytes()))
+	})
+}
+
+// eachPort mocks iterating over the given ports.
+func eachPort(ports domain.Ports) func(context.Context, func(*domain.Port) error) error {
+	return func(_ context.Context, fn func(*domain.Port) error) error {
+		for idx := range ports {
+			if err := fn(&ports[idx]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,557 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/logging"
+)
+
+const (
+	// ImportRunning is the status of an import being processed.
+	ImportRunning ImportStatus = "running"
+	// ImportSucceeded is the status of an import processed successfully.
+	ImportSucceeded ImportStatus = "succeeded"
+	// ImportFailed is the status of an import which failed.
+	ImportFailed ImportStatus = "failed"
+	// ImportCancelled is the status of an import cancelled before finishing.
+	ImportCancelled ImportStatus = "cancelled"
+)
+
+// importFileField is the multipart form field holding the uploaded file.
+const importFileField = "file"
+
+const (
+	// importMaxBytesDefault is the default size limit of the uploaded files.
+	importMaxBytesDefault int64 = 100 << 20
+	// importMaxInputBytesDefault is the default size limit of the uploaded
+	// files once decompressed.
+	importMaxInputBytesDefault int64 = 1 << 30
+	// importMaxJobsDefault is the default number of jobs running at once.
+	importMaxJobsDefault = 4
+	// importJobsTTLDefault is how long finished jobs are kept by default.
+	importJobsTTLDefault = 24 * time.Hour
+)
+
+var (
+	errImportNotFound = errors.New("import not found")
+	errImportFinished = errors.New("import already finished")
+	errTooManyImports = errors.New("too many imports running")
+)
+
+type (
+	// ImportStatus is the state of an import job.
+	ImportStatus string
+
+	// ImportJob is the state and progress of an import job.
+	ImportJob struct {
+		ID         string       `json:"id"`
+		Status     ImportStatus `json:"status"`
+		Upserted   int          `json:"upserted"`
+		Deleted    int          `json:"deleted"`
+		Batches    int          `json:"batches"`
+		Errors     []string     `json:"errors,omitempty"`
+		CreatedAt  time.Time    `json:"createdAt"`
+		FinishedAt *time.Time   `json:"finishedAt,omitempty"`
+	}
+
+	// Imports runs the uploaded ports files through the ingestor in the
+	// background, keeping track of their progress.
+	Imports struct {
+		ctx           context.Context
+		portSvc       port.PortService
+		opts          []ingest.PortIngestorOption
+		logger        *slog.Logger
+		maxBytes      int64
+		maxInputBytes int64
+		maxJobs       int
+		jobsTTL       time.Duration
+		mu            sync.Mutex
+		jobs          map[string]*importJob
+	}
+
+	ImportsOption func(*Imports)
+
+	importJob struct {
+		mu     sync.Mutex
+		job    ImportJob
+		cancel context.CancelFunc
+	}
+
+	// importPortService counts the ports written by an import job.
+	importPortService struct {
+		port.PortService
+		job *importJob
+	}
+)
+
+// NewImports creates the import jobs runner. Jobs are cancelled once the
+// given context is done.
+func NewImports(
+	ctx context.Context,
+	portSvc port.PortService,
+	logger *slog.Logger,
+	opts ...ImportsOption,
+) *Imports {
+	im := &Imports{
+		ctx:           ctx,
+		portSvc:       portSvc,
+		logger:        logger,
+		maxBytes:      importMaxBytesDefault,
+		maxInputBytes: importMaxInputBytesDefault,
+		maxJobs:       importMaxJobsDefault,
+		jobsTTL:       importJobsTTLDefault,
+		jobs:          make(map[string]*importJob),
+	}
+
+	for _, opt := range opts {
+		opt(im)
+	}
+
+	return im
+}
+
+// WithIngestorOptions sets the options of the ingestor processing the
+// uploaded files.
+func WithIngestorOptions(opts ...ingest.PortIngestorOption) ImportsOption {
+	return func(im *Imports) {
+		im.opts = append(im.opts, opts...)
+	}
+}
+
+// WithImportMaxBytes sets the size limit of the uploaded files.
+func WithImportMaxBytes(n int64) ImportsOption {
+	return func(im *Imports) {
+		if n > 0 {
+			im.maxBytes = n
+		}
+	}
+}
+
+// WithImportMaxInputBytes sets the size limit of the uploaded files once
+// decompressed, so that a small compressed upload can't be expanded into an
+// unbounded stream.
+func WithImportMaxInputBytes(n int64) ImportsOption {
+	return func(im *Imports) {
+		if n > 0 {
+			im.maxInputBytes = n
+		}
+	}
+}
+
+// WithImportMaxJobs sets the number of jobs running at once. Further imports
+// are refused until one of them finishes.
+func WithImportMaxJobs(n int) ImportsOption {
+	return func(im *Imports) {
+		if n > 0 {
+			im.maxJobs = n
+		}
+	}
+}
+
+// WithImportJobsTTL sets how long finished jobs are kept, so that their
+// status can still be requested.
+func WithImportJobsTTL(ttl time.Duration) ImportsOption {
+	return func(im *Imports) {
+		if ttl > 0 {
+			im.jobsTTL = ttl
+		}
+	}
+}
+
+// start starts importing the given file in the background, removing it when
+// done. It fails with errTooManyImports when the running jobs are at the limit.
+func (im *Imports) start(filename string, format ingest.Format) (ImportJob, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return ImportJob{}, fmt.Errorf("failed to generate import id: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(im.ctx)
+
+	j := &importJob{
+		job: ImportJob{
+			ID:        id.String(),
+			Status:    ImportRunning,
+			CreatedAt: time.Now().UTC(),
+		},
+		cancel: cancel,
+	}
+
+	im.mu.Lock()
+	im.prune(time.Now().UTC())
+
+	if im.running() >= im.maxJobs {
+		im.mu.Unlock()
+		cancel()
+
+		return ImportJob{}, errTooManyImports
+	}
+
+	im.jobs[j.job.ID] = j
+	im.mu.Unlock()
+
+	opts := append(append([]ingest.PortIngestorOption{}, im.opts...),
+		ingest.WithFormat(format),
+		ingest.WithMaxInputBytes(im.maxInputBytes),
+	)
+	ingestor := ingest.NewPortIngestor(
+		&importPortService{PortService: im.portSvc, job: j},
+		im.logger.With(slog.String("importId", j.job.ID)),
+		opts...,
+	)
+
+	go func() {
+		defer cancel()
+
+		err := ingestor.Process(ctx, filename)
+
+		if rmErr := os.Remove(filename); rmErr != nil {
+			im.logger.Error(
+				"failed to remove import file",
+				logging.Error(rmErr),
+			)
+		}
+
+		j.finish(ctx, err)
+	}()
+
+	return j.view(), nil
+}
+
+// prune forgets the jobs finished for longer than the jobs TTL. It must be
+// called with im.mu held.
+func (im *Imports) prune(now time.Time) {
+	for id, j := range im.jobs {
+		if v := j.view(); v.FinishedAt != nil && now.Sub(*v.FinishedAt) > im.jobsTTL {
+			delete(im.jobs, id)
+		}
+	}
+}
+
+// running returns the number of jobs not finished yet. It must be called with
+// im.mu held.
+func (im *Imports) running() int {
+	n := 0
+
+	for _, j := range im.jobs {
+		if j.view().FinishedAt == nil {
+			n++
+		}
+	}
+
+	return n
+}
+
+// full tells whether the running jobs are at the limit, so that uploads can
+// be refused before being read.
+func (im *Imports) full() bool {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	return im.running() >= im.maxJobs
+}
+
+func (im *Imports) get(id string) (*importJob, bool) {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	j, ok := im.jobs[id]
+
+	return j, ok
+}
+
+func (j *importJob) view() ImportJob {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	v := j.job
+	v.Errors = append([]string(nil), j.job.Errors...)
+
+	return v
+}
+
+func (j *importJob) finish(ctx context.Context, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now().UTC()
+	j.job.FinishedAt = &now
+
+	switch {
+	case ctx.Err() != nil:
+		j.job.Status = ImportCancelled
+	case err != nil:
+		j.job.Status = ImportFailed
+		j.job.Errors = append(j.job.Errors, err.Error())
+	default:
+		j.job.Status = ImportSucceeded
+	}
+}
+
+// stop cancels a running job, failing if it has already finished.
+func (j *importJob) stop() (ImportJob, error) {
+	v := j.view()
+	if v.FinishedAt != nil {
+		return v, errImportFinished
+	}
+
+	j.cancel()
+
+	return v, nil
+}
+
+func (j *importJob) count(upserted, deleted int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.job.Upserted += upserted
+	j.job.Deleted += deleted
+	j.job.Batches++
+}
+
+func (s *importPortService) BulkUpsert(ctx context.Context, ports domain.Ports) error {
+	err := s.PortService.BulkUpsert(ctx, ports)
+	if err == nil {
+		s.job.count(len(ports), 0)
+	}
+
+	return err
+}
+
+func (s *importPortService) BulkDelete(ctx context.Context, ids []string) error {
+	err := s.PortService.BulkDelete(ctx, ids)
+	if err == nil {
+		s.job.count(0, len(ids))
+	}
+
+	return err
+}
+
+// spoolUpload saves the uploaded file, sent either as the "file" field of a
+// multipart form or as the raw request body, into a temporary file. The body
+// must be already limited to the largest size accepted.
+func spoolUpload(r *http.Request) (string, error) {
+	var body io.Reader = r.Body
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		mr, err := r.MultipartReader()
+		if err != nil {
+			return "", err
+		}
+
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				return "", fmt.Errorf("missing '%s' field: %w", importFileField, err)
+			}
+
+			if part.FormName() == importFileField {
+				body = part
+				break
+			}
+		}
+	}
+
+	f, err := os.CreateTemp("", "ports-import-*")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = io.Copy(f, body)
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+func createImportHandler(
+	imports *Imports,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		format := ingest.FormatJSON
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == geoJSONContentType {
+			format = ingest.FormatGeoJSON
+		}
+
+		if f := r.URL.Query().Get("format"); f != "" {
+			var err error
+
+			format, err = ingest.ParseFormat(f)
+			if err != nil {
+				writeResponse(
+					w,
+					withStatusCode(http.StatusBadRequest),
+					withError(err),
+				)
+
+				return
+			}
+		}
+
+		if imports.full() {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusTooManyRequests),
+				withError(errTooManyImports),
+			)
+
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, imports.maxBytes)
+
+		filename, err := spoolUpload(r)
+		if err != nil {
+			logger.ErrorContext(ctx,
+				"failed to read uploaded file",
+				logging.Error(err),
+			)
+
+			var mbErr *http.MaxBytesError
+			if errors.As(err, &mbErr) {
+				writeResponse(
+					w,
+					withStatusCode(http.StatusRequestEntityTooLarge),
+					withError(err),
+				)
+
+				return
+			}
+
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(errBadRequest),
+			)
+
+			return
+		}
+
+		job, err := imports.start(filename, format)
+		if err != nil {
+			_ = os.Remove(filename)
+
+			if errors.Is(err, errTooManyImports) {
+				writeResponse(
+					w,
+					withStatusCode(http.StatusTooManyRequests),
+					withError(err),
+				)
+
+				return
+			}
+
+			writeResponse(
+				w,
+				withStatusCode(http.StatusInternalServerError),
+				withError(err),
+			)
+
+			return
+		}
+
+		w.Header().Set("Location", "/imports/"+job.ID)
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusAccepted),
+			withBody(job),
+		)
+	})
+}
+
+func getImportHandler(imports *Imports) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		j, ok := imports.get(mux.Vars(r)["id"])
+		if !ok {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusNotFound),
+				withError(errImportNotFound),
+			)
+
+			return
+		}
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusOK),
+			withBody(j.view()),
+		)
+	})
+}
+
+func cancelImportHandler(imports *Imports) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		j, ok := imports.get(mux.Vars(r)["id"])
+		if !ok {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusNotFound),
+				withError(errImportNotFound),
+			)
+
+			return
+		}
+
+		job, err := j.stop()
+		if err != nil {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusConflict),
+				withError(err),
+			)
+
+			return
+		}
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusAccepted),
+			withBody(job),
+		)
+	})
+}
+
+// WithImportHandlers setup import jobs API handlers.
+func WithImportHandlers(
+	router *mux.Router,
+	imports *Imports,
+	logger *slog.Logger,
+) {
+	router.Handle("/imports", createImportHandler(imports, logger)).
+		Methods(http.MethodPost).
+		Name("createImport")
+
+	router.Handle("/imports/{id}", getImportHandler(imports)).
+		Methods(http.MethodGet).
+		Name("getImport")
+
+	router.Handle("/imports/{id}", cancelImportHandler(imports)).
+		Methods(http.MethodDelete).
+		Name("cancelImport")
+}
@@ -0,0 +1,332 @@
+package http_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	gohttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/adapters/handler/http"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/domain/domaintest"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+)
+
+const importPortsJSON = `{
+	"AEAJM": {"name": "Ajman"},
+	"AEAUH": {"name": "Abu Dhabi"}
+}`
+
+func TestImports(t *testing.T) {
+	newServer := func(t *testing.T, mockedPortSvc *porttest.MockPortService, opts ...http.ImportsOption) *httptest.Server {
+		router := mux.NewRouter()
+		http.WithImportHandlers(
+			router,
+			http.NewImports(context.Background(), mockedPortSvc, loggerTest, opts...),
+			loggerTest,
+		)
+
+		srv := httptest.NewServer(router)
+		t.Cleanup(srv.Close)
+
+		return srv
+	}
+
+	do := func(t *testing.T, method, url, contentType string, body *bytes.Buffer) (int, http.ImportJob) {
+		if body == nil {
+			body = &bytes.Buffer{}
+		}
+
+		req, err := gohttp.NewRequestWithContext(context.Background(), method, url, body)
+		assert.NoError(t, err)
+
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		resp, err := gohttp.DefaultClient.Do(req)
+		assert.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		var job http.ImportJob
+		_ = json.NewDecoder(resp.Body).Decode(&job)
+
+		return resp.StatusCode, job
+	}
+
+	waitStatus := func(t *testing.T, srv *httptest.Server, id string, status http.ImportStatus) http.ImportJob {
+		var job http.ImportJob
+
+		assert.Eventually(t, func() bool {
+			_, job = do(t, gohttp.MethodGet, fmt.Sprintf("%s/imports/%s", srv.URL, id), "", nil)
+			return job.Status == status
+		}, time.Second, 10*time.Millisecond)
+
+		return job
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		srv := newServer(t, porttest.NewMockPortService(ctrl))
+
+		code, _ := do(t, gohttp.MethodGet, srv.URL+"/imports/abc", "", nil)
+		assert.Equal(t, gohttp.StatusNotFound, code)
+
+		code, _ = do(t, gohttp.MethodDelete, srv.URL+"/imports/abc", "", nil)
+		assert.Equal(t, gohttp.StatusNotFound, code)
+	})
+
+	t.Run("raw body", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(
+				gomock.Any(),
+				domaintest.PortsMatcher(
+					domain.Ports{
+						{ID: "AEAJM", Name: "Ajman"},
+						{ID: "AEAUH", Name: "Abu Dhabi"},
+					},
+				),
+			).
+			Return(nil)
+
+		srv := newServer(t, mockedPortSvc)
+
+		code, job := do(t, gohttp.MethodPost, srv.URL+"/imports", "application/json",
+			bytes.NewBufferString(importPortsJSON))
+		assert.Equal(t, gohttp.StatusAccepted, code)
+		assert.NotEmpty(t, job.ID)
+
+		job = waitStatus(t, srv, job.ID, http.ImportSucceeded)
+		assert.Equal(t, 2, job.Upserted)
+		assert.Equal(t, 1, job.Batches)
+		assert.NotNil(t, job.FinishedAt)
+
+		code, _ = do(t, gohttp.MethodDelete, srv.URL+"/imports/"+job.ID, "", nil)
+		assert.Equal(t, gohttp.StatusConflict, code)
+	})
+
+	t.Run("geojson", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(
+				gomock.Any(),
+				domaintest.PortsMatcher(
+					domain.Ports{
+						{ID: "AEAJM", Name: "Ajman"},
+					},
+				),
+			).
+			Return(nil)
+
+		srv := newServer(t, mockedPortSvc)
+
+		code, job := do(t, gohttp.MethodPost, srv.URL+"/imports", "application/geo+json",
+			bytes.NewBufferString(`{"type": "FeatureCollection", "features": [
+				{"type": "Feature", "id": "AEAJM", "geometry": null, "properties": {"name": "Ajman"}}
+			]}`))
+		assert.Equal(t, gohttp.StatusAccepted, code)
+
+		job = waitStatus(t, srv, job.ID, http.ImportSucceeded)
+		assert.Equal(t, 1, job.Upserted)
+	})
+
+	t.Run("multipart", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		srv := newServer(t, porttest.NewMockPortService(ctrl))
+
+		body := &bytes.Buffer{}
+		mw := multipart.NewWriter(body)
+
+		fw, err := mw.CreateFormFile("file", "ports.json")
+		assert.NoError(t, err)
+
+		_, _ = fw.Write([]byte(`{"AEAJM": {"name": 1}}`))
+		assert.NoError(t, mw.Close())
+
+		code, job := do(t, gohttp.MethodPost, srv.URL+"/imports", mw.FormDataContentType(), body)
+		assert.Equal(t, gohttp.StatusAccepted, code)
+
+		job = waitStatus(t, srv, job.ID, http.ImportFailed)
+		assert.Len(t, job.Errors, 1)
+		assert.True(t, strings.HasPrefix(job.Errors[0], "error on decoding port with id 'AEAJM'"))
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		srv := newServer(t, porttest.NewMockPortService(ctrl))
+
+		code, job := do(t, gohttp.MethodPost, srv.URL+"/imports?format=xml", "application/json",
+			bytes.NewBufferString(importPortsJSON))
+		assert.Equal(t, gohttp.StatusBadRequest, code)
+		assert.Empty(t, job.ID)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		srv := newServer(t, porttest.NewMockPortService(ctrl), http.WithImportMaxBytes(16))
+
+		code, job := do(t, gohttp.MethodPost, srv.URL+"/imports", "application/json",
+			bytes.NewBufferString(importPortsJSON))
+		assert.Equal(t, gohttp.StatusRequestEntityTooLarge, code)
+		assert.Empty(t, job.ID)
+
+		body := &bytes.Buffer{}
+		mw := multipart.NewWriter(body)
+
+		fw, err := mw.CreateFormFile("file", "ports.json")
+		assert.NoError(t, err)
+
+		_, _ = fw.Write([]byte(importPortsJSON))
+		assert.NoError(t, mw.Close())
+
+		code, _ = do(t, gohttp.MethodPost, srv.URL+"/imports", mw.FormDataContentType(), body)
+		assert.Equal(t, gohttp.StatusRequestEntityTooLarge, code)
+	})
+
+	t.Run("decompressed too large", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		srv := newServer(t, porttest.NewMockPortService(ctrl), http.WithImportMaxInputBytes(16))
+
+		body := &bytes.Buffer{}
+		zw := gzip.NewWriter(body)
+
+		_, _ = zw.Write([]byte(importPortsJSON))
+		assert.NoError(t, zw.Close())
+
+		code, job := do(t, gohttp.MethodPost, srv.URL+"/imports", "application/json", body)
+		assert.Equal(t, gohttp.StatusAccepted, code)
+
+		job = waitStatus(t, srv, job.ID, http.ImportFailed)
+		assert.Len(t, job.Errors, 1)
+		assert.Contains(t, job.Errors[0], "input too large: more than 16 bytes")
+	})
+
+	t.Run("too many running", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		started := make(chan struct{})
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		gomock.InOrder(
+			mockedPortSvc.EXPECT().
+				BulkUpsert(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _ domain.Ports) error {
+					close(started)
+					<-ctx.Done()
+					return ctx.Err()
+				}),
+			mockedPortSvc.EXPECT().
+				BulkUpsert(gomock.Any(), gomock.Any()).
+				Return(nil),
+		)
+
+		srv := newServer(t, mockedPortSvc, http.WithImportMaxJobs(1))
+
+		code, first := do(t, gohttp.MethodPost, srv.URL+"/imports", "application/json",
+			bytes.NewBufferString(importPortsJSON))
+		assert.Equal(t, gohttp.StatusAccepted, code)
+
+		<-started
+
+		code, job := do(t, gohttp.MethodPost, srv.URL+"/imports", "application/json",
+			bytes.NewBufferString(importPortsJSON))
+		assert.Equal(t, gohttp.StatusTooManyRequests, code)
+		assert.Empty(t, job.ID)
+
+		code, _ = do(t, gohttp.MethodDelete, srv.URL+"/imports/"+first.ID, "", nil)
+		assert.Equal(t, gohttp.StatusAccepted, code)
+
+		waitStatus(t, srv, first.ID, http.ImportCancelled)
+
+		code, job = do(t, gohttp.MethodPost, srv.URL+"/imports", "application/json",
+			bytes.NewBufferString(importPortsJSON))
+		assert.Equal(t, gohttp.StatusAccepted, code)
+
+		waitStatus(t, srv, job.ID, http.ImportSucceeded)
+	})
+
+	t.Run("finished jobs pruned", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(2)
+
+		srv := newServer(t, mockedPortSvc, http.WithImportJobsTTL(time.Millisecond))
+
+		_, first := do(t, gohttp.MethodPost, srv.URL+"/imports", "application/json",
+			bytes.NewBufferString(importPortsJSON))
+		waitStatus(t, srv, first.ID, http.ImportSucceeded)
+
+		time.Sleep(5 * time.Millisecond)
+
+		_, second := do(t, gohttp.MethodPost, srv.URL+"/imports", "application/json",
+			bytes.NewBufferString(importPortsJSON))
+
+		code, _ := do(t, gohttp.MethodGet, srv.URL+"/imports/"+first.ID, "", nil)
+		assert.Equal(t, gohttp.StatusNotFound, code)
+
+		waitStatus(t, srv, second.ID, http.ImportSucceeded)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		started := make(chan struct{})
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ domain.Ports) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			})
+
+		srv := newServer(t, mockedPortSvc)
+
+		code, job := do(t, gohttp.MethodPost, srv.URL+"/imports", "application/json",
+			bytes.NewBufferString(importPortsJSON))
+		assert.Equal(t, gohttp.StatusAccepted, code)
+
+		<-started
+
+		code, _ = do(t, gohttp.MethodDelete, srv.URL+"/imports/"+job.ID, "", nil)
+		assert.Equal(t, gohttp.StatusAccepted, code)
+
+		waitStatus(t, srv, job.ID, http.ImportCancelled)
+	})
+}
@@ -0,0 +1,174 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/logging"
+)
+
+const (
+	// resolveParam is the query param holding the value a port is resolved
+	// by.
+	resolveParam = "q"
+	// redirectParam is the query param choosing whether looked up ports are
+	// redirected to their canonical URL, by default, or returned as is.
+	redirectParam = "redirect"
+)
+
+// lookupPortHandler answers the port looked up by the value read from the
+// request, either with a redirect to its canonical URL or with the port.
+func lookupPortHandler(
+	lookup func(context.Context, string) (*domain.Port, error),
+	value func(*http.Request) (string, error),
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		view, err := portViewOptions(r)
+		if err != nil {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(err),
+			)
+
+			return
+		}
+
+		redirect := true
+
+		if s := r.URL.Query().Get(redirectParam); s != "" {
+			redirect, err = strconv.ParseBool(s)
+			if err != nil {
+				writeResponse(
+					w,
+					withStatusCode(http.StatusBadRequest),
+					withError(fmt.Errorf("invalid %s '%s': must be a boolean", redirectParam, s)),
+				)
+
+				return
+			}
+		}
+
+		v, err := value(r)
+		if err != nil {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(err),
+			)
+
+			return
+		}
+
+		p, err := lookup(ctx, v)
+		if err != nil {
+			var aErr *port.AmbiguousPortError
+
+			switch {
+			case errors.Is(err, port.ErrPortNotFound):
+				writeResponse(
+					w,
+					withStatusCode(http.StatusNotFound),
+					withError(err),
+				)
+			case errors.As(err, &aErr):
+				writeResponse(
+					w,
+					withStatusCode(http.StatusConflict),
+					withError(err),
+				)
+			default:
+				logger.ErrorContext(ctx,
+					"failed to look port up",
+					logging.Error(err),
+				)
+
+				writeResponse(
+					w,
+					withStatusCode(http.StatusInternalServerError),
+					withError(err),
+				)
+			}
+
+			return
+		}
+
+		location := canonicalPortURL(r, p.ID)
+
+		if redirect {
+			http.Redirect(w, r, location, http.StatusMovedPermanently)
+			return
+		}
+
+		w.Header().Set("Content-Location", location)
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusOK),
+			withBody(p),
+			withPortView(view),
+		)
+	})
+}
+
+// canonicalPortURL returns the URL of the port with the given ID, keeping
+// the view query params of the request.
+func canonicalPortURL(r *http.Request, id string) string {
+	query := r.URL.Query()
+	query.Del(resolveParam)
+	query.Del(redirectParam)
+
+	location := "/ports/" + url.PathEscape(id)
+	if len(query) > 0 {
+		location += "?" + query.Encode()
+	}
+
+	return location
+}
+
+// WithLookupHandlers setup the handlers looking ports up by their secondary
+// keys. They must be set up before WithPortHandlers, otherwise /ports/{id}
+// would match /ports/resolve.
+func WithLookupHandlers(
+	router *mux.Router,
+	lookupSvc port.LookupService,
+	logger *slog.Logger,
+) {
+	pathVar := func(name string) func(*http.Request) (string, error) {
+		return func(r *http.Request) (string, error) {
+			return mux.Vars(r)[name], nil
+		}
+	}
+
+	router.Handle("/ports/by-unloc/{unloc}", lookupPortHandler(lookupSvc.ByUnloc, pathVar("unloc"), logger)).
+		Methods(http.MethodGet).
+		Name("getPortByUnloc")
+
+	router.Handle("/ports/by-code/{code}", lookupPortHandler(lookupSvc.ByCode, pathVar("code"), logger)).
+		Methods(http.MethodGet).
+		Name("getPortByCode")
+
+	resolveValue := func(r *http.Request) (string, error) {
+		q := r.URL.Query().Get(resolveParam)
+		if q == "" {
+			return "", fmt.Errorf("missing query param '%s'", resolveParam)
+		}
+
+		return q, nil
+	}
+
+	router.Handle("/ports/resolve", lookupPortHandler(lookupSvc.Resolve, resolveValue, logger)).
+		Methods(http.MethodGet).
+		Name("resolvePort")
+}
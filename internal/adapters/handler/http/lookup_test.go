@@ -0,0 +1,207 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	gohttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/adapters/handler/http"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupHandlers(t *testing.T) {
+	uslgb := &domain.Port{ID: "USLGB", Name: "Long Beach"}
+
+	tcs := []struct {
+		name               string
+		path               string
+		setupMock          func(svc *porttest.MockLookupService)
+		expectedStatusCode int
+		expectedLocation   string
+		expectedBody       string
+	}{
+		{
+			name: "by unloc redirect",
+			path: "/ports/by-unloc/USLAX",
+			setupMock: func(svc *porttest.MockLookupService) {
+				svc.EXPECT().ByUnloc(gomock.Any(), "USLAX").Return(uslgb, nil)
+			},
+			expectedStatusCode: gohttp.StatusMovedPermanently,
+			expectedLocation:   "/ports/USLGB",
+		},
+		{
+			name: "by code redirect keeps the view",
+			path: "/ports/by-code/2709?coordinates=object",
+			setupMock: func(svc *porttest.MockLookupService) {
+				svc.EXPECT().ByCode(gomock.Any(), "2709").Return(uslgb, nil)
+			},
+			expectedStatusCode: gohttp.StatusMovedPermanently,
+			expectedLocation:   "/ports/USLGB?coordinates=object",
+		},
+		{
+			name: "resolve redirect",
+			path: "/ports/resolve?q=long+beach",
+			setupMock: func(svc *porttest.MockLookupService) {
+				svc.EXPECT().Resolve(gomock.Any(), "long beach").Return(uslgb, nil)
+			},
+			expectedStatusCode: gohttp.StatusMovedPermanently,
+			expectedLocation:   "/ports/USLGB",
+		},
+		{
+			name: "resolve without redirect",
+			path: "/ports/resolve?q=long+beach&redirect=false",
+			setupMock: func(svc *porttest.MockLookupService) {
+				svc.EXPECT().Resolve(gomock.Any(), "long beach").Return(uslgb, nil)
+			},
+			expectedStatusCode: gohttp.StatusOK,
+			expectedLocation:   "/ports/USLGB",
+			expectedBody:       `{"id":"USLGB","name":"Long Beach","city":"","country":"","province":"","timezone":"","code":""}`,
+		},
+		{
+			name:               "resolve without query",
+			path:               "/ports/resolve",
+			setupMock:          func(*porttest.MockLookupService) {},
+			expectedStatusCode: gohttp.StatusBadRequest,
+			expectedBody:       `{"error":{"message":"missing query param 'q'"}}`,
+		},
+		{
+			name:               "invalid redirect",
+			path:               "/ports/by-unloc/USLAX?redirect=maybe",
+			setupMock:          func(*porttest.MockLookupService) {},
+			expectedStatusCode: gohttp.StatusBadRequest,
+			expectedBody:       `{"error":{"message":"invalid redirect 'maybe': must be a boolean"}}`,
+		},
+		{
+			name: "not found",
+			path: "/ports/by-unloc/NLXXX",
+			setupMock: func(svc *porttest.MockLookupService) {
+				svc.EXPECT().ByUnloc(gomock.Any(), "NLXXX").Return(nil, port.ErrPortNotFound)
+			},
+			expectedStatusCode: gohttp.StatusNotFound,
+			expectedBody:       `{"error":{"message":"port not found"}}`,
+		},
+		{
+			name: "ambiguous",
+			path: "/ports/by-code/57000",
+			setupMock: func(svc *porttest.MockLookupService) {
+				svc.EXPECT().ByCode(gomock.Any(), "57000").Return(nil, &port.AmbiguousPortError{
+					Key:   domain.PortKeyCode,
+					Value: "57000",
+					Ports: []string{"CNDAL", "CNDLC"},
+				})
+			},
+			expectedStatusCode: gohttp.StatusConflict,
+			expectedBody:       `{"error":{"message":"code '57000' is claimed by several ports: CNDAL, CNDLC","candidates":["CNDAL","CNDLC"]}}`,
+		},
+		{
+			name: "internal server error",
+			path: "/ports/by-unloc/USLAX",
+			setupMock: func(svc *porttest.MockLookupService) {
+				svc.EXPECT().ByUnloc(gomock.Any(), "USLAX").Return(nil, errors.New("find err"))
+			},
+			expectedStatusCode: gohttp.StatusInternalServerError,
+			expectedBody:       `{"error":{"message":"find err"}}`,
+		},
+	}
+
+	// redirects are checked, instead of being followed
+	client := &gohttp.Client{
+		CheckRedirect: func(*gohttp.Request, []*gohttp.Request) error {
+			return gohttp.ErrUseLastResponse
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockedLookupSvc := porttest.NewMockLookupService(ctrl)
+			tc.setupMock(mockedLookupSvc)
+
+			router := mux.NewRouter()
+			http.WithLookupHandlers(router, mockedLookupSvc, loggerTest)
+			http.WithPortHandlers(router, porttest.NewMockPortService(ctrl), loggerTest)
+
+			srv := httptest.NewServer(router)
+			defer srv.Close()
+
+			req, err := gohttp.NewRequestWithContext(
+				context.Background(),
+				gohttp.MethodGet,
+				srv.URL+tc.path,
+				nil,
+			)
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			if tc.expectedStatusCode == gohttp.StatusMovedPermanently {
+				assert.Equal(t, tc.expectedLocation, resp.Header.Get("Location"))
+				return
+			}
+
+			if tc.expectedLocation != "" {
+				assert.Equal(t, tc.expectedLocation, resp.Header.Get("Content-Location"))
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedBody, string(body))
+		})
+	}
+}
+
+func TestLookupHandlers_FollowRedirect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uslgb := &domain.Port{ID: "USLGB", Name: "Long Beach"}
+
+	mockedLookupSvc := porttest.NewMockLookupService(ctrl)
+	mockedLookupSvc.EXPECT().ByUnloc(gomock.Any(), "USLAX").Return(uslgb, nil)
+
+	mockedPortSvc := porttest.NewMockPortService(ctrl)
+	mockedPortSvc.EXPECT().Get(gomock.Any(), "USLGB").Return(uslgb, nil)
+
+	router := mux.NewRouter()
+	http.WithLookupHandlers(router, mockedLookupSvc, loggerTest)
+	http.WithPortHandlers(router, mockedPortSvc, loggerTest)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	req, err := gohttp.NewRequestWithContext(
+		context.Background(),
+		gohttp.MethodGet,
+		srv.URL+"/ports/by-unloc/USLAX",
+		nil,
+	)
+	require.NoError(t, err)
+
+	resp, err := gohttp.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, gohttp.StatusOK, resp.StatusCode)
+	assert.Equal(t, "/ports/USLGB", resp.Request.URL.Path)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"id":"USLGB"`)
+}
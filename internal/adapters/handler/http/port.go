@@ -3,12 +3,15 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/rafaeltg/goports/internal/core/domain"
 	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/rules"
 	"github.com/rafaeltg/goports/pkg/cid"
 	"github.com/rafaeltg/goports/pkg/logging"
 )
@@ -20,6 +23,17 @@ func getPortHandler(
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := getContext(r)
 
+		view, err := portViewOptions(r)
+		if err != nil {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(err),
+			)
+
+			return
+		}
+
 		id := mux.Vars(r)["id"]
 
 		p, err := portSvc.Get(ctx, id)
@@ -51,6 +65,70 @@ func getPortHandler(
 			w,
 			withStatusCode(http.StatusOK),
 			withBody(p),
+			withPortView(view),
+		)
+	})
+}
+
+func listPortsHandler(
+	portSvc port.PortService,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		view, err := portViewOptions(r)
+		if err != nil {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(err),
+			)
+
+			return
+		}
+
+		offset, filterOffset, err := utcOffset(r)
+		if err != nil {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(err),
+			)
+
+			return
+		}
+
+		ports, err := portSvc.List(ctx)
+		if err != nil {
+			logger.ErrorContext(ctx,
+				"failed to list ports",
+				logging.Error(err),
+			)
+
+			writeResponse(
+				w,
+				withStatusCode(http.StatusInternalServerError),
+				withError(err),
+			)
+
+			return
+		}
+
+		if filterOffset {
+			ports = ports.AtUTCOffset(offset, time.Now())
+		}
+
+		if wantsGeoJSON(r) {
+			writeGeoJSON(w, r, eachPort(ports), logger)
+			return
+		}
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusOK),
+			withBody(ports),
+			withPortView(view),
 		)
 	})
 }
@@ -82,9 +160,21 @@ func bulkUpsertHandler(
 
 		err = portSvc.BulkUpsert(ctx, ports)
 		if err != nil {
+			code := http.StatusInternalServerError
+
+			var (
+				pvErr *domain.PortsValidationError
+				vErr  *rules.ViolationError
+				urErr *port.UnknownRegionsError
+			)
+
+			if errors.As(err, &pvErr) || errors.As(err, &vErr) || errors.As(err, &urErr) {
+				code = http.StatusUnprocessableEntity
+			}
+
 			writeResponse(
 				w,
-				withStatusCode(http.StatusInternalServerError),
+				withStatusCode(code),
 				withError(err),
 			)
 
@@ -98,6 +188,49 @@ func bulkUpsertHandler(
 	})
 }
 
+func bulkDeleteHandler(
+	portSvc port.PortService,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		var ids []string
+
+		err := json.NewDecoder(r.Body).Decode(&ids)
+		if err != nil {
+			logger.ErrorContext(ctx,
+				"failed to decode request body",
+				logging.Error(err),
+			)
+
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(errBadRequest),
+			)
+
+			return
+		}
+
+		err = portSvc.BulkDelete(ctx, ids)
+		if err != nil {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusInternalServerError),
+				withError(err),
+			)
+
+			return
+		}
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusNoContent),
+		)
+	})
+}
+
 func getContext(r *http.Request) context.Context {
 	ctx := context.Background()
 
@@ -109,12 +242,29 @@ func getContext(r *http.Request) context.Context {
 	return ctx
 }
 
+// getRequestContext is like getContext, but it is done when the request is,
+// for the handlers whose work is not worth finishing once the client is gone.
+func getRequestContext(r *http.Request) context.Context {
+	ctx := r.Context()
+
+	corrId, err := cid.FromRequest(r)
+	if err == nil {
+		ctx = cid.NewContext(ctx, corrId)
+	}
+
+	return ctx
+}
+
 // WithPortHandlers setup port API handlers.
 func WithPortHandlers(
 	router *mux.Router,
 	portSvc port.PortService,
 	logger *slog.Logger,
 ) {
+	router.Handle("/ports", listPortsHandler(portSvc, logger)).
+		Methods(http.MethodGet).
+		Name("listPorts")
+
 	router.Handle("/ports/{id}", getPortHandler(portSvc, logger)).
 		Methods(http.MethodGet).
 		Name("getPort")
@@ -122,4 +272,8 @@ func WithPortHandlers(
 	router.Handle("/ports/bulk-upsert", bulkUpsertHandler(portSvc, logger)).
 		Methods(http.MethodPost).
 		Name("bulkUpsertPorts")
+
+	router.Handle("/ports/bulk-delete", bulkDeleteHandler(portSvc, logger)).
+		Methods(http.MethodPost).
+		Name("bulkDeletePorts")
 }
@@ -1,6 +1,7 @@
 package http_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -18,6 +19,7 @@ import (
 	"github.com/rafaeltg/goports/internal/core/domain"
 	"github.com/rafaeltg/goports/internal/core/port"
 	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/rafaeltg/goports/internal/core/rules"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -114,3 +116,225 @@ func TestGetPort(t *testing.T) {
 		})
 	}
 }
+
+func TestListPorts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ports := domain.Ports{
+		{ID: "AEAJM", Name: "Ajman", Coordinates: domain.Coordinates{55.5136433, 25.4052165}},
+		{ID: "AEAUH", Name: "Abu Dhabi"},
+	}
+
+	// the fields which are always rendered, even when empty
+	emptyFields := `"city":"","country":"","province":"","timezone":"","code":""`
+
+	tcs := []struct {
+		name               string
+		query              string
+		listCalled         bool
+		svcPorts           domain.Ports
+		svcError           error
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "internal server error",
+			listCalled:         true,
+			svcError:           errors.New("internal"),
+			expectedStatusCode: gohttp.StatusInternalServerError,
+			expectedBody:       `{"error":{"message":"internal"}}`,
+		},
+		{
+			name:               "empty",
+			listCalled:         true,
+			svcPorts:           domain.Ports{},
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody:       `[]`,
+		},
+		{
+			name:               "success",
+			listCalled:         true,
+			svcPorts:           ports,
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody: `[{"id":"AEAJM","name":"Ajman",` + emptyFields + `,"coordinates":[55.5136433,25.4052165]},` +
+				`{"id":"AEAUH","name":"Abu Dhabi",` + emptyFields + `}]`,
+		},
+		{
+			name:               "coordinates format",
+			query:              "?coordinates=geohash",
+			listCalled:         true,
+			svcPorts:           ports,
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody: `[{"id":"AEAJM","name":"Ajman",` + emptyFields + `,"coordinates":"thx2x0zu1"},` +
+				`{"id":"AEAUH","name":"Abu Dhabi",` + emptyFields + `}]`,
+		},
+		{
+			name:               "invalid coordinates format",
+			query:              "?coordinates=wkt",
+			expectedStatusCode: gohttp.StatusBadRequest,
+			expectedBody:       `{"error":{"message":"unknown coordinates format 'wkt'"}}`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedPortSvc := porttest.NewMockPortService(ctrl)
+			if tc.listCalled {
+				mockedPortSvc.EXPECT().
+					List(gomock.Any()).
+					Return(tc.svcPorts, tc.svcError)
+			}
+
+			router := mux.NewRouter()
+			http.WithPortHandlers(
+				router,
+				mockedPortSvc,
+				loggerTest,
+			)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, "/ports"+tc.query, nil))
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestBulkUpsertPorts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ports := domain.Ports{
+		{
+			ID:   "ABC",
+			Name: "Test",
+		},
+	}
+
+	violations := []rules.Violation{
+		{
+			RuleID:   "city-required",
+			Severity: rules.SeverityError,
+			PortID:   "ABC",
+			Message:  "city is required",
+		},
+	}
+
+	invalidPorts := []domain.InvalidPort{
+		{
+			ID: "ABC",
+			Errors: []domain.FieldError{
+				{Field: "timezone", Message: "unknown timezone 'Mars/Olympus'"},
+			},
+		},
+	}
+
+	tcs := []struct {
+		name               string
+		svcError           error
+		expectedStatusCode int
+		expectedResponse   any
+	}{
+		{
+			name:               "internal server error",
+			svcError:           errors.New("internal"),
+			expectedStatusCode: gohttp.StatusInternalServerError,
+			expectedResponse: http.ErrorResponse{
+				Error: http.ErrorData{
+					Message: "internal",
+				},
+			},
+		},
+		{
+			name:               "rules violated",
+			svcError:           &rules.ViolationError{Violations: violations},
+			expectedStatusCode: gohttp.StatusUnprocessableEntity,
+			expectedResponse: http.ErrorResponse{
+				Error: http.ErrorData{
+					Message:    "rules violated: port 'ABC': city-required: city is required",
+					Violations: violations,
+				},
+			},
+		},
+		{
+			name:               "invalid ports",
+			svcError:           &domain.PortsValidationError{Ports: invalidPorts},
+			expectedStatusCode: gohttp.StatusUnprocessableEntity,
+			expectedResponse: http.ErrorResponse{
+				Error: http.ErrorData{
+					Message:      "invalid ports: port 'ABC': timezone: unknown timezone 'Mars/Olympus'",
+					InvalidPorts: invalidPorts,
+				},
+			},
+		},
+		{
+			name:               "unknown regions",
+			svcError:           &port.UnknownRegionsError{Regions: []string{"north-sea"}},
+			expectedStatusCode: gohttp.StatusUnprocessableEntity,
+			expectedResponse: http.ErrorResponse{
+				Error: http.ErrorData{
+					Message: "unknown regions: north-sea",
+					Regions: []string{"north-sea"},
+				},
+			},
+		},
+		{
+			name:               "success",
+			expectedStatusCode: gohttp.StatusCreated,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedPortSvc := porttest.NewMockPortService(ctrl)
+			mockedPortSvc.EXPECT().
+				BulkUpsert(gomock.Any(), ports).
+				Return(tc.svcError)
+
+			router := mux.NewRouter()
+			http.WithPortHandlers(
+				router,
+				mockedPortSvc,
+				loggerTest,
+			)
+
+			srv := httptest.NewServer(router)
+			defer srv.Close()
+
+			body, err := json.Marshal(ports)
+			assert.NoError(t, err)
+
+			client := &gohttp.Client{}
+			req, err := gohttp.NewRequestWithContext(
+				context.Background(),
+				gohttp.MethodPost,
+				fmt.Sprintf("%s/ports/bulk-upsert", srv.URL),
+				bytes.NewReader(body),
+			)
+			assert.NoError(t, err)
+
+			resp, err := client.Do(req)
+			assert.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			actualResp, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+
+			if tc.expectedResponse == nil {
+				assert.Empty(t, actualResp)
+				return
+			}
+
+			expectedResp, err := json.Marshal(tc.expectedResponse)
+			assert.NoError(t, err)
+
+			// Read all adds an exta \n at the end
+			assert.Equal(t, string(expectedResp), string(actualResp)[:len(actualResp)-1])
+		})
+	}
+}
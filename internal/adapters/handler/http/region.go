@@ -0,0 +1,208 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/logging"
+)
+
+// descendantsParam is the query param adding the ports of the nested regions
+// to the ones of a region.
+const descendantsParam = "descendants"
+
+func listRegionsHandler(
+	regionSvc port.RegionService,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		regions, err := regionSvc.Regions(ctx)
+		if err != nil {
+			logger.ErrorContext(ctx,
+				"failed to list regions",
+				logging.Error(err),
+			)
+
+			writeResponse(
+				w,
+				withStatusCode(http.StatusInternalServerError),
+				withError(err),
+			)
+
+			return
+		}
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusOK),
+			withBody(regions),
+		)
+	})
+}
+
+func replaceRegionsHandler(
+	regionSvc port.RegionService,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		var regions domain.Regions
+
+		err := json.NewDecoder(r.Body).Decode(&regions)
+		if err != nil {
+			logger.ErrorContext(ctx,
+				"failed to decode request body",
+				logging.Error(err),
+			)
+
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(errBadRequest),
+			)
+
+			return
+		}
+
+		err = regionSvc.ReplaceRegions(ctx, regions)
+		if err != nil {
+			var iuErr *port.RegionsInUseError
+
+			switch {
+			case errors.Is(err, port.ErrInvalidRegions):
+				writeResponse(
+					w,
+					withStatusCode(http.StatusUnprocessableEntity),
+					withError(err),
+				)
+			case errors.As(err, &iuErr):
+				writeResponse(
+					w,
+					withStatusCode(http.StatusConflict),
+					withError(err),
+				)
+			default:
+				logger.ErrorContext(ctx,
+					"failed to replace regions",
+					logging.Error(err),
+				)
+
+				writeResponse(
+					w,
+					withStatusCode(http.StatusInternalServerError),
+					withError(err),
+				)
+			}
+
+			return
+		}
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusNoContent),
+		)
+	})
+}
+
+func listRegionPortsHandler(
+	regionSvc port.RegionService,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		view, err := portViewOptions(r)
+		if err != nil {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(err),
+			)
+
+			return
+		}
+
+		descendants := false
+
+		if s := r.URL.Query().Get(descendantsParam); s != "" {
+			descendants, err = strconv.ParseBool(s)
+			if err != nil {
+				writeResponse(
+					w,
+					withStatusCode(http.StatusBadRequest),
+					withError(fmt.Errorf("invalid %s '%s': must be a boolean", descendantsParam, s)),
+				)
+
+				return
+			}
+		}
+
+		id := mux.Vars(r)["id"]
+
+		ports, err := regionSvc.RegionPorts(ctx, id, descendants)
+		if err != nil {
+			switch err {
+			case port.ErrRegionNotFound:
+				writeResponse(
+					w,
+					withStatusCode(http.StatusNotFound),
+					withError(err),
+				)
+			default:
+				logger.ErrorContext(ctx,
+					"failed to list region ports",
+					logging.Error(err),
+				)
+
+				writeResponse(
+					w,
+					withStatusCode(http.StatusInternalServerError),
+					withError(err),
+				)
+			}
+
+			return
+		}
+
+		if wantsGeoJSON(r) {
+			writeGeoJSON(w, r, eachPort(ports), logger)
+			return
+		}
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusOK),
+			withBody(ports),
+			withPortView(view),
+		)
+	})
+}
+
+// WithRegionHandlers setup region API handlers.
+func WithRegionHandlers(
+	router *mux.Router,
+	regionSvc port.RegionService,
+	logger *slog.Logger,
+) {
+	router.Handle("/regions", listRegionsHandler(regionSvc, logger)).
+		Methods(http.MethodGet).
+		Name("listRegions")
+
+	router.Handle("/regions", replaceRegionsHandler(regionSvc, logger)).
+		Methods(http.MethodPut).
+		Name("replaceRegions")
+
+	router.Handle("/regions/{id}/ports", listRegionPortsHandler(regionSvc, logger)).
+		Methods(http.MethodGet).
+		Name("listRegionPorts")
+}
@@ -0,0 +1,189 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	gohttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/adapters/handler/http"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegionHandlers(t *testing.T) {
+	regions := domain.Regions{
+		{ID: "asia", Name: "Asia"},
+		{ID: "gulf", Name: "Persian Gulf", Parent: "asia"},
+	}
+
+	tcs := []struct {
+		name               string
+		method             string
+		path               string
+		body               string
+		setupMock          func(svc *porttest.MockRegionService)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:   "list regions",
+			method: gohttp.MethodGet,
+			path:   "/regions",
+			setupMock: func(svc *porttest.MockRegionService) {
+				svc.EXPECT().Regions(gomock.Any()).Return(regions, nil)
+			},
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody:       `[{"id":"asia","name":"Asia"},{"id":"gulf","name":"Persian Gulf","parent":"asia"}]`,
+		},
+		{
+			name:   "list regions error",
+			method: gohttp.MethodGet,
+			path:   "/regions",
+			setupMock: func(svc *porttest.MockRegionService) {
+				svc.EXPECT().Regions(gomock.Any()).Return(nil, errors.New("list err"))
+			},
+			expectedStatusCode: gohttp.StatusInternalServerError,
+			expectedBody:       `{"error":{"message":"list err"}}`,
+		},
+		{
+			name:   "replace regions",
+			method: gohttp.MethodPut,
+			path:   "/regions",
+			body:   `[{"id":"asia","name":"Asia"},{"id":"gulf","name":"Persian Gulf","parent":"asia"}]`,
+			setupMock: func(svc *porttest.MockRegionService) {
+				svc.EXPECT().ReplaceRegions(gomock.Any(), regions).Return(nil)
+			},
+			expectedStatusCode: gohttp.StatusNoContent,
+		},
+		{
+			name:               "replace regions invalid body",
+			method:             gohttp.MethodPut,
+			path:               "/regions",
+			body:               `{`,
+			setupMock:          func(*porttest.MockRegionService) {},
+			expectedStatusCode: gohttp.StatusBadRequest,
+			expectedBody:       `{"error":{"message":"failed to read request body"}}`,
+		},
+		{
+			name:   "replace invalid regions",
+			method: gohttp.MethodPut,
+			path:   "/regions",
+			body:   `[]`,
+			setupMock: func(svc *porttest.MockRegionService) {
+				svc.EXPECT().
+					ReplaceRegions(gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("%w: %w", port.ErrInvalidRegions, errors.New("region 'gulf': unknown parent 'asia'")))
+			},
+			expectedStatusCode: gohttp.StatusUnprocessableEntity,
+			expectedBody:       `{"error":{"message":"invalid regions: region 'gulf': unknown parent 'asia'"}}`,
+		},
+		{
+			name:   "replace regions in use",
+			method: gohttp.MethodPut,
+			path:   "/regions",
+			body:   `[]`,
+			setupMock: func(svc *porttest.MockRegionService) {
+				svc.EXPECT().
+					ReplaceRegions(gomock.Any(), gomock.Any()).
+					Return(&port.RegionsInUseError{Regions: []string{"gulf"}})
+			},
+			expectedStatusCode: gohttp.StatusConflict,
+			expectedBody:       `{"error":{"message":"regions referenced by ports: gulf","regions":["gulf"]}}`,
+		},
+		{
+			name:   "list region ports",
+			method: gohttp.MethodGet,
+			path:   "/regions/asia/ports",
+			setupMock: func(svc *porttest.MockRegionService) {
+				svc.EXPECT().
+					RegionPorts(gomock.Any(), "asia", false).
+					Return(domain.Ports{{ID: "INBOM", Regions: []string{"asia"}}}, nil)
+			},
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody:       `[{"id":"INBOM","name":"","city":"","country":"","regions":["asia"],"province":"","timezone":"","code":""}]`,
+		},
+		{
+			name:   "list region ports with descendants",
+			method: gohttp.MethodGet,
+			path:   "/regions/asia/ports?descendants=true",
+			setupMock: func(svc *porttest.MockRegionService) {
+				svc.EXPECT().
+					RegionPorts(gomock.Any(), "asia", true).
+					Return(domain.Ports{}, nil)
+			},
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody:       `[]`,
+		},
+		{
+			name:               "list region ports invalid descendants",
+			method:             gohttp.MethodGet,
+			path:               "/regions/asia/ports?descendants=all",
+			setupMock:          func(*porttest.MockRegionService) {},
+			expectedStatusCode: gohttp.StatusBadRequest,
+			expectedBody:       `{"error":{"message":"invalid descendants 'all': must be a boolean"}}`,
+		},
+		{
+			name:   "list region ports not found",
+			method: gohttp.MethodGet,
+			path:   "/regions/europe/ports",
+			setupMock: func(svc *porttest.MockRegionService) {
+				svc.EXPECT().
+					RegionPorts(gomock.Any(), "europe", false).
+					Return(nil, port.ErrRegionNotFound)
+			},
+			expectedStatusCode: gohttp.StatusNotFound,
+			expectedBody:       `{"error":{"message":"region not found"}}`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockedRegionSvc := porttest.NewMockRegionService(ctrl)
+			tc.setupMock(mockedRegionSvc)
+
+			router := mux.NewRouter()
+			http.WithRegionHandlers(router, mockedRegionSvc, loggerTest)
+
+			srv := httptest.NewServer(router)
+			defer srv.Close()
+
+			req, err := gohttp.NewRequestWithContext(
+				context.Background(),
+				tc.method,
+				srv.URL+tc.path,
+				strings.NewReader(tc.body),
+			)
+			require.NoError(t, err)
+
+			resp, err := gohttp.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			if tc.expectedBody == "" {
+				assert.Empty(t, body)
+				return
+			}
+
+			assert.JSONEq(t, tc.expectedBody, string(body))
+		})
+	}
+}
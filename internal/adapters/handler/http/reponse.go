@@ -2,7 +2,12 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/rules"
 )
 
 type (
@@ -28,10 +33,43 @@ func withBody(body any) responseOption {
 
 func withError(err error) responseOption {
 	return func(r *response) {
+		data := ErrorData{
+			Message: err.Error(),
+		}
+
+		var pvErr *domain.PortsValidationError
+		if errors.As(err, &pvErr) {
+			data.InvalidPorts = pvErr.Ports
+		}
+
+		var vErr *rules.ViolationError
+		if errors.As(err, &vErr) {
+			data.Violations = vErr.Violations
+		}
+
+		var uErr *port.UnlocatedPortsError
+		if errors.As(err, &uErr) {
+			data.NotFound = uErr.NotFound
+			data.NoCoordinates = uErr.NoCoordinates
+		}
+
+		var urErr *port.UnknownRegionsError
+		if errors.As(err, &urErr) {
+			data.Regions = urErr.Regions
+		}
+
+		var iuErr *port.RegionsInUseError
+		if errors.As(err, &iuErr) {
+			data.Regions = iuErr.Regions
+		}
+
+		var aErr *port.AmbiguousPortError
+		if errors.As(err, &aErr) {
+			data.Candidates = aErr.Ports
+		}
+
 		r.body = ErrorResponse{
-			Error: ErrorData{
-				Message: err.Error(),
-			},
+			Error: data,
 		}
 	}
 }
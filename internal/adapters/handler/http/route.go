@@ -0,0 +1,83 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/logging"
+)
+
+var errRoutePorts = errors.New("both the from and to ports are required")
+
+func getRouteHandler(
+	routeSvc port.RouteService,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		query := r.URL.Query()
+		from, to := query.Get("from"), query.Get("to")
+
+		if from == "" || to == "" {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(errRoutePorts),
+			)
+
+			return
+		}
+
+		route, err := routeSvc.Route(ctx, from, to)
+		if err != nil {
+			switch err {
+			case port.ErrPortNotFound:
+				writeResponse(
+					w,
+					withStatusCode(http.StatusNotFound),
+					withError(err),
+				)
+			case port.ErrNoCoordinates, port.ErrNoRoute:
+				writeResponse(
+					w,
+					withStatusCode(http.StatusUnprocessableEntity),
+					withError(err),
+				)
+			default:
+				logger.ErrorContext(ctx,
+					"failed to compute route",
+					logging.Error(err),
+				)
+
+				writeResponse(
+					w,
+					withStatusCode(http.StatusInternalServerError),
+					withError(err),
+				)
+			}
+
+			return
+		}
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusOK),
+			withBody(route),
+		)
+	})
+}
+
+// WithRouteHandlers setup sea routes API handlers.
+func WithRouteHandlers(
+	router *mux.Router,
+	routeSvc port.RouteService,
+	logger *slog.Logger,
+) {
+	router.Handle("/routes", getRouteHandler(routeSvc, logger)).
+		Methods(http.MethodGet).
+		Name("getRoute")
+}
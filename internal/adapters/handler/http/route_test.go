@@ -0,0 +1,115 @@
+package http_test
+
+import (
+	"encoding/json"
+	"errors"
+	gohttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/adapters/handler/http"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRoute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	route := &domain.Route{
+		From:       "AEJEA",
+		To:         "NLRTM",
+		DistanceNM: 6643.7,
+		Waypoints:  []string{"suez"},
+		Geometry: &domain.Geometry{
+			Type:        domain.GeoJSONLineString,
+			Coordinates: json.RawMessage(`[[55.06,25.01],[32.6,29.5],[4.05,51.95]]`),
+		},
+	}
+
+	tcs := []struct {
+		name               string
+		path               string
+		callSvc            bool
+		route              *domain.Route
+		svcError           error
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "success",
+			path:               "/routes?from=AEJEA&to=NLRTM",
+			callSvc:            true,
+			route:              route,
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody: `{"from":"AEJEA","to":"NLRTM","distanceNm":6643.7,"waypoints":["suez"],` +
+				`"geometry":{"type":"LineString","coordinates":[[55.06,25.01],[32.6,29.5],[4.05,51.95]]}}` + "\n",
+		},
+		{
+			name:               "missing port",
+			path:               "/routes?from=AEJEA",
+			expectedStatusCode: gohttp.StatusBadRequest,
+			expectedBody:       `{"error":{"message":"both the from and to ports are required"}}` + "\n",
+		},
+		{
+			name:               "port not found",
+			path:               "/routes?from=AEJEA&to=NLXXX",
+			callSvc:            true,
+			svcError:           port.ErrPortNotFound,
+			expectedStatusCode: gohttp.StatusNotFound,
+			expectedBody:       `{"error":{"message":"port not found"}}` + "\n",
+		},
+		{
+			name:               "port without coordinates",
+			path:               "/routes?from=AEJEA&to=XXXXX",
+			callSvc:            true,
+			svcError:           port.ErrNoCoordinates,
+			expectedStatusCode: gohttp.StatusUnprocessableEntity,
+			expectedBody:       `{"error":{"message":"port has no coordinates"}}` + "\n",
+		},
+		{
+			name:               "no route",
+			path:               "/routes?from=AEJEA&to=NLRTM",
+			callSvc:            true,
+			svcError:           port.ErrNoRoute,
+			expectedStatusCode: gohttp.StatusUnprocessableEntity,
+			expectedBody:       `{"error":{"message":"no sea route found"}}` + "\n",
+		},
+		{
+			name:               "internal server error",
+			path:               "/routes?from=AEJEA&to=NLRTM",
+			callSvc:            true,
+			svcError:           errors.New("internal"),
+			expectedStatusCode: gohttp.StatusInternalServerError,
+			expectedBody:       `{"error":{"message":"internal"}}` + "\n",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedRouteSvc := porttest.NewMockRouteService(ctrl)
+			if tc.callSvc {
+				mockedRouteSvc.EXPECT().
+					Route(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(tc.route, tc.svcError)
+			}
+
+			router := mux.NewRouter()
+			http.WithRouteHandlers(
+				router,
+				mockedRouteSvc,
+				loggerTest,
+			)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, tc.path, nil))
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedBody, rec.Body.String())
+		})
+	}
+}
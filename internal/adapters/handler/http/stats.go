@@ -0,0 +1,67 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/logging"
+)
+
+// statsHandler returns the part of the ports stats picked by view.
+func statsHandler(
+	statsSvc port.StatsService,
+	view func(*domain.Stats) any,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		stats, err := statsSvc.Stats(ctx)
+		if err != nil {
+			logger.ErrorContext(ctx,
+				"failed to get ports stats",
+				logging.Error(err),
+			)
+
+			writeResponse(
+				w,
+				withStatusCode(http.StatusInternalServerError),
+				withError(err),
+			)
+
+			return
+		}
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusOK),
+			withBody(view(stats)),
+		)
+	})
+}
+
+// WithStatsHandlers setup stats API handlers.
+func WithStatsHandlers(
+	router *mux.Router,
+	statsSvc port.StatsService,
+	logger *slog.Logger,
+) {
+	router.Handle("/stats", statsHandler(statsSvc, func(s *domain.Stats) any { return s.Summary() }, logger)).
+		Methods(http.MethodGet).
+		Name("getStats")
+
+	router.Handle("/stats/countries", statsHandler(statsSvc, func(s *domain.Stats) any { return s.Countries }, logger)).
+		Methods(http.MethodGet).
+		Name("getCountryStats")
+
+	router.Handle("/stats/regions", statsHandler(statsSvc, func(s *domain.Stats) any { return s.Regions }, logger)).
+		Methods(http.MethodGet).
+		Name("getRegionStats")
+
+	router.Handle("/stats/timezones", statsHandler(statsSvc, func(s *domain.Stats) any { return s.Timezones }, logger)).
+		Methods(http.MethodGet).
+		Name("getTimezoneStats")
+}
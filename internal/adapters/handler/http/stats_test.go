@@ -0,0 +1,114 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	gohttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/adapters/handler/http"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsHandlers(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	stats := &domain.Stats{
+		PortCounts: domain.PortCounts{Ports: 3, MissingCoordinates: 1, UpdatedAt: &updatedAt},
+		Countries: []domain.CountryStats{
+			{Country: "AE", PortCounts: domain.PortCounts{Ports: 2, UpdatedAt: &updatedAt}},
+			{Country: "BR", PortCounts: domain.PortCounts{Ports: 1, MissingCoordinates: 1, UpdatedAt: &updatedAt}},
+		},
+		Regions: []domain.RegionStats{
+			{Region: "Gulf", PortCounts: domain.PortCounts{Ports: 2, UpdatedAt: &updatedAt}},
+		},
+		Timezones: []domain.TimezoneStats{},
+	}
+
+	tcs := []struct {
+		name               string
+		path               string
+		err                error
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "summary",
+			path:               "/stats",
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody:       `{"ports":3,"missingCoordinates":1,"updatedAt":"2024-01-15T10:00:00Z","countries":2,"regions":1,"timezones":0}`,
+		},
+		{
+			name:               "countries",
+			path:               "/stats/countries",
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody:       `[{"country":"AE","ports":2,"missingCoordinates":0,"updatedAt":"2024-01-15T10:00:00Z"},{"country":"BR","ports":1,"missingCoordinates":1,"updatedAt":"2024-01-15T10:00:00Z"}]`,
+		},
+		{
+			name:               "regions",
+			path:               "/stats/regions",
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody:       `[{"region":"Gulf","ports":2,"missingCoordinates":0,"updatedAt":"2024-01-15T10:00:00Z"}]`,
+		},
+		{
+			name:               "timezones",
+			path:               "/stats/timezones",
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody:       `[]`,
+		},
+		{
+			name:               "internal server error",
+			path:               "/stats",
+			err:                errors.New("stats err"),
+			expectedStatusCode: gohttp.StatusInternalServerError,
+			expectedBody:       `{"error":{"message":"stats err"}}`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockedStatsSvc := porttest.NewMockStatsService(ctrl)
+			if tc.err != nil {
+				mockedStatsSvc.EXPECT().Stats(gomock.Any()).Return(nil, tc.err)
+			} else {
+				mockedStatsSvc.EXPECT().Stats(gomock.Any()).Return(stats, nil)
+			}
+
+			router := mux.NewRouter()
+			http.WithStatsHandlers(router, mockedStatsSvc, loggerTest)
+
+			srv := httptest.NewServer(router)
+			defer srv.Close()
+
+			req, err := gohttp.NewRequestWithContext(
+				context.Background(),
+				gohttp.MethodGet,
+				srv.URL+tc.path,
+				nil,
+			)
+			require.NoError(t, err)
+
+			resp, err := gohttp.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedBody, string(body))
+		})
+	}
+}
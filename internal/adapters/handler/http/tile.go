@@ -0,0 +1,79 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/logging"
+	"github.com/rafaeltg/goports/pkg/mvt"
+)
+
+func getTileHandler(
+	tileSvc port.TileService,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		vars := mux.Vars(r)
+
+		// the route only matches digits, so only too large numbers fail
+		z, zErr := strconv.Atoi(vars["z"])
+		x, xErr := strconv.Atoi(vars["x"])
+		y, yErr := strconv.Atoi(vars["y"])
+
+		if zErr != nil || xErr != nil || yErr != nil {
+			writeResponse(
+				w,
+				withStatusCode(http.StatusBadRequest),
+				withError(port.ErrInvalidTile),
+			)
+
+			return
+		}
+
+		tile, err := tileSvc.Tile(ctx, z, x, y)
+		if err != nil {
+			switch err {
+			case port.ErrInvalidTile:
+				writeResponse(
+					w,
+					withStatusCode(http.StatusBadRequest),
+					withError(err),
+				)
+			default:
+				logger.ErrorContext(ctx,
+					"failed to render tile",
+					logging.Error(err),
+				)
+
+				writeResponse(
+					w,
+					withStatusCode(http.StatusInternalServerError),
+					withError(err),
+				)
+			}
+
+			return
+		}
+
+		w.Header().Set("Content-Type", mvt.ContentType)
+		w.WriteHeader(http.StatusOK)
+
+		_, _ = w.Write(tile)
+	})
+}
+
+// WithTileHandlers setup vector tiles API handlers.
+func WithTileHandlers(
+	router *mux.Router,
+	tileSvc port.TileService,
+	logger *slog.Logger,
+) {
+	router.Handle("/tiles/{z:[0-9]+}/{x:[0-9]+}/{y:[0-9]+}.mvt", getTileHandler(tileSvc, logger)).
+		Methods(http.MethodGet).
+		Name("getTile")
+}
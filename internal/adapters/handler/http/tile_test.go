@@ -0,0 +1,92 @@
+package http_test
+
+import (
+	"errors"
+	gohttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/adapters/handler/http"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tcs := []struct {
+		name                string
+		path                string
+		tile                []byte
+		svcError            error
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "success",
+			path:                "/tiles/8/167/109.mvt",
+			tile:                []byte("tile"),
+			expectedStatusCode:  gohttp.StatusOK,
+			expectedContentType: "application/vnd.mapbox-vector-tile",
+			expectedBody:        "tile",
+		},
+		{
+			name:                "invalid tile",
+			path:                "/tiles/1/2/0.mvt",
+			svcError:            port.ErrInvalidTile,
+			expectedStatusCode:  gohttp.StatusBadRequest,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":{"message":"invalid tile"}}` + "\n",
+		},
+		{
+			name:                "internal server error",
+			path:                "/tiles/0/0/0.mvt",
+			svcError:            errors.New("internal"),
+			expectedStatusCode:  gohttp.StatusInternalServerError,
+			expectedContentType: "application/json",
+			expectedBody:        `{"error":{"message":"internal"}}` + "\n",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedTileSvc := porttest.NewMockTileService(ctrl)
+			mockedTileSvc.EXPECT().
+				Tile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(tc.tile, tc.svcError)
+
+			router := mux.NewRouter()
+			http.WithTileHandlers(
+				router,
+				mockedTileSvc,
+				loggerTest,
+			)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, tc.path, nil))
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.Equal(t, tc.expectedContentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, tc.expectedBody, rec.Body.String())
+		})
+	}
+
+	t.Run("not a tile path", func(t *testing.T) {
+		router := mux.NewRouter()
+		http.WithTileHandlers(
+			router,
+			porttest.NewMockTileService(ctrl),
+			loggerTest,
+		)
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, "/tiles/a/0/0.mvt", nil))
+
+		assert.Equal(t, gohttp.StatusNotFound, rec.Code)
+	})
+}
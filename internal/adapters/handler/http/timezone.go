@@ -0,0 +1,73 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/logging"
+)
+
+// utcOffsetParam is the query param filtering the ports by the current UTC
+// offset of their timezone.
+const utcOffsetParam = "utcOffset"
+
+// utcOffset returns the UTC offset, in seconds, the ports are requested to
+// be at, if any.
+func utcOffset(r *http.Request) (int, bool, error) {
+	query := r.URL.Query()
+	if !query.Has(utcOffsetParam) {
+		return 0, false, nil
+	}
+
+	offset, err := domain.ParseUTCOffset(query.Get(utcOffsetParam))
+	if err != nil {
+		return 0, false, err
+	}
+
+	return offset, true, nil
+}
+
+func listTimezonesHandler(
+	timezoneSvc port.TimezoneService,
+	logger *slog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := getContext(r)
+
+		timezones, err := timezoneSvc.Timezones(ctx)
+		if err != nil {
+			logger.ErrorContext(ctx,
+				"failed to list timezones",
+				logging.Error(err),
+			)
+
+			writeResponse(
+				w,
+				withStatusCode(http.StatusInternalServerError),
+				withError(err),
+			)
+
+			return
+		}
+
+		writeResponse(
+			w,
+			withStatusCode(http.StatusOK),
+			withBody(timezones),
+		)
+	})
+}
+
+// WithTimezoneHandlers setup timezone API handlers.
+func WithTimezoneHandlers(
+	router *mux.Router,
+	timezoneSvc port.TimezoneService,
+	logger *slog.Logger,
+) {
+	router.Handle("/timezones", listTimezonesHandler(timezoneSvc, logger)).
+		Methods(http.MethodGet).
+		Name("listTimezones")
+}
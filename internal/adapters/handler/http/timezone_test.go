@@ -0,0 +1,265 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	gohttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/adapters/handler/http"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTimezonesHandler(t *testing.T) {
+	tcs := []struct {
+		name               string
+		setupMock          func(svc *porttest.MockTimezoneService)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "success",
+			setupMock: func(svc *porttest.MockTimezoneService) {
+				svc.EXPECT().
+					Timezones(gomock.Any()).
+					Return([]domain.TimezoneSummary{
+						{
+							Timezone: "Asia/Dubai",
+							LocalTime: domain.LocalTime{
+								UTCOffset:     "+04:00",
+								LocalTime:     "2024-01-15T10:00:00+04:00",
+								BusinessHours: true,
+							},
+							Ports: 2,
+						},
+					}, nil)
+			},
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody:       `[{"timezone":"Asia/Dubai","utcOffset":"+04:00","dst":false,"localTime":"2024-01-15T10:00:00+04:00","businessHours":true,"ports":2}]`,
+		},
+		{
+			name: "internal server error",
+			setupMock: func(svc *porttest.MockTimezoneService) {
+				svc.EXPECT().
+					Timezones(gomock.Any()).
+					Return(nil, errors.New("list err"))
+			},
+			expectedStatusCode: gohttp.StatusInternalServerError,
+			expectedBody:       `{"error":{"message":"list err"}}`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockedTimezoneSvc := porttest.NewMockTimezoneService(ctrl)
+			tc.setupMock(mockedTimezoneSvc)
+
+			router := mux.NewRouter()
+			http.WithTimezoneHandlers(router, mockedTimezoneSvc, loggerTest)
+
+			srv := httptest.NewServer(router)
+			defer srv.Close()
+
+			req, err := gohttp.NewRequestWithContext(
+				context.Background(),
+				gohttp.MethodGet,
+				srv.URL+"/timezones",
+				nil,
+			)
+			require.NoError(t, err)
+
+			resp, err := gohttp.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedBody, string(body))
+		})
+	}
+}
+
+func TestListPortsHandler_UTCOffset(t *testing.T) {
+	// neither Dubai nor Kolkata have daylight saving time
+	ports := domain.Ports{
+		{ID: "AEAJM", Timezone: "Asia/Dubai"},
+		{ID: "INBOM", Timezone: "Asia/Kolkata"},
+		{ID: "XXXXX"},
+	}
+
+	tcs := []struct {
+		name               string
+		query              string
+		expectedStatusCode int
+		expectedIDs        []string
+		expectedBody       string
+	}{
+		{
+			name:               "escaped plus sign",
+			query:              "?utcOffset=%2B04:00",
+			expectedStatusCode: gohttp.StatusOK,
+			expectedIDs:        []string{"AEAJM"},
+		},
+		{
+			name:               "unescaped plus sign",
+			query:              "?utcOffset=+0530",
+			expectedStatusCode: gohttp.StatusOK,
+			expectedIDs:        []string{"INBOM"},
+		},
+		{
+			name:               "no port at the offset",
+			query:              "?utcOffset=-03:00",
+			expectedStatusCode: gohttp.StatusOK,
+			expectedIDs:        []string{},
+		},
+		{
+			name:               "invalid offset",
+			query:              "?utcOffset=abc",
+			expectedStatusCode: gohttp.StatusBadRequest,
+			expectedBody:       `{"error":{"message":"invalid UTC offset 'abc'"}}`,
+		},
+		{
+			name:               "offset out of range",
+			query:              "?utcOffset=%2B15:00",
+			expectedStatusCode: gohttp.StatusBadRequest,
+			expectedBody:       `{"error":{"message":"UTC offset '+15:00' out of range"}}`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockedPortSvc := porttest.NewMockPortService(ctrl)
+			mockedPortSvc.EXPECT().
+				List(gomock.Any()).
+				Return(ports, nil).
+				MaxTimes(1)
+
+			router := mux.NewRouter()
+			http.WithPortHandlers(router, mockedPortSvc, loggerTest)
+
+			srv := httptest.NewServer(router)
+			defer srv.Close()
+
+			req, err := gohttp.NewRequestWithContext(
+				context.Background(),
+				gohttp.MethodGet,
+				srv.URL+"/ports"+tc.query,
+				nil,
+			)
+			require.NoError(t, err)
+
+			resp, err := gohttp.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, string(body))
+				return
+			}
+
+			var got []struct {
+				ID string `json:"id"`
+			}
+			require.NoError(t, json.Unmarshal(body, &got))
+
+			ids := []string{}
+			for _, p := range got {
+				ids = append(ids, p.ID)
+			}
+
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestLocalTimeView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ports := domain.Ports{
+		{ID: "AEAJM", Timezone: "Asia/Dubai"},
+		{ID: "XXXXX"},
+	}
+
+	mockedPortSvc := porttest.NewMockPortService(ctrl)
+	mockedPortSvc.EXPECT().
+		List(gomock.Any()).
+		Return(ports, nil).
+		Times(2)
+
+	router := mux.NewRouter()
+	http.WithPortHandlers(router, mockedPortSvc, loggerTest)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	get := func(t *testing.T, path string) (int, []byte) {
+		req, err := gohttp.NewRequestWithContext(context.Background(), gohttp.MethodGet, srv.URL+path, nil)
+		require.NoError(t, err)
+
+		resp, err := gohttp.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, body
+	}
+
+	t.Run("with local time", func(t *testing.T) {
+		statusCode, body := get(t, "/ports?localTime=true")
+		assert.Equal(t, gohttp.StatusOK, statusCode)
+
+		var got []struct {
+			ID   string            `json:"id"`
+			Time *domain.LocalTime `json:"time"`
+		}
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.Len(t, got, 2)
+
+		require.NotNil(t, got[0].Time)
+		assert.Equal(t, "+04:00", got[0].Time.UTCOffset)
+		assert.False(t, got[0].Time.DST)
+		assert.NotEmpty(t, got[0].Time.LocalTime)
+
+		// ports without a timezone have no local time
+		assert.Nil(t, got[1].Time)
+	})
+
+	t.Run("without local time", func(t *testing.T) {
+		statusCode, body := get(t, "/ports?localTime=false")
+		assert.Equal(t, gohttp.StatusOK, statusCode)
+		assert.NotContains(t, string(body), `"time"`)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		statusCode, body := get(t, "/ports?localTime=maybe")
+		assert.Equal(t, gohttp.StatusBadRequest, statusCode)
+		assert.JSONEq(t, `{"error":{"message":"invalid localTime 'maybe': must be a boolean"}}`, string(body))
+	})
+}
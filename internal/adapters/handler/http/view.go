@@ -0,0 +1,101 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+)
+
+const (
+	// coordinatesParam is the query param setting how the port coordinates
+	// are rendered.
+	coordinatesParam = "coordinates"
+	// localTimeParam is the query param adding the current local time of
+	// the ports.
+	localTimeParam = "localTime"
+)
+
+type (
+	// portView is a port whose coordinates are rendered in a given format,
+	// along with its current local time, when requested.
+	portView struct {
+		domain.Port
+		Coordinates any               `json:"coordinates,omitempty"`
+		Time        *domain.LocalTime `json:"time,omitempty"`
+	}
+
+	// viewOptions sets how ports are rendered.
+	viewOptions struct {
+		format domain.CoordinatesFormat
+		// now, when set, is the time the local time of the ports is given at.
+		now *time.Time
+	}
+)
+
+// portViewOptions returns the view options requested: the coordinates
+// format, the array one by default, and whether the local time is added.
+func portViewOptions(r *http.Request) (viewOptions, error) {
+	query := r.URL.Query()
+
+	format, err := domain.ParseCoordinatesFormat(query.Get(coordinatesParam))
+	if err != nil {
+		return viewOptions{}, err
+	}
+
+	opts := viewOptions{format: format}
+
+	if s := query.Get(localTimeParam); s != "" {
+		localTime, err := strconv.ParseBool(s)
+		if err != nil {
+			return viewOptions{}, fmt.Errorf("invalid %s '%s': must be a boolean", localTimeParam, s)
+		}
+
+		if localTime {
+			now := time.Now()
+			opts.now = &now
+		}
+	}
+
+	return opts, nil
+}
+
+// withPortView renders the port, or ports, body as requested. It must
+// follow withBody.
+func withPortView(opts viewOptions) responseOption {
+	return func(r *response) {
+		if opts.format == domain.CoordinatesArray && opts.now == nil {
+			return
+		}
+
+		switch body := r.body.(type) {
+		case *domain.Port:
+			r.body = newPortView(body, opts)
+		case domain.Ports:
+			views := make([]portView, len(body))
+			for idx := range body {
+				views[idx] = newPortView(&body[idx], opts)
+			}
+
+			r.body = views
+		}
+	}
+}
+
+func newPortView(p *domain.Port, opts viewOptions) portView {
+	v := portView{
+		Port:        *p,
+		Coordinates: p.Coordinates.Format(opts.format),
+	}
+
+	if opts.now != nil {
+		// ports with a missing or unknown timezone have no local time
+		if t, ok := p.LocalTimeAt(*opts.now); ok {
+			v.Time = &t
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,107 @@
+package http_test
+
+import (
+	"context"
+	"io"
+	gohttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/rafaeltg/goports/internal/adapters/handler/http"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoordinatesFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ports := domain.Ports{
+		{ID: "AEAJM", Coordinates: domain.NewCoordinates(25.4052165, 55.5136433)},
+		{ID: "AEAUH"},
+	}
+
+	tcs := []struct {
+		name               string
+		path               string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "array by default",
+			path:               "/ports/AEAJM",
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody:       `"coordinates":[55.5136433,25.4052165]`,
+		},
+		{
+			name:               "object",
+			path:               "/ports/AEAJM?coordinates=object",
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody:       `"coordinates":{"lat":25.4052165,"lon":55.5136433}`,
+		},
+		{
+			name:               "dms",
+			path:               "/ports/AEAJM?coordinates=dms",
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody:       `"coordinates":"25°24'18.78\"N 55°30'49.12\"E"`,
+		},
+		{
+			name:               "geohash list",
+			path:               "/ports?coordinates=geohash",
+			expectedStatusCode: gohttp.StatusOK,
+			expectedBody:       `[{"id":"AEAJM","name":"","city":"","country":"","province":"","timezone":"","code":"","coordinates":"thx2x0zu1"},{"id":"AEAUH","name":"","city":"","country":"","province":"","timezone":"","code":""}]`,
+		},
+		{
+			name:               "unknown format",
+			path:               "/ports?coordinates=wkt",
+			expectedStatusCode: gohttp.StatusBadRequest,
+			expectedBody:       `{"error":{"message":"unknown coordinates format 'wkt'"}}`,
+		},
+	}
+
+	mockedPortSvc := porttest.NewMockPortService(ctrl)
+	mockedPortSvc.EXPECT().
+		Get(gomock.Any(), "AEAJM").
+		Return(&ports[0], nil).
+		Times(3)
+	mockedPortSvc.EXPECT().
+		List(gomock.Any()).
+		Return(ports, nil)
+
+	router := mux.NewRouter()
+	http.WithPortHandlers(
+		router,
+		mockedPortSvc,
+		loggerTest,
+	)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := gohttp.NewRequestWithContext(
+				context.Background(),
+				gohttp.MethodGet,
+				srv.URL+tc.path,
+				nil,
+			)
+			assert.NoError(t, err)
+
+			resp, err := gohttp.DefaultClient.Do(req)
+			assert.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package ingest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	minBatchSizeDefault   = 1
+	maxBatchSizeDefault   = 1000
+	maxConcurrencyDefault = 8
+	targetLatencyDefault  = 500 * time.Millisecond
+	retryBackoffDefault   = 100 * time.Millisecond
+)
+
+type (
+	// AdaptiveOptions sets the bounds within which the batch size and the
+	// number of concurrent batches are adjusted.
+	AdaptiveOptions struct {
+		MinBatchSize   int
+		MaxBatchSize   int
+		MaxConcurrency int
+		// TargetLatency is the batch latency above which the server is
+		// considered overloaded.
+		TargetLatency time.Duration
+		// MaxRetries is the number of times a failed batch is retried.
+		MaxRetries int
+		// RetryBackoff is the wait before the first retry, doubled on each one.
+		RetryBackoff time.Duration
+	}
+
+	// controller adjusts the batch size and concurrency AIMD-style: both grow
+	// additively while batches succeed within the target latency, and are
+	// halved when a batch fails or is too slow.
+	controller struct {
+		opts AdaptiveOptions
+		step int
+
+		mu          sync.Mutex
+		size        int
+		concurrency int
+		inflight    int
+		// changed is closed, and replaced, whenever a slot may be available.
+		changed chan struct{}
+	}
+)
+
+func newController(opts AdaptiveOptions, batchSize int) *controller {
+	if opts.MinBatchSize <= 0 {
+		opts.MinBatchSize = minBatchSizeDefault
+	}
+
+	if opts.MaxBatchSize <= 0 {
+		opts.MaxBatchSize = maxBatchSizeDefault
+	}
+
+	opts.MaxBatchSize = max(opts.MaxBatchSize, opts.MinBatchSize)
+
+	if opts.MaxConcurrency <= 0 {
+		opts.MaxConcurrency = maxConcurrencyDefault
+	}
+
+	if opts.TargetLatency <= 0 {
+		opts.TargetLatency = targetLatencyDefault
+	}
+
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = retryBackoffDefault
+	}
+
+	size := min(max(batchSize, opts.MinBatchSize), opts.MaxBatchSize)
+
+	return &controller{
+		opts:        opts,
+		step:        size,
+		size:        size,
+		concurrency: 1,
+		changed:     make(chan struct{}),
+	}
+}
+
+// settings returns the current batch size and concurrency.
+func (c *controller) settings() (size, concurrency int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size, c.concurrency
+}
+
+// observe adjusts the settings according to the outcome of a batch.
+func (c *controller) observe(latency time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil || latency > c.opts.TargetLatency {
+		c.size = max(c.size/2, c.opts.MinBatchSize)
+		c.concurrency = max(c.concurrency/2, 1)
+
+		return
+	}
+
+	c.size = min(c.size+c.step, c.opts.MaxBatchSize)
+	c.concurrency = min(c.concurrency+1, c.opts.MaxConcurrency)
+	c.notify()
+}
+
+// acquire waits for a batch slot to be available.
+func (c *controller) acquire(ctx context.Context) error {
+	for {
+		c.mu.Lock()
+
+		if c.inflight < c.concurrency {
+			c.inflight++
+			c.mu.Unlock()
+
+			return nil
+		}
+
+		changed := c.changed
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// release frees a batch slot.
+func (c *controller) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.inflight--
+	c.notify()
+}
+
+// notify wakes up the goroutines waiting for a slot. It must be called with
+// the lock held.
+func (c *controller) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// backoff waits before the given retry attempt, starting from 1.
+func (c *controller) backoff(ctx context.Context, attempt int) error {
+	t := time.NewTimer(c.opts.RetryBackoff << (attempt - 1))
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// waitRate waits until n ports can be sent within the rate limit, if any.
+func (i *PortIngestor) waitRate(ctx context.Context, n int) error {
+	if i.limiter == nil {
+		return nil
+	}
+
+	for n > 0 {
+		k := min(n, i.limiter.Burst())
+		if err := i.limiter.WaitN(ctx, k); err != nil {
+			return err
+		}
+
+		n -= k
+	}
+
+	return nil
+}
+
+// WithAdaptive enables adjusting the batch size and the number of concurrent
+// batches to the observed batch latency and errors. The batch size set by
+// WithBatchSize is used as the starting size. Failed batches are retried.
+func WithAdaptive(opts AdaptiveOptions) PortIngestorOption {
+	return func(pi *PortIngestor) {
+		pi.adaptive = &opts
+	}
+}
+
+// WithRateLimit limits the number of ports sent per second. Zero or less
+// disables the limit.
+func WithRateLimit(portsPerSecond float64) PortIngestorOption {
+	return func(pi *PortIngestor) {
+		if portsPerSecond <= 0 {
+			pi.limiter = nil
+			return
+		}
+
+		// up to one second worth of ports can be sent at once
+		burst := max(int(portsPerSecond), 1)
+		pi.limiter = rate.NewLimiter(rate.Limit(portsPerSecond), burst)
+	}
+}
+
+// WithRateLimiter limits the ports sent with the given limiter, each port
+// taking a token, instead of the one set by WithRateLimit.
+func WithRateLimiter(l *rate.Limiter) PortIngestorOption {
+	return func(pi *PortIngestor) {
+		pi.limiter = l
+	}
+}
@@ -0,0 +1,229 @@
+package ingest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/rafaeltg/goports/internal/core/rules"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestAdaptive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("grows on success", func(t *testing.T) {
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(gomock.Any(), gomock.Any()).
+			Return(nil).
+			MinTimes(1)
+
+		var last ingest.Progress
+
+		ingestor := ingest.NewPortIngestor(
+			mockedPortSvc,
+			loggerTest,
+			ingest.WithBatchSize(1),
+			ingest.WithAdaptive(ingest.AdaptiveOptions{
+				MaxBatchSize:   3,
+				MaxConcurrency: 2,
+				TargetLatency:  time.Second,
+			}),
+			ingest.WithProgress(func(p ingest.Progress) { last = p }, time.Hour),
+		)
+
+		err := ingestor.Process(context.Background(), "testdata/ports_valid.json")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), last.PortsDecoded)
+		assert.Equal(t, last.BatchesSent, last.BatchesAcked)
+		assert.Greater(t, last.BatchSize, 1)
+		assert.LessOrEqual(t, last.BatchSize, 3)
+		assert.Equal(t, 2, last.Concurrency)
+	})
+
+	t.Run("retries failed batches", func(t *testing.T) {
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		gomock.InOrder(
+			mockedPortSvc.EXPECT().
+				BulkUpsert(gomock.Any(), gomock.Any()).
+				Return(errors.New("some error")),
+			mockedPortSvc.EXPECT().
+				BulkUpsert(gomock.Any(), gomock.Any()).
+				Return(nil),
+		)
+
+		reportPath := filepath.Join(t.TempDir(), "report.json")
+
+		ingestor := ingest.NewPortIngestor(
+			mockedPortSvc,
+			loggerTest,
+			ingest.WithBatchSize(10),
+			ingest.WithReportPath(reportPath),
+			ingest.WithAdaptive(ingest.AdaptiveOptions{
+				MaxRetries:   1,
+				RetryBackoff: time.Millisecond,
+			}),
+		)
+
+		err := ingestor.Process(context.Background(), "testdata/ports_valid.json")
+		assert.NoError(t, err)
+
+		report := readRunReport(t, reportPath)
+		assert.True(t, report.Succeeded)
+		assert.Equal(t, int64(1), report.Retries)
+		assert.Equal(t, int64(4), report.PortsUpserted)
+	})
+
+	t.Run("shrinks and gives up on errors", func(t *testing.T) {
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(gomock.Any(), gomock.Any()).
+			Return(errors.New("some error")).
+			Times(3)
+
+		var last ingest.Progress
+
+		ingestor := ingest.NewPortIngestor(
+			mockedPortSvc,
+			loggerTest,
+			ingest.WithBatchSize(10),
+			ingest.WithAdaptive(ingest.AdaptiveOptions{
+				MinBatchSize: 2,
+				MaxRetries:   2,
+				RetryBackoff: time.Millisecond,
+			}),
+			ingest.WithProgress(func(p ingest.Progress) { last = p }, time.Hour),
+		)
+
+		err := ingestor.Process(context.Background(), "testdata/ports_valid.json")
+		assert.EqualError(t, err, "some error")
+		assert.Equal(t, 2, last.BatchSize)
+		assert.Equal(t, 1, last.Concurrency)
+	})
+}
+
+func TestAdaptive_Rejected(t *testing.T) {
+	tcs := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "invalid ports",
+			err: &domain.PortsValidationError{Ports: []domain.InvalidPort{
+				{ID: "AEAJM", Errors: []domain.FieldError{{Field: "name", Message: "is required"}}},
+			}},
+		},
+		{
+			name: "rules violated",
+			err:  &rules.ViolationError{Violations: []rules.Violation{{RuleID: "city-required", PortID: "AEAJM"}}},
+		},
+		{
+			name: "client error",
+			err:  fmt.Errorf("bad request: %w", port.ErrPortsRejected),
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// neither retried nor shrinking the batches
+			mockedPortSvc := porttest.NewMockPortService(ctrl)
+			mockedPortSvc.EXPECT().
+				BulkUpsert(gomock.Any(), gomock.Any()).
+				Return(tc.err).
+				Times(1)
+
+			var last ingest.Progress
+
+			ingestor := ingest.NewPortIngestor(
+				mockedPortSvc,
+				loggerTest,
+				ingest.WithBatchSize(10),
+				ingest.WithAdaptive(ingest.AdaptiveOptions{
+					MinBatchSize: 2,
+					MaxRetries:   2,
+					RetryBackoff: time.Millisecond,
+				}),
+				ingest.WithProgress(func(p ingest.Progress) { last = p }, time.Hour),
+			)
+
+			err := ingestor.Process(context.Background(), "testdata/ports_valid.json")
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, 10, last.BatchSize)
+			assert.Equal(t, int64(1), last.BatchesSent)
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Run("limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(4)
+
+		var last ingest.Progress
+
+		ingestor := ingest.NewPortIngestor(
+			mockedPortSvc,
+			loggerTest,
+			ingest.WithBatchSize(1),
+			ingest.WithRateLimit(4),
+			ingest.WithProgress(func(p ingest.Progress) { last = p }, time.Hour),
+		)
+
+		err := ingestor.Process(context.Background(), "testdata/ports_valid.json")
+		assert.NoError(t, err)
+		assert.Equal(t, 4.0, last.RateLimit)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(4)
+
+		// a token a day, so that only the burst is ever available
+		limiter := rate.NewLimiter(rate.Every(24*time.Hour), 4)
+
+		ingestor := ingest.NewPortIngestor(
+			mockedPortSvc,
+			loggerTest,
+			ingest.WithBatchSize(1),
+			ingest.WithRateLimiter(limiter),
+		)
+
+		// the burst is enough for the 4 ports, each taking a token
+		err := ingestor.Process(context.Background(), "testdata/ports_valid.json")
+		assert.NoError(t, err)
+		assert.InDelta(t, 0, limiter.Tokens(), 0.01)
+
+		// the next ports would only be sent long after the deadline
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		err = ingestor.Process(ctx, "testdata/ports_valid.json")
+		assert.ErrorContains(t, err, "would exceed context deadline")
+	})
+}
@@ -0,0 +1,216 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/rules"
+	"github.com/rafaeltg/goports/pkg/logging"
+)
+
+type (
+	// DryRunReport is the outcome of checking an input without ingesting it.
+	DryRunReport struct {
+		// Records is the number of records read, including malformed ones.
+		Records    int       `json:"records"`
+		Upserts    int       `json:"upserts"`
+		Deletes    int       `json:"deletes"`
+		Valid      int       `json:"valid"`
+		Invalid    int       `json:"invalid"`
+		Malformed  int       `json:"malformed"`
+		Duplicates int       `json:"duplicates"`
+		Warnings   int       `json:"warnings"`
+		Problems   []Problem `json:"problems,omitempty"`
+	}
+
+	// Problem is an issue found on the input.
+	Problem struct {
+		Key     string `json:"key,omitempty"`
+		Path    string `json:"path,omitempty"`
+		Line    int    `json:"line,omitempty"`
+		Column  int    `json:"column,omitempty"`
+		Message string `json:"message"`
+		// Rule and Severity are set on rules violations.
+		Rule     string         `json:"rule,omitempty"`
+		Severity rules.Severity `json:"severity,omitempty"`
+	}
+)
+
+// Clean reports whether no problems, other than warn severity rules
+// violations, were found.
+func (r *DryRunReport) Clean() bool {
+	return len(r.Problems) == r.Warnings
+}
+
+func (r *DryRunReport) addProblem(key, path string, pos position, msg string) {
+	r.Problems = append(r.Problems, Problem{
+		Key:     key,
+		Path:    path,
+		Line:    pos.Line,
+		Column:  pos.Column,
+		Message: msg,
+	})
+}
+
+func (e *recordError) problem() Problem {
+	return Problem{
+		Key:     e.key,
+		Path:    e.path,
+		Line:    e.pos.Line,
+		Column:  e.pos.Column,
+		Message: e.Error(),
+	}
+}
+
+// DryRun reads the whole input as Process does, validating each port and
+// looking for malformed records and duplicated keys, but without writing
+// anything. Malformed records are reported and skipped whenever the input
+// can still be read past them.
+func (i *PortIngestor) DryRun(ctx context.Context, filename string) (*DryRunReport, error) {
+	l := i.logger.With(
+		slog.String("filepath", filename),
+	)
+
+	l.InfoContext(ctx, "[PortIngestor.DryRun] processing")
+
+	in, err := i.openInput(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := in.Close(); err != nil {
+			l.Error(
+				"error on closing file",
+				logging.Error(err),
+			)
+		}
+	}()
+
+	report := &DryRunReport{}
+
+	r, err := i.newReader(in)
+	if err != nil {
+		report.addProblem("", "", position{}, err.Error())
+
+		return report, nil
+	}
+
+	defer closeReader(r)
+
+	seen := make(map[string]position)
+
+	for {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var e entry
+
+		e, err = r.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var rErr *recordError
+		if errors.As(err, &rErr) && rErr.recoverable {
+			report.Records++
+			report.Malformed++
+			report.addProblem(rErr.key, rErr.path, rErr.pos, rErr.Error())
+
+			continue
+		}
+
+		if err != nil {
+			var pos position
+			if rErr != nil {
+				pos = rErr.pos
+			}
+
+			report.addProblem("", "", pos, err.Error())
+
+			break
+		}
+
+		report.Records++
+		report.check(seen, e, i.rules)
+	}
+
+	return report, nil
+}
+
+// check checks a read entry for duplicated keys, validation errors and,
+// when set, rules violations.
+func (r *DryRunReport) check(seen map[string]position, e entry, engine *rules.Engine) {
+	path := e.path
+
+	if first, ok := seen[e.key]; ok {
+		r.Duplicates++
+		r.addProblem(e.key, path, e.pos, fmt.Sprintf("duplicated key, first seen at %s", first))
+	} else {
+		seen[e.key] = e.pos
+	}
+
+	if e.op == opDelete {
+		r.Deletes++
+
+		return
+	}
+
+	r.Upserts++
+
+	invalid := false
+
+	var vErr *domain.ValidationError
+	if err := e.port.Validate(); errors.As(err, &vErr) {
+		invalid = true
+
+		for _, fe := range vErr.Errors {
+			fPath := path
+			if fPath != "" {
+				fPath += "." + fe.Field
+			}
+
+			r.addProblem(e.key, fPath, e.pos, fe.Error())
+		}
+	}
+
+	if engine != nil {
+		for _, v := range engine.Evaluate(&e.port) {
+			if v.Severity == rules.SeverityWarn {
+				r.Warnings++
+			} else {
+				invalid = true
+			}
+
+			r.Problems = append(r.Problems, Problem{
+				Key:      e.key,
+				Path:     path,
+				Line:     e.pos.Line,
+				Column:   e.pos.Column,
+				Message:  v.Message,
+				Rule:     v.RuleID,
+				Severity: v.Severity,
+			})
+		}
+	}
+
+	if invalid {
+		r.Invalid++
+	} else {
+		r.Valid++
+	}
+}
+
+// WithRules sets the rules the ports are checked against on dry runs.
+// Violations of error severity rules make the ports invalid, while warnings
+// are only reported.
+func WithRules(e *rules.Engine) PortIngestorOption {
+	return func(pi *PortIngestor) {
+		pi.rules = e
+	}
+}
@@ -0,0 +1,204 @@
+package ingest_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/rafaeltg/goports/internal/core/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("file not found", func(t *testing.T) {
+		ingestor := ingest.NewPortIngestor(nil, loggerTest)
+
+		report, err := ingestor.DryRun(context.Background(), "abc.json")
+		assert.EqualError(t, err, "failed to read file: open abc.json: no such file or directory")
+		assert.Nil(t, report)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		ingestor := ingest.NewPortIngestor(nil, loggerTest)
+
+		report, err := ingestor.DryRun(context.Background(), "testdata/ports_invalid.json")
+		assert.NoError(t, err)
+		assert.False(t, report.Clean())
+		assert.Equal(t,
+			[]ingest.Problem{
+				{Message: "unexpected token encountered on reading opening delimiterr: abc"},
+			},
+			report.Problems,
+		)
+	})
+
+	t.Run("clean file", func(t *testing.T) {
+		// no writes are expected on the mocked service
+		ingestor := ingest.NewPortIngestor(porttest.NewMockPortService(ctrl), loggerTest)
+
+		report, err := ingestor.DryRun(context.Background(), "testdata/ports_valid.json")
+		assert.NoError(t, err)
+		assert.True(t, report.Clean())
+		assert.Equal(t,
+			&ingest.DryRunReport{
+				Records: 4,
+				Upserts: 4,
+				Valid:   4,
+			},
+			report,
+		)
+	})
+
+	t.Run("problems", func(t *testing.T) {
+		ingestor := ingest.NewPortIngestor(porttest.NewMockPortService(ctrl), loggerTest)
+
+		report, err := ingestor.DryRun(context.Background(), "testdata/ports_dryrun.json")
+		assert.NoError(t, err)
+		assert.False(t, report.Clean())
+		assert.Equal(t, 4, report.Records)
+		assert.Equal(t, 3, report.Upserts)
+		assert.Equal(t, 2, report.Valid)
+		assert.Equal(t, 1, report.Invalid)
+		assert.Equal(t, 1, report.Malformed)
+		assert.Equal(t, 1, report.Duplicates)
+
+		assert.Len(t, report.Problems, 4)
+
+		assert.Equal(t, "AEAUH", report.Problems[0].Key)
+		assert.Contains(t, report.Problems[0].Path, `$["AEAUH"].coordinates`)
+		assert.Equal(t, 9, report.Problems[0].Line)
+
+		assert.Equal(t,
+			[]ingest.Problem{
+				{
+					Key:     "AEDXB",
+					Path:    `$["AEDXB"].name`,
+					Line:    12,
+					Column:  3,
+					Message: "name: is required",
+				},
+				{
+					Key:     "AEDXB",
+					Path:    `$["AEDXB"].coordinates`,
+					Line:    12,
+					Column:  3,
+					Message: "coordinates: longitude 255.27 out of range",
+				},
+				{
+					Key:     "AEAJM",
+					Path:    `$["AEAJM"]`,
+					Line:    17,
+					Column:  3,
+					Message: "duplicated key, first seen at 2:3",
+				},
+			},
+			report.Problems[1:],
+		)
+	})
+	t.Run("problems far into the input", func(t *testing.T) {
+		var b strings.Builder
+
+		b.WriteString("{\n")
+
+		for n := 0; n < 5000; n++ {
+			fmt.Fprintf(&b, "  \"XX%05d\": {\"name\": \"Port %d\"},\n", n, n)
+		}
+
+		b.WriteString("  \"YY00000\": {\"name\": \"Bad\", \"coordinates\": [1, \"2\"]},\n")
+		b.WriteString("  \"YY00001\": {\"name\": \"Good\"}\n}\n")
+
+		filename := filepath.Join(t.TempDir(), "ports.json")
+		require.NoError(t, os.WriteFile(filename, []byte(b.String()), 0o600))
+
+		ingestor := ingest.NewPortIngestor(porttest.NewMockPortService(ctrl), loggerTest)
+
+		report, err := ingestor.DryRun(context.Background(), filename)
+		assert.NoError(t, err)
+		assert.Equal(t, 5002, report.Records)
+		assert.Equal(t, 5001, report.Valid)
+		assert.Equal(t, 1, report.Malformed)
+
+		require.Len(t, report.Problems, 1)
+		assert.Equal(t, `$["YY00000"].coordinates[1]`, report.Problems[0].Path)
+		assert.Equal(t, 5002, report.Problems[0].Line)
+		assert.Equal(t, 52, report.Problems[0].Column)
+	})
+
+	t.Run("rules", func(t *testing.T) {
+		engine, err := rules.New([]rules.Rule{
+			{
+				ID:          "not-dubai",
+				Description: "Dubai is not allowed",
+				Expression:  `port.name != "Dubai"`,
+			},
+			{
+				ID:         "no-al-prefix",
+				Expression: `!port.name.startsWith("Al ")`,
+				Severity:   rules.SeverityWarn,
+				Message:    "name starts with Al",
+			},
+		})
+		require.NoError(t, err)
+
+		ingestor := ingest.NewPortIngestor(porttest.NewMockPortService(ctrl), loggerTest, ingest.WithRules(engine))
+
+		report, err := ingestor.DryRun(context.Background(), "testdata/ports_valid.json")
+		assert.NoError(t, err)
+		assert.False(t, report.Clean())
+		assert.Equal(t, 3, report.Valid)
+		assert.Equal(t, 1, report.Invalid)
+		assert.Equal(t, 1, report.Warnings)
+		assert.Equal(t,
+			[]ingest.Problem{
+				{
+					Key:      "AEDXB",
+					Path:     `$["AEDXB"]`,
+					Line:     36,
+					Column:   3,
+					Message:  "Dubai is not allowed",
+					Rule:     "not-dubai",
+					Severity: rules.SeverityError,
+				},
+				{
+					Key:      "AEFJR",
+					Path:     `$["AEFJR"]`,
+					Line:     53,
+					Column:   3,
+					Message:  "name starts with Al",
+					Rule:     "no-al-prefix",
+					Severity: rules.SeverityWarn,
+				},
+			},
+			report.Problems,
+		)
+	})
+
+	t.Run("rules warnings only", func(t *testing.T) {
+		engine, err := rules.New([]rules.Rule{
+			{
+				ID:         "no-al-prefix",
+				Expression: `!port.name.startsWith("Al ")`,
+				Severity:   rules.SeverityWarn,
+			},
+		})
+		require.NoError(t, err)
+
+		ingestor := ingest.NewPortIngestor(porttest.NewMockPortService(ctrl), loggerTest, ingest.WithRules(engine))
+
+		report, err := ingestor.DryRun(context.Background(), "testdata/ports_valid.json")
+		assert.NoError(t, err)
+		assert.True(t, report.Clean())
+		assert.Equal(t, 4, report.Valid)
+		assert.Len(t, report.Problems, 1)
+	})
+}
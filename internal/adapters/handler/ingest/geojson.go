@@ -0,0 +1,160 @@
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+)
+
+// geoJSONReader reads ports from a GeoJSON feature collection of points,
+// decoding one feature at a time. Feature properties are the port fields.
+type geoJSONReader struct {
+	dec   *json.Decoder
+	lines *lineCounter
+	// idx is the index of the next feature.
+	idx  int
+	done bool
+}
+
+func newGeoJSONReader(r io.Reader) (*geoJSONReader, error) {
+	lines := newLineCounter(r)
+	dec := json.NewDecoder(lines)
+
+	token, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read opening delimiter: %w", err)
+	}
+
+	if token != json.Delim('{') {
+		return nil, fmt.Errorf("unexpected token encountered on reading opening delimiter: %s", token)
+	}
+
+	gr := &geoJSONReader{dec: dec, lines: lines}
+
+	// members may come in any order, so the ones before the features are
+	// read, and those after them are skipped once the features are done
+	if err := gr.skipTo("features"); err != nil {
+		return nil, err
+	}
+
+	return gr, nil
+}
+
+func (r *geoJSONReader) next() (entry, error) {
+	if r.done {
+		return entry{}, io.EOF
+	}
+
+	if !r.dec.More() {
+		r.done = true
+
+		if _, err := r.dec.Token(); err != nil {
+			return entry{}, fmt.Errorf("failed to read features closing delimiter: %w", err)
+		}
+
+		if err := r.skipTo(""); err != nil {
+			return entry{}, err
+		}
+
+		return entry{}, io.EOF
+	}
+
+	idx := r.idx
+	r.idx++
+
+	var raw json.RawMessage
+
+	if err := r.dec.Decode(&raw); err != nil {
+		return entry{}, r.syntaxError(fmt.Errorf("error on decoding feature %d: %w", idx, err))
+	}
+
+	path := fmt.Sprintf("$.features[%d]", idx)
+	pos := r.lines.position(r.dec.InputOffset() - int64(len(raw)))
+
+	var f domain.Feature
+
+	p, err := func() (*domain.Port, error) {
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return nil, err
+		}
+
+		return f.Port()
+	}()
+	if err != nil {
+		return entry{}, &recordError{
+			path:        path,
+			pos:         pos,
+			err:         fmt.Errorf("error on decoding feature %d: %w", idx, err),
+			recoverable: true,
+		}
+	}
+
+	return entry{port: *p, op: opUpsert, key: p.ID, path: path, pos: pos}, nil
+}
+
+// skipTo reads the members of the collection up to the given one, whose
+// array opening delimiter is read, or up to its end when there is no such
+// member, or it is empty. The collection type is checked on the way.
+func (r *geoJSONReader) skipTo(member string) error {
+	for r.dec.More() {
+		token, err := r.dec.Token()
+		if err != nil {
+			return r.syntaxError(fmt.Errorf("failed to read member key: %w", err))
+		}
+
+		key, _ := token.(string)
+
+		switch {
+		case member != "" && key == member:
+			token, err := r.dec.Token()
+			if err != nil {
+				return r.syntaxError(fmt.Errorf("failed to read %s: %w", member, err))
+			}
+
+			if token != json.Delim('[') {
+				return fmt.Errorf("unexpected token encountered on reading %s: %v", member, token)
+			}
+
+			return nil
+		case key == "type":
+			var t string
+			if err := r.dec.Decode(&t); err != nil {
+				return r.syntaxError(fmt.Errorf("failed to read GeoJSON type: %w", err))
+			}
+
+			if t != domain.GeoJSONFeatureCollection {
+				return fmt.Errorf("unexpected GeoJSON type '%s', want '%s'", t, domain.GeoJSONFeatureCollection)
+			}
+		default:
+			var skipped json.RawMessage
+			if err := r.dec.Decode(&skipped); err != nil {
+				return r.syntaxError(fmt.Errorf("failed to read member '%s': %w", key, err))
+			}
+		}
+	}
+
+	// no features at all, or the remaining members were skipped
+	r.done = true
+
+	if _, err := r.dec.Token(); err != nil && !errors.Is(err, io.EOF) {
+		return r.syntaxError(fmt.Errorf("failed to read closing delimiter: %w", err))
+	}
+
+	return nil
+}
+
+// syntaxError adds the input position to err when it is a JSON syntax error.
+func (r *geoJSONReader) syntaxError(err error) error {
+	var synErr *json.SyntaxError
+	if !errors.As(err, &synErr) {
+		return err
+	}
+
+	return &recordError{
+		pos: r.lines.position(synErr.Offset),
+		err: err,
+	}
+}
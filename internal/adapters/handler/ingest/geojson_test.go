@@ -0,0 +1,110 @@
+package ingest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/domain/domaintest"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcess_GeoJSON(t *testing.T) {
+	t.Run("unsupported geometry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ingestor := ingest.NewPortIngestor(
+			porttest.NewMockPortService(ctrl),
+			loggerTest,
+			ingest.WithFormat(ingest.FormatGeoJSON),
+			ingest.WithBatchSize(10),
+		)
+
+		err := ingestor.Process(context.Background(), "testdata/ports.geojson")
+		assert.EqualError(t, err,
+			"error on decoding feature 2: unsupported geometry type 'Polygon', want 'Point'",
+		)
+	})
+
+	t.Run("lenient", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var upserted domain.Ports
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(
+				gomock.Any(),
+				domaintest.PortsMatcher(
+					domain.Ports{
+						{ID: "AEAJM", Name: "Ajman"},
+						{ID: "AEAUH", Name: "Abu Dhabi"},
+						{ID: "AEFJR", Name: "Al Fujayrah"},
+					},
+				),
+			).
+			DoAndReturn(func(_ context.Context, ports domain.Ports) error {
+				upserted = append(upserted, ports...)
+				return nil
+			})
+
+		reportPath := filepath.Join(t.TempDir(), "report.json")
+
+		ingestor := ingest.NewPortIngestor(
+			mockedPortSvc,
+			loggerTest,
+			ingest.WithFormat(ingest.FormatGeoJSON),
+			ingest.WithBatchSize(10),
+			ingest.WithLenient(0),
+			ingest.WithReportPath(reportPath),
+		)
+
+		err := ingestor.Process(context.Background(), "testdata/ports.geojson")
+		assert.NoError(t, err)
+
+		assert.Equal(t,
+			domain.Port{
+				ID:          "AEAJM",
+				Name:        "Ajman",
+				City:        "Ajman",
+				Country:     "United Arab Emirates",
+				Coordinates: domain.Coordinates{55.5136433, 25.4052165},
+				Unlocs:      []string{"AEAJM"},
+			},
+			upserted[0],
+		)
+		assert.Empty(t, upserted[1].Coordinates)
+		assert.Equal(t, domain.NewCoordinates(25.1288, 56.3265), upserted[2].Coordinates)
+
+		report := readRunReport(t, reportPath)
+		assert.Len(t, report.Skipped, 1)
+		assert.Equal(t, "$.features[2]", report.Skipped[0].Path)
+		assert.Equal(t, 15, report.Skipped[0].Line)
+		assert.Equal(t, 5, report.Skipped[0].Column)
+	})
+
+	t.Run("not a feature collection", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		path := filepath.Join(t.TempDir(), "port.geojson")
+		err := os.WriteFile(path, []byte(`{"type": "Feature", "properties": {}}`), 0o600)
+		assert.NoError(t, err)
+
+		ingestor := ingest.NewPortIngestor(
+			porttest.NewMockPortService(ctrl),
+			loggerTest,
+			ingest.WithFormat(ingest.FormatGeoJSON),
+		)
+
+		err = ingestor.Process(context.Background(), path)
+		assert.EqualError(t, err, "unexpected GeoJSON type 'Feature', want 'FeatureCollection'")
+	})
+}
@@ -0,0 +1,234 @@
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// StdinInput is the input name used to read from the standard input.
+const StdinInput = "-"
+
+// ErrInputTooLarge is returned when the input, once decompressed, is larger
+// than allowed.
+var ErrInputTooLarge = errors.New("input too large")
+
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	bzip2Magic = []byte("BZh")
+	zstdMagic  = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+type (
+	// input is a readable source of ports data.
+	input struct {
+		io.Reader
+		closers []io.Closer
+		// size is the length of the source data, or 0 when unknown.
+		size int64
+		// read counts the source bytes read, before any decompression.
+		read *countingReader
+	}
+
+	countingReader struct {
+		r io.Reader
+		n atomic.Int64
+	}
+
+	// limitedReader fails with ErrInputTooLarge once more than max bytes
+	// are read.
+	limitedReader struct {
+		r      io.Reader
+		n, max int64
+	}
+
+	closerFunc func() error
+)
+
+func (f closerFunc) Close() error {
+	return f()
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+
+	return n, err
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	// one byte past the limit tells an input of exactly max bytes apart
+	// from a larger one
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	if int64(n) <= l.n {
+		l.n -= int64(n)
+
+		return n, err
+	}
+
+	n, l.n = int(l.n), 0
+
+	return n, fmt.Errorf("%w: more than %d bytes", ErrInputTooLarge, l.max)
+}
+
+// Close closes the decompressor, if any, and the underlying source.
+func (in *input) Close() error {
+	var err error
+
+	for i := len(in.closers) - 1; i >= 0; i-- {
+		if cErr := in.closers[i].Close(); cErr != nil && err == nil {
+			err = cErr
+		}
+	}
+
+	return err
+}
+
+// openInput opens the given input for reading. The input may be a local file
+// path, StdinInput or an http(s) URL. Compressed (gzip, zstd or bzip2) data is
+// detected by its magic bytes and decompressed while it is read, up to the
+// input size limit, if any.
+func (i *PortIngestor) openInput(ctx context.Context, name string) (*input, error) {
+	var (
+		src  io.ReadCloser
+		size int64
+		err  error
+	)
+
+	switch {
+	case name == StdinInput:
+		src = io.NopCloser(os.Stdin)
+	case strings.HasPrefix(name, "http://"), strings.HasPrefix(name, "https://"):
+		src, size, err = i.fetch(ctx, name)
+	default:
+		src, size, err = openFile(name)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	in := &input{
+		closers: []io.Closer{src},
+		size:    size,
+		read:    &countingReader{r: src},
+	}
+
+	in.Reader, err = decompress(in.read, in)
+	if err != nil {
+		_ = in.Close()
+
+		return nil, err
+	}
+
+	if i.maxInputBytes > 0 {
+		in.Reader = &limitedReader{r: in.Reader, n: i.maxInputBytes, max: i.maxInputBytes}
+	}
+
+	return in, nil
+}
+
+func openFile(name string) (io.ReadCloser, int64, error) {
+	f, err := os.Open(filepath.Clean(name))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+
+		return nil, 0, fmt.Errorf("finvalid file: %w", err)
+	}
+
+	return f, fi.Size(), nil
+}
+
+func (i *PortIngestor) fetch(ctx context.Context, url string) (io.ReadCloser, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	res, err := i.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch file: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		_ = res.Body.Close()
+
+		return nil, 0, fmt.Errorf("failed to fetch file: unexpected status code %d", res.StatusCode)
+	}
+
+	return res.Body, max(res.ContentLength, 0), nil
+}
+
+// decompress wraps r with a decompressor according to its magic bytes,
+// registering the decompressor to be closed along with the input.
+func decompress(r io.Reader, in *input) (io.Reader, error) {
+	br := bufio.NewReader(r)
+
+	// a short input is fine: it just can't be compressed
+	magic, _ := br.Peek(len(zstdMagic))
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzip data: %w", err)
+		}
+
+		in.closers = append(in.closers, zr)
+
+		return zr, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read zstd data: %w", err)
+		}
+
+		in.closers = append(in.closers, closerFunc(func() error {
+			zr.Close()
+			return nil
+		}))
+
+		return zr, nil
+	case bytes.HasPrefix(magic, bzip2Magic):
+		return bzip2.NewReader(br), nil
+	default:
+		return br, nil
+	}
+}
+
+// WithHTTPClient sets the client used to fetch http(s) inputs.
+func WithHTTPClient(c *http.Client) PortIngestorOption {
+	return func(pi *PortIngestor) {
+		pi.httpClient = c
+	}
+}
+
+// WithMaxInputBytes limits the size of the inputs, once decompressed, so that
+// a small compressed file can't be expanded into an unbounded stream. Zero or
+// less means no limit.
+func WithMaxInputBytes(n int64) PortIngestorOption {
+	return func(pi *PortIngestor) {
+		pi.maxInputBytes = n
+	}
+}
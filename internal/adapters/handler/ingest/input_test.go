@@ -0,0 +1,143 @@
+package ingest_test
+
+import (
+	"context"
+	gohttp "net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/domain/domaintest"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcess_Inputs(t *testing.T) {
+	srv := httptest.NewServer(gohttp.FileServer(gohttp.Dir("testdata")))
+	defer srv.Close()
+
+	tcs := []struct {
+		name  string
+		input string
+		stdin string
+	}{
+		{
+			name:  "plain file",
+			input: "testdata/ports_valid.json",
+		},
+		{
+			name:  "gzip file",
+			input: "testdata/ports_valid.json.gz",
+		},
+		{
+			name:  "bzip2 file",
+			input: "testdata/ports_valid.json.bz2",
+		},
+		{
+			name:  "zstd file",
+			input: "testdata/ports_valid.json.zst",
+		},
+		{
+			name:  "stdin",
+			input: ingest.StdinInput,
+			stdin: "testdata/ports_valid.json.gz",
+		},
+		{
+			name:  "url",
+			input: srv.URL + "/ports_valid.json",
+		},
+		{
+			name:  "compressed url",
+			input: srv.URL + "/ports_valid.json.zst",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			if tc.stdin != "" {
+				f, err := os.Open(tc.stdin)
+				assert.NoError(t, err)
+
+				defer f.Close()
+
+				stdin := os.Stdin
+				os.Stdin = f
+
+				defer func() { os.Stdin = stdin }()
+			}
+
+			mockedPortSvc := porttest.NewMockPortService(ctrl)
+			mockedPortSvc.EXPECT().
+				BulkUpsert(
+					gomock.Any(),
+					domaintest.PortsMatcher(
+						domain.Ports{
+							{ID: "AEAJM", Name: "Ajman"},
+							{ID: "AEAUH", Name: "Abu Dhabi"},
+							{ID: "AEDXB", Name: "Dubai"},
+							{ID: "AEFJR", Name: "Al Fujayrah"},
+						},
+					),
+				).
+				Return(nil)
+
+			ingestor := ingest.NewPortIngestor(
+				mockedPortSvc,
+				loggerTest,
+				ingest.WithHTTPClient(srv.Client()),
+			)
+
+			err := ingestor.Process(context.Background(), tc.input)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("url not found", func(t *testing.T) {
+		ingestor := ingest.NewPortIngestor(
+			nil,
+			loggerTest,
+			ingest.WithHTTPClient(srv.Client()),
+		)
+
+		err := ingestor.Process(context.Background(), srv.URL+"/abc.json")
+		assert.EqualError(t, err, "failed to fetch file: unexpected status code 404")
+	})
+	t.Run("decompressed input too large", func(t *testing.T) {
+		ingestor := ingest.NewPortIngestor(
+			nil,
+			loggerTest,
+			ingest.WithMaxInputBytes(1000),
+		)
+
+		err := ingestor.Process(context.Background(), "testdata/ports_valid.json.gz")
+		assert.ErrorIs(t, err, ingest.ErrInputTooLarge)
+	})
+
+	t.Run("decompressed input at the limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fi, err := os.Stat("testdata/ports_valid.json")
+		assert.NoError(t, err)
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		ingestor := ingest.NewPortIngestor(
+			mockedPortSvc,
+			loggerTest,
+			ingest.WithMaxInputBytes(fi.Size()),
+		)
+
+		err = ingestor.Process(context.Background(), "testdata/ports_valid.json.gz")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,216 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+)
+
+type (
+	// jsonReader reads ports from a JSON object keyed by port ID. Without a
+	// decode function, ports are decoded straight from the input, which is
+	// only read again to describe the records failing to decode.
+	jsonReader struct {
+		dec    *json.Decoder
+		lines  *lineCounter
+		replay *replayBuffer
+		decode decodeFunc
+	}
+
+	// replayBuffer keeps the input read since the current record.
+	replayBuffer struct {
+		r    io.Reader
+		buf  []byte
+		base int64 // input offset of buf[0]
+	}
+)
+
+func newJSONReader(r io.Reader, decode decodeFunc) (*jsonReader, error) {
+	lines := newLineCounter(r)
+
+	var (
+		src    io.Reader = lines
+		replay *replayBuffer
+	)
+
+	if decode == nil {
+		replay = &replayBuffer{r: lines}
+		src = replay
+	}
+
+	dec := json.NewDecoder(src)
+
+	// read opening JSON delimiter
+	token, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read opening delimiter: %w", err)
+	}
+
+	if token != json.Delim('{') {
+		return nil, fmt.Errorf("unexpected token encountered on reading opening delimiterr: %s", token)
+	}
+
+	return &jsonReader{dec: dec, lines: lines, replay: replay, decode: decode}, nil
+}
+
+func (r *jsonReader) next() (entry, error) {
+	if !r.dec.More() {
+		return entry{}, io.EOF
+	}
+
+	id, err := r.dec.Token()
+	if err != nil {
+		return entry{}, r.syntaxError(fmt.Errorf("failed to read port key: %w", err))
+	}
+
+	key, ok := id.(string)
+	if !ok {
+		return entry{}, fmt.Errorf("unexpected type for port key: '%T'", id)
+	}
+
+	// the key offset ignores escaped characters, which are rare in port IDs
+	pos := r.lines.position(r.dec.InputOffset() - int64(len(key)) - 2)
+
+	port, raw, err := r.decodePort()
+	if err != nil && raw == nil {
+		return entry{}, r.syntaxError(fmt.Errorf("error on decoding port with id '%s': %w", key, err))
+	}
+
+	if err != nil {
+		rErr := &recordError{
+			key:         key,
+			path:        keyPath(key),
+			pos:         pos,
+			err:         fmt.Errorf("error on decoding port with id '%s': %w", key, err),
+			recoverable: true,
+		}
+
+		// the value was read in full, so the following ports can still be read
+		var (
+			typeErr  *json.UnmarshalTypeError
+			fieldErr *fieldError
+		)
+
+		switch {
+		case errors.As(err, &fieldErr):
+			rErr.path += "." + fieldErr.field
+		case errors.As(err, &typeErr):
+			rErr.path += fieldPath(typeErr.Field)
+
+			rErr.pos = r.lines.position(r.dec.InputOffset() - int64(len(raw)) + typeErr.Offset)
+		}
+
+		return entry{}, rErr
+	}
+
+	port.ID = key
+
+	return entry{port: port, op: opUpsert, key: key, path: keyPath(key), pos: pos}, nil
+}
+
+// decodePort reads the rest of the port JSON. The raw port is returned along
+// with the decoding errors of a value read in full, or nil when the value
+// couldn't be read.
+func (r *jsonReader) decodePort() (domain.Port, []byte, error) {
+	var port domain.Port
+
+	if r.decode != nil {
+		var raw json.RawMessage
+
+		if err := r.dec.Decode(&raw); err != nil {
+			return port, nil, err
+		}
+
+		return port, raw, r.decode(raw, &port)
+	}
+
+	start := r.dec.InputOffset()
+	r.replay.discard(start)
+
+	err := r.dec.Decode(&port)
+	if err == nil {
+		return port, nil, nil
+	}
+
+	// the colon was consumed even if the value couldn't be read
+	raw := bytes.TrimLeft(r.replay.bytes(start, r.dec.InputOffset()), " \t\r\n:")
+	if len(raw) == 0 {
+		return port, nil, err
+	}
+
+	// decode it again to describe the error as when decoding the raw value
+	if rawErr := decodeStd(raw, new(domain.Port)); rawErr != nil {
+		err = rawErr
+	}
+
+	return port, raw, err
+}
+
+func (b *replayBuffer) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.buf = append(b.buf, p[:n]...)
+
+	return n, err
+}
+
+// discard forgets the input before the given offset. The kept input is only
+// moved once most of the buffer is to be discarded.
+func (b *replayBuffer) discard(offset int64) {
+	n := int(offset - b.base)
+	if n < len(b.buf)/2 {
+		return
+	}
+
+	b.buf = b.buf[:copy(b.buf, b.buf[n:])]
+	b.base = offset
+}
+
+// bytes returns the input between the given offsets, which must not have
+// been discarded.
+func (b *replayBuffer) bytes(from, to int64) []byte {
+	return b.buf[from-b.base : to-b.base]
+}
+
+// syntaxError adds the input position to err when it is a JSON syntax error.
+func (r *jsonReader) syntaxError(err error) error {
+	var synErr *json.SyntaxError
+	if !errors.As(err, &synErr) {
+		return err
+	}
+
+	return &recordError{
+		pos: r.lines.position(synErr.Offset),
+		err: err,
+	}
+}
+
+// keyPath returns the JSON path of the port with the given key.
+func keyPath(key string) string {
+	return fmt.Sprintf("$[%q]", key)
+}
+
+// fieldPath converts a dotted decoding error field ("coordinates.0") into a
+// JSON path suffix (".coordinates[0]").
+func fieldPath(field string) string {
+	if field == "" {
+		return ""
+	}
+
+	var b strings.Builder
+
+	for _, f := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(f); err == nil {
+			b.WriteString("[" + f + "]")
+		} else {
+			b.WriteString("." + f)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,46 @@
+package ingest
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrTooManyErrors is returned when a lenient ingestion skips more records
+// than allowed.
+var ErrTooManyErrors = errors.New("too many malformed records")
+
+// lenientJSONReader reads ports from a JSON object keyed by port ID, like
+// jsonReader, but splits the object members by itself so that a malformed
+// member is skipped up to the next one.
+type lenientJSONReader struct {
+	members *memberScanner
+	decode  decodeFunc
+}
+
+func newLenientJSONReader(r io.Reader, decode decodeFunc) (*lenientJSONReader, error) {
+	members, err := newMemberScanner(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lenientJSONReader{members: members, decode: decode}, nil
+}
+
+func (r *lenientJSONReader) next() (entry, error) {
+	m, err := r.members.next()
+	if err != nil {
+		return entry{}, err
+	}
+
+	return m.decode(r.decode)
+}
+
+// WithLenient makes malformed records to be skipped, and reported, instead
+// of aborting the ingestion, which is only aborted once more than maxErrors
+// records were skipped. Zero or less allows any number of skipped records.
+func WithLenient(maxErrors int) PortIngestorOption {
+	return func(pi *PortIngestor) {
+		pi.lenient = true
+		pi.maxErrors = maxErrors
+	}
+}
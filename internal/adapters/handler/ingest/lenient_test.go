@@ -0,0 +1,97 @@
+package ingest_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/domain/domaintest"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLenient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("skips malformed records", func(t *testing.T) {
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(
+				gomock.Any(),
+				domaintest.PortsMatcher(
+					domain.Ports{
+						{ID: "AEAJM", Name: "Ajman", Unlocs: []string{"AEAJM"}},
+						{ID: "AEFJR", Name: "Al Fujayrah", Unlocs: []string{"AEFJR"}},
+					},
+				),
+			).
+			Return(nil)
+
+		reportPath := filepath.Join(t.TempDir(), "report.json")
+
+		ingestor := ingest.NewPortIngestor(
+			mockedPortSvc,
+			loggerTest,
+			ingest.WithLenient(0),
+			ingest.WithReportPath(reportPath),
+		)
+
+		err := ingestor.Process(context.Background(), "testdata/ports_lenient.json")
+		assert.NoError(t, err)
+
+		report := readRunReport(t, reportPath)
+		assert.True(t, report.Succeeded)
+		assert.Equal(t, int64(2), report.PortsDecoded)
+		assert.Equal(t, int64(3), report.PortsSkipped)
+		assert.Len(t, report.Skipped, 3)
+
+		assert.Equal(t, "AEAUH", report.Skipped[0].Key)
+		assert.Equal(t, `$["AEAUH"].coordinates[0]`, report.Skipped[0].Path)
+		assert.Equal(t, 8, report.Skipped[0].Line)
+
+		assert.Equal(t, "AEDXB", report.Skipped[1].Key)
+		assert.Equal(t, 11, report.Skipped[1].Line)
+		assert.Equal(t, 13, report.Skipped[1].Column)
+
+		assert.Equal(t,
+			ingest.Problem{
+				Line:    14,
+				Column:  3,
+				Message: "invalid character '1' looking for port key",
+			},
+			report.Skipped[2],
+		)
+	})
+
+	t.Run("too many errors", func(t *testing.T) {
+		ingestor := ingest.NewPortIngestor(
+			porttest.NewMockPortService(ctrl),
+			loggerTest,
+			ingest.WithLenient(2),
+		)
+
+		err := ingestor.Process(context.Background(), "testdata/ports_lenient.json")
+		assert.True(t, errors.Is(err, ingest.ErrTooManyErrors))
+		assert.EqualError(t, err, "too many malformed records: 3 skipped, max 2")
+	})
+
+	t.Run("unexpected end", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "ports.json")
+		assert.NoError(t, os.WriteFile(filename, []byte(`{"AEAJM": {"name": "Ajman"`), 0o600))
+
+		ingestor := ingest.NewPortIngestor(
+			porttest.NewMockPortService(ctrl),
+			loggerTest,
+			ingest.WithLenient(0),
+		)
+
+		err := ingestor.Process(context.Background(), filename)
+		assert.EqualError(t, err, "unexpected end of JSON input")
+	})
+}
@@ -0,0 +1,500 @@
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConvertUpper converts a string to upper case.
+	ConvertUpper Conversion = "upper"
+	// ConvertLower converts a string to lower case.
+	ConvertLower Conversion = "lower"
+	// ConvertTitle converts the first letter of each word to upper case and the others to lower case.
+	ConvertTitle Conversion = "title"
+	// ConvertTrim removes the leading and trailing spaces of a string.
+	ConvertTrim Conversion = "trim"
+)
+
+type (
+	// Mapping maps the fields of the source ports into the domain.Port ones.
+	// Target fields which are not mapped are decoded as usual, by their name.
+	Mapping struct {
+		// Fields holds the mapping of each target field, by its JSON name.
+		Fields map[string]FieldMapping `yaml:"fields"`
+	}
+
+	// FieldMapping sets how a target field is built from the source fields.
+	FieldMapping struct {
+		// From holds the source fields, using dots for nested ones. Only
+		// the coordinates may be built from two fields: longitude and latitude.
+		From SourceFields `yaml:"from"`
+		// Split sets the separator used to split a string into a list.
+		Split string `yaml:"split"`
+		// Convert sets a conversion applied to string values.
+		Convert Conversion `yaml:"convert"`
+		// Default is the value used when the source fields are missing.
+		Default any `yaml:"default"`
+	}
+
+	// SourceFields is a list of source fields, which may be given as a
+	// single string.
+	SourceFields []string
+
+	// Conversion is a conversion applied to string values.
+	Conversion string
+
+	// fieldError is an error found on mapping a target field.
+	fieldError struct {
+		field string
+		err   error
+	}
+
+	// decodeFunc decodes a port JSON value.
+	decodeFunc func(raw []byte, port *domain.Port) error
+
+	fieldKind int
+)
+
+const (
+	kindString fieldKind = iota
+	kindList
+	kindCoordinates
+)
+
+var fastJSON = jsoniter.ConfigCompatibleWithStandardLibrary
+
+var targetKinds = map[string]fieldKind{
+	"name":        kindString,
+	"city":        kindString,
+	"country":     kindString,
+	"province":    kindString,
+	"timezone":    kindString,
+	"code":        kindString,
+	"alias":       kindList,
+	"regions":     kindList,
+	"unlocs":      kindList,
+	"coordinates": kindCoordinates,
+}
+
+// LoadMapping loads a mapping from a YAML, or JSON, file. A field mapping
+// may be given as just its source field:
+//
+//	fields:
+//	  name: port_name
+//	  country: {from: country_code, convert: upper}
+//	  coordinates: {from: [lng, lat]}
+//	  alias: {from: aliases, split: ","}
+//	  timezone: {default: UTC}
+func LoadMapping(path string) (*Mapping, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+
+	var m Mapping
+
+	if err = yaml.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("invalid mapping file: %w", err)
+	}
+
+	if err = m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid mapping file: %w", err)
+	}
+
+	return &m, nil
+}
+
+// UnmarshalYAML allows a field mapping to be given as its source field.
+func (f *FieldMapping) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&f.From)
+	}
+
+	type plain FieldMapping
+
+	return value.Decode((*plain)(f))
+}
+
+// UnmarshalYAML allows the source fields to be given as a single string.
+func (s *SourceFields) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*s = SourceFields{value.Value}
+		return nil
+	}
+
+	return value.Decode((*[]string)(s))
+}
+
+func (m *Mapping) validate() error {
+	var errs []error
+
+	for _, target := range m.targets() {
+		f := m.Fields[target]
+
+		kind, ok := targetKinds[target]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown target field", target))
+			continue
+		}
+
+		maxFrom := 1
+		if kind == kindCoordinates {
+			maxFrom = 2
+		}
+
+		if len(f.From) > maxFrom {
+			errs = append(errs, fmt.Errorf("%s: at most %d source fields allowed", target, maxFrom))
+		}
+
+		if len(f.From) == 0 && f.Default == nil {
+			errs = append(errs, fmt.Errorf("%s: either a source field or a default is required", target))
+		}
+
+		switch f.Convert {
+		case "", ConvertUpper, ConvertLower, ConvertTitle, ConvertTrim:
+		default:
+			errs = append(errs, fmt.Errorf("%s: unknown conversion '%s'", target, f.Convert))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// targets returns the mapped target fields, sorted.
+func (m *Mapping) targets() []string {
+	targets := make([]string, 0, len(m.Fields))
+	for target := range m.Fields {
+		targets = append(targets, target)
+	}
+
+	sort.Strings(targets)
+
+	return targets
+}
+
+// decode decodes a port JSON value, building the mapped fields from the
+// source ones.
+func (m *Mapping) decode(raw []byte, port *domain.Port) error {
+	if err := decodeStd(raw, port); err != nil {
+		var typeErr *json.UnmarshalTypeError
+
+		// mapped fields may have the name, but not the type, of the targets
+		if !errors.As(err, &typeErr) {
+			return err
+		}
+
+		if _, ok := m.Fields[strings.SplitN(typeErr.Field, ".", 2)[0]]; !ok {
+			return err
+		}
+	}
+
+	var src map[string]any
+	if err := json.Unmarshal(raw, &src); err != nil {
+		return err
+	}
+
+	for _, target := range m.targets() {
+		f := m.Fields[target]
+
+		if err := f.apply(target, src, port); err != nil {
+			return &fieldError{field: target, err: err}
+		}
+	}
+
+	return nil
+}
+
+func (f *FieldMapping) apply(target string, src map[string]any, port *domain.Port) error {
+	values := make([]any, 0, len(f.From))
+
+	for _, name := range f.From {
+		if v := lookup(src, name); v != nil {
+			values = append(values, v)
+		}
+	}
+
+	if len(values) < len(f.From) || len(values) == 0 {
+		if f.Default == nil {
+			return nil
+		}
+
+		values = []any{f.Default}
+	}
+
+	switch targetKinds[target] {
+	case kindCoordinates:
+		coordinates, err := f.coordinates(values)
+		if err != nil {
+			return err
+		}
+
+		return setField(port, target, coordinates)
+	case kindList:
+		list, err := f.list(values[0])
+		if err != nil {
+			return err
+		}
+
+		return setField(port, target, list)
+	default:
+		s, err := toString(values[0])
+		if err != nil {
+			return err
+		}
+
+		return setField(port, target, f.convert(s))
+	}
+}
+
+func (f *FieldMapping) coordinates(values []any) ([]float64, error) {
+	if len(values) == 1 {
+		switch v := values[0].(type) {
+		case []any:
+			values = v
+		case string:
+			if f.Split == "" {
+				return nil, errors.New("a separator is required to split coordinates")
+			}
+
+			values = values[:0]
+			for _, s := range strings.Split(v, f.Split) {
+				values = append(values, s)
+			}
+		}
+	}
+
+	if len(values) != 2 {
+		return nil, fmt.Errorf("expected longitude and latitude, got %d values", len(values))
+	}
+
+	coordinates := make([]float64, 0, len(values))
+
+	for _, v := range values {
+		c, err := toFloat(v)
+		if err != nil {
+			return nil, err
+		}
+
+		coordinates = append(coordinates, c)
+	}
+
+	return coordinates, nil
+}
+
+func (f *FieldMapping) list(v any) ([]string, error) {
+	var items []any
+
+	switch v := v.(type) {
+	case []any:
+		items = v
+	case string:
+		if f.Split == "" {
+			items = []any{v}
+			break
+		}
+
+		for _, s := range strings.Split(v, f.Split) {
+			items = append(items, s)
+		}
+	default:
+		items = []any{v}
+	}
+
+	list := make([]string, 0, len(items))
+
+	for _, item := range items {
+		s, err := toString(item)
+		if err != nil {
+			return nil, err
+		}
+
+		if s = f.convert(strings.TrimSpace(s)); s != "" {
+			list = append(list, s)
+		}
+	}
+
+	return list, nil
+}
+
+func (f *FieldMapping) convert(s string) string {
+	switch f.Convert {
+	case ConvertUpper:
+		return strings.ToUpper(s)
+	case ConvertLower:
+		return strings.ToLower(s)
+	case ConvertTitle:
+		words := strings.Fields(strings.ToLower(s))
+		for idx, w := range words {
+			words[idx] = titleWord(w)
+		}
+
+		return strings.Join(words, " ")
+	case ConvertTrim:
+		return strings.TrimSpace(s)
+	default:
+		return s
+	}
+}
+
+// lookup returns the source field with the given dotted name, or nil.
+func lookup(src map[string]any, name string) any {
+	var v any = src
+
+	for _, part := range strings.Split(name, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		v = obj[part]
+	}
+
+	return v
+}
+
+func toString(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("cannot convert %T to string", v)
+	}
+}
+
+func toFloat(v any) (float64, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number '%s'", v)
+		}
+
+		return f, nil
+	default:
+		return 0, fmt.Errorf("cannot convert %T to number", v)
+	}
+}
+
+// setField sets the target field of the port.
+func setField(port *domain.Port, target string, v any) error {
+	switch target {
+	case "coordinates":
+		coordinates, _ := v.([]float64)
+		port.Coordinates = coordinates
+	case "alias":
+		port.Alias, _ = v.([]string)
+	case "regions":
+		port.Regions, _ = v.([]string)
+	case "unlocs":
+		port.Unlocs, _ = v.([]string)
+	default:
+		s, _ := v.(string)
+
+		switch target {
+		case "name":
+			port.Name = s
+		case "city":
+			port.City = s
+		case "country":
+			port.Country = s
+		case "province":
+			port.Province = s
+		case "timezone":
+			port.Timezone = s
+		case "code":
+			port.Code = s
+		default:
+			return fmt.Errorf("unknown target field '%s'", target)
+		}
+	}
+
+	return nil
+}
+
+func (e *fieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.field, e.err)
+}
+
+func (e *fieldError) Unwrap() error {
+	return e.err
+}
+
+// portDecoder returns the function decoding the port JSON values, using
+// jsoniter when fast is set.
+func (i *PortIngestor) portDecoder(fast bool) decodeFunc {
+	switch {
+	case i.mapping != nil:
+		return i.mapping.decode
+	case fast:
+		return decodeFast
+	default:
+		return decodeStd
+	}
+}
+
+func decodeStd(raw []byte, port *domain.Port) error {
+	return coordinatesError(raw, json.Unmarshal(raw, port))
+}
+
+func decodeFast(raw []byte, port *domain.Port) error {
+	if err := fastJSON.Unmarshal(raw, port); err != nil {
+		// decode again to describe the error as the standard library does
+		if sErr := decodeStd(raw, new(domain.Port)); sErr != nil {
+			return sErr
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// coordinatesError describes the type errors of coordinates given as an
+// array as the standard library does for plain arrays, with the field path
+// and the offset within the port, which are lost when decoding them as
+// domain.Coordinates.
+func coordinatesError(raw []byte, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Struct != "" {
+		return err
+	}
+
+	var plain struct {
+		Coordinates []float64 `json:"coordinates"`
+	}
+
+	if pErr := json.Unmarshal(raw, &plain); errors.As(pErr, &typeErr) {
+		typeErr.Struct = "Port"
+		return typeErr
+	}
+
+	return err
+}
+
+// WithMapping sets the mapping of the source fields of JSON inputs.
+func WithMapping(m *Mapping) PortIngestorOption {
+	return func(pi *PortIngestor) {
+		pi.mapping = m
+	}
+}
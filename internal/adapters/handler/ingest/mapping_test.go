@@ -0,0 +1,118 @@
+package ingest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/domain/domaintest"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMapping(t *testing.T) {
+	t.Run("file not found", func(t *testing.T) {
+		_, err := ingest.LoadMapping("abc.yaml")
+		assert.EqualError(t, err, "failed to read mapping file: open abc.yaml: no such file or directory")
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		m, err := ingest.LoadMapping("testdata/mapping.yaml")
+		assert.NoError(t, err)
+		assert.Equal(t,
+			ingest.FieldMapping{From: ingest.SourceFields{"location.lng", "location.lat"}},
+			m.Fields["coordinates"],
+		)
+		assert.Equal(t, ingest.FieldMapping{From: ingest.SourceFields{"unloc"}}, m.Fields["unlocs"])
+	})
+
+	t.Run("json", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "mapping.json")
+		assert.NoError(t, os.WriteFile(filename, []byte(`{"fields": {"name": "port_name"}}`), 0o600))
+
+		m, err := ingest.LoadMapping(filename)
+		assert.NoError(t, err)
+		assert.Equal(t, ingest.FieldMapping{From: ingest.SourceFields{"port_name"}}, m.Fields["name"])
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "mapping.yaml")
+		assert.NoError(t, os.WriteFile(filename, []byte(`
+fields:
+  id: key
+  name: {from: [first, last]}
+  city: {convert: upper}
+  country: {from: cc, convert: reverse}
+`), 0o600))
+
+		_, err := ingest.LoadMapping(filename)
+		assert.EqualError(t, err, "invalid mapping file: city: either a source field or a default is required\n"+
+			"country: unknown conversion 'reverse'\n"+
+			"id: unknown target field\n"+
+			"name: at most 1 source fields allowed")
+	})
+}
+
+func TestMapping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mapping, err := ingest.LoadMapping("testdata/mapping.yaml")
+	assert.NoError(t, err)
+
+	mockedPortSvc := porttest.NewMockPortService(ctrl)
+	mockedPortSvc.EXPECT().
+		BulkUpsert(
+			gomock.Any(),
+			domaintest.PortsMatcher(
+				domain.Ports{
+					{
+						ID:          "AEAJM",
+						Name:        "Ajman",
+						Country:     "AE",
+						Coordinates: []float64{55.5136433, 25.4052165},
+						Alias:       []string{"Ajman Port", "Ajman Marine"},
+						Unlocs:      []string{"AEAJM"},
+						Timezone:    "Asia/Dubai",
+						Code:        "52000",
+					},
+					{
+						ID:       "AEAUH",
+						Name:     "Abu Dhabi",
+						City:     "Abu Dhabi",
+						Country:  "AE",
+						Timezone: "UTC",
+					},
+				},
+			),
+		).
+		Return(nil)
+
+	ingestor := ingest.NewPortIngestor(
+		mockedPortSvc,
+		loggerTest,
+		ingest.WithMapping(mapping),
+		ingest.WithLenient(0),
+	)
+
+	err = ingestor.Process(context.Background(), "testdata/ports_partner.json")
+	assert.NoError(t, err)
+
+	report, err := ingestor.DryRun(context.Background(), "testdata/ports_partner.json")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Malformed)
+	assert.Equal(t,
+		ingest.Problem{
+			Key:     "AEDXB",
+			Path:    `$["AEDXB"].coordinates`,
+			Line:    16,
+			Column:  3,
+			Message: "error on decoding port with id 'AEDXB': coordinates: invalid number 'north'",
+		},
+		report.Problems[0],
+	)
+}
@@ -0,0 +1,170 @@
+package ingest
+
+import (
+	"io"
+	"runtime"
+	"sync"
+)
+
+// chunkSize is the number of members decoded at once by a decoder.
+const chunkSize = 256
+
+type (
+	// parallelJSONReader reads ports from a JSON object keyed by port ID,
+	// splitting the object members on a single goroutine and decoding them in
+	// chunks on several others. Entries are returned in the input order.
+	parallelJSONReader struct {
+		// order holds the result of each chunk, in the input order.
+		order chan chan chunk
+		stop  chan struct{}
+		once  sync.Once
+
+		cur []result
+		err error
+	}
+
+	// chunk is a group of members, along with their decoding results.
+	chunk struct {
+		members []member
+		results []result
+		// err is the error which stopped the scanning after the members.
+		err error
+	}
+
+	result struct {
+		e   entry
+		err error
+	}
+
+	job struct {
+		c   chunk
+		out chan chunk
+	}
+)
+
+func newParallelJSONReader(r io.Reader, decoders int, decode decodeFunc) (*parallelJSONReader, error) {
+	members, err := newMemberScanner(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if decoders <= 0 {
+		decoders = runtime.GOMAXPROCS(0)
+	}
+
+	pr := &parallelJSONReader{
+		order: make(chan chan chunk, 2*decoders),
+		stop:  make(chan struct{}),
+	}
+
+	jobs := make(chan job, decoders)
+
+	for n := 0; n < decoders; n++ {
+		go decodeChunks(jobs, decode)
+	}
+
+	go pr.split(members, jobs)
+
+	return pr, nil
+}
+
+// split splits the input members into chunks, to be decoded by the decoders.
+func (r *parallelJSONReader) split(members *memberScanner, jobs chan<- job) {
+	defer close(jobs)
+	defer close(r.order)
+
+	for {
+		c := chunk{members: make([]member, 0, chunkSize)}
+
+		for len(c.members) < chunkSize {
+			m, err := members.next()
+			if err != nil {
+				c.err = err
+				break
+			}
+
+			// the raw value is only valid until the next member is read
+			m.raw = append([]byte(nil), m.raw...)
+			c.members = append(c.members, m)
+		}
+
+		j := job{c: c, out: make(chan chunk, 1)}
+
+		select {
+		case <-r.stop:
+			return
+		case r.order <- j.out:
+		}
+
+		select {
+		case <-r.stop:
+			return
+		case jobs <- j:
+		}
+
+		if c.err != nil {
+			return
+		}
+	}
+}
+
+func decodeChunks(jobs <-chan job, decode decodeFunc) {
+	for j := range jobs {
+		j.c.results = make([]result, len(j.c.members))
+
+		for idx := range j.c.members {
+			e, err := j.c.members[idx].decode(decode)
+			j.c.results[idx] = result{e: e, err: err}
+		}
+
+		j.c.members = nil
+		j.out <- j.c
+	}
+}
+
+func (r *parallelJSONReader) next() (entry, error) {
+	for len(r.cur) == 0 {
+		if r.err != nil {
+			return entry{}, r.err
+		}
+
+		out, ok := <-r.order
+		if !ok {
+			return entry{}, io.EOF
+		}
+
+		c := <-out
+		r.cur = c.results
+		r.err = c.err
+	}
+
+	res := r.cur[0]
+	r.cur = r.cur[1:]
+
+	return res.e, res.err
+}
+
+// Close stops the splitting and decoding of the remaining input.
+func (r *parallelJSONReader) Close() error {
+	r.once.Do(func() {
+		close(r.stop)
+	})
+
+	return nil
+}
+
+// closeReader releases the resources held by r, if any.
+func closeReader(r portReader) {
+	if c, ok := r.(io.Closer); ok {
+		_ = c.Close()
+	}
+}
+
+// WithDecoders sets the number of goroutines decoding JSON inputs. More than
+// one enables splitting the input into chunks of ports decoded in parallel,
+// which pays off on large inputs. Zero or less uses all the available CPUs.
+func WithDecoders(n int) PortIngestorOption {
+	return func(pi *PortIngestor) {
+		pi.decoders = n
+	}
+}
@@ -0,0 +1,161 @@
+package ingest_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+const portsFile = "../../../../testdata/ports.json"
+
+// recordingPortService keeps the ports upserted through it.
+type recordingPortService struct {
+	mu    sync.Mutex
+	ports domain.Ports
+}
+
+func (s *recordingPortService) Get(context.Context, string) (*domain.Port, error) {
+	return nil, nil
+}
+
+func (s *recordingPortService) List(context.Context) (domain.Ports, error) {
+	return nil, nil
+}
+
+func (s *recordingPortService) BulkUpsert(_ context.Context, ports domain.Ports) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ports = append(s.ports, ports...)
+
+	return nil
+}
+
+func (s *recordingPortService) BulkDelete(context.Context, []string) error {
+	return nil
+}
+
+func TestParallelDecoding(t *testing.T) {
+	process := func(t *testing.T, filename string, opts ...ingest.PortIngestorOption) (domain.Ports, error) {
+		svc := &recordingPortService{}
+
+		// a single batch keeps the ports in the input order
+		opts = append(opts, ingest.WithBatchSize(1_000_000))
+
+		err := ingest.NewPortIngestor(svc, loggerTest, opts...).Process(context.Background(), filename)
+
+		return svc.ports, err
+	}
+
+	t.Run("same ports and order", func(t *testing.T) {
+		want, err := process(t, portsFile)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, want)
+
+		for _, decoders := range []int{0, 2, 7} {
+			got, err := process(t, portsFile, ingest.WithDecoders(decoders))
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		}
+	})
+
+	t.Run("malformed port", func(t *testing.T) {
+		_, err := process(t, "testdata/ports_dryrun.json", ingest.WithDecoders(2))
+		assert.ErrorContains(t, err, "error on decoding port with id 'AEAUH'")
+
+		got, err := process(t, "testdata/ports_lenient.json", ingest.WithDecoders(2), ingest.WithLenient(0))
+		assert.NoError(t, err)
+		assert.Len(t, got, 2)
+	})
+}
+
+func BenchmarkProcess(b *testing.B) {
+	filename := scalePortsFile(b, 50)
+
+	fi, err := os.Stat(filename)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, decoders := range []int{1, 2, 4, 0} {
+		b.Run(fmt.Sprintf("decoders=%d", decoders), func(b *testing.B) {
+			b.SetBytes(fi.Size())
+
+			for n := 0; n < b.N; n++ {
+				ingestor := ingest.NewPortIngestor(
+					&recordingPortService{},
+					loggerTest,
+					ingest.WithBatchSize(500),
+					ingest.WithDecoders(decoders),
+				)
+
+				if err := ingestor.Process(context.Background(), filename); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// scalePortsFile writes a ports file with the ports of testdata/ports.json
+// repeated times times, under different keys.
+func scalePortsFile(b *testing.B, times int) string {
+	b.Helper()
+
+	data, err := os.ReadFile(portsFile)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var ports map[string]json.RawMessage
+	if err = json.Unmarshal(data, &ports); err != nil {
+		b.Fatal(err)
+	}
+
+	keys := make([]string, 0, len(ports))
+	for k := range ports {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	filename := filepath.Join(b.TempDir(), "ports.json")
+
+	f, err := os.Create(filename)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	_, _ = w.WriteString("{\n")
+
+	for n := 0; n < times; n++ {
+		for idx, k := range keys {
+			if n > 0 || idx > 0 {
+				_, _ = w.WriteString(",\n")
+			}
+
+			_, _ = fmt.Fprintf(w, "%q: %s", fmt.Sprintf("%s%d", k, n), ports[k])
+		}
+	}
+
+	_, _ = w.WriteString("\n}\n")
+
+	if err = w.Flush(); err != nil {
+		b.Fatal(err)
+	}
+
+	return filename
+}
@@ -2,35 +2,97 @@ package ingest
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
-	"os"
-	"path/filepath"
+	"net/http"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/rafaeltg/goports/internal/core/domain"
 	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/rules"
 	"github.com/rafaeltg/goports/pkg/logging"
+	"golang.org/x/time/rate"
 )
 
 const batchSizeDefault int = 20
 
+const (
+	// FormatJSON is a JSON object of ports keyed by their IDs.
+	FormatJSON Format = "json"
+	// FormatUNLocode is the UNECE UN/LOCODE code list CSV.
+	FormatUNLocode Format = "unlocode"
+	// FormatGeoJSON is a GeoJSON feature collection of port points.
+	FormatGeoJSON Format = "geojson"
+)
+
+const (
+	opUpsert operation = iota
+	opDelete
+)
+
 type (
 	PortIngestor struct {
-		portSvc   port.PortService
-		batchSize int
-		logger    *slog.Logger
+		portSvc    port.PortService
+		batchSize  int
+		format     Format
+		httpClient *http.Client
+		logger     *slog.Logger
+
+		maxInputBytes int64
+
+		lenient   bool
+		maxErrors int
+		decoders  int
+		mapping   *Mapping
+		script    *Script
+		rules     *rules.Engine
+
+		adaptive *AdaptiveOptions
+		limiter  *rate.Limiter
+
+		progressFn       ProgressFunc
+		progressInterval time.Duration
+		reportPath       string
 	}
 
 	PortIngestorOption func(*PortIngestor)
+
+	// Format is the layout of the file to be ingested.
+	Format string
+
+	// operation is the action to be applied to a port read from the input.
+	operation int
+
+	// entry is a port read from the input along with the action to apply to it.
+	entry struct {
+		port domain.Port
+		op   operation
+		key  string
+		path string
+		pos  position
+	}
+
+	// portReader reads ports one at a time from an input.
+	portReader interface {
+		// next returns the next entry, or io.EOF when the input is exhausted.
+		next() (entry, error)
+	}
 )
 
 func NewPortIngestor(svc port.PortService, logger *slog.Logger, opts ...PortIngestorOption) *PortIngestor {
 	i := &PortIngestor{
-		portSvc:   svc,
-		logger:    logger,
-		batchSize: batchSizeDefault,
+		portSvc:    svc,
+		logger:     logger,
+		batchSize:  batchSizeDefault,
+		format:     FormatJSON,
+		httpClient: http.DefaultClient,
+		decoders:   1,
+
+		progressInterval: progressIntervalDefault,
 	}
 
 	for _, opt := range opts {
@@ -40,111 +102,313 @@ func NewPortIngestor(svc port.PortService, logger *slog.Logger, opts ...PortInge
 	return i
 }
 
-func (i *PortIngestor) Process(ctx context.Context, filename string) error {
+// Process ingests the ports read from the given input, which may be a local
+// file path, StdinInput or an http(s) URL, optionally compressed.
+func (i *PortIngestor) Process(ctx context.Context, filename string) (err error) {
 	l := i.logger.With(
 		slog.String("filepath", filename),
 	)
 
 	l.InfoContext(ctx, "[PortIngestor.Process] processing")
 
-	f, err := os.Open(filepath.Clean(filename))
+	stats := newRun(filename)
+	stats.batchSize = i.batchSize
+	stats.rateLimit = i.rateLimit()
+
+	if i.adaptive != nil {
+		stats.ctl = newController(*i.adaptive, i.batchSize)
+	}
+
+	defer func() {
+		i.finish(ctx, stats, err)
+	}()
+
+	in, err := i.openInput(ctx, filename)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return err
 	}
 
-	defer func(file *os.File) {
-		if err := file.Close(); err != nil {
+	stats.in = in
+
+	defer func() {
+		if err := in.Close(); err != nil {
 			l.Error(
 				"error on closing file",
 				logging.Error(err),
 			)
 		}
-	}(f)
+	}()
 
-	if _, err = f.Stat(); err != nil {
-		return fmt.Errorf("finvalid file: %w", err)
+	r, err := i.newReader(in)
+	if err != nil {
+		return err
 	}
 
-	dec := json.NewDecoder(f)
+	defer closeReader(r)
 
-	// read opening JSON delimiter
-	token, err := dec.Token()
-	if err != nil {
-		return fmt.Errorf("failed to read opening delimiter: %w", err)
-	}
+	if i.progressFn != nil {
+		stop := make(chan struct{})
+		defer close(stop)
 
-	if token != json.Delim('{') {
-		return fmt.Errorf("unexpected token encountered on reading opening delimiterr: %s", token)
+		go stats.report(i.progressFn, i.progressInterval, stop)
 	}
 
 	wg := sync.WaitGroup{}
 	errCh := make(chan error)
-	batch := make(domain.Ports, 0, i.batchSize)
+	upserts := make(domain.Ports, 0, i.batchSize)
+	deletes := make([]string, 0, i.batchSize)
+
+	send := func(fn func() error) {
+		if err := stats.acquire(ctx); err != nil {
+			// the context is done, which stops the ingestion
+			return
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := fn()
+			stats.release()
+
+			if err != nil {
+				errCh <- err
+			}
+		}()
+	}
+
+	// wait waits for the in-flight batches, returning the first error found.
+	wait := func() error {
+		waited := make(chan struct{})
+
+		go func() {
+			wg.Wait()
+			close(waited)
+		}()
+
+		var first error
+
+		for {
+			select {
+			case <-waited:
+				return first
+			case bErr := <-errCh:
+				if first == nil {
+					first = bErr
+				}
+			}
+		}
+	}
+
+	// flush sends the pending batch and waits for it, along with the other
+	// in-flight batches, before batching entries of another operation, so
+	// that a port deleted and re-added, or the other way around, is written
+	// in the order it was read.
+	flush := func() error {
+		if len(upserts) > 0 {
+			ports := upserts
+			send(func() error { return i.bulkUpsert(ctx, stats, ports) })
+
+			upserts = make(domain.Ports, 0, stats.size())
+		}
+
+		if len(deletes) > 0 {
+			ids := deletes
+			send(func() error { return i.bulkDelete(ctx, stats, ids) })
+
+			deletes = make([]string, 0, stats.size())
+		}
+
+		return wait()
+	}
 
 	done := false
-	for !done && dec.More() {
+	eof := false
+	last := opUpsert
+
+	for !done && !eof {
 		select {
 		case <-ctx.Done():
 			done = true
 		case err = <-errCh:
 			done = true
 		default:
-			var id json.Token
+			var e entry
 
-			id, err = dec.Token()
-			if err != nil {
-				err = fmt.Errorf("failed to read port key: %w", err)
-				done = true
+			e, err = r.next()
+			if errors.Is(err, io.EOF) {
+				err = nil
+				eof = true
 
 				continue
 			}
 
-			key, ok := id.(string)
-			if !ok {
-				err = fmt.Errorf("unexpected type for port key: '%T'", token)
-				done = true
-
-				continue
+			if err != nil && i.lenient {
+				if err = i.skip(ctx, stats, err); err == nil {
+					continue
+				}
 			}
 
-			// read the rest of the port JSON
-			var port domain.Port
-
-			err = dec.Decode(&port)
 			if err != nil {
-				err = fmt.Errorf("error on decoding port with id '%s': %w", key, err)
 				done = true
 
 				continue
 			}
 
-			port.ID = key
+			stats.decoded.Add(1)
 
-			batch = append(batch, port)
+			if e.op != last {
+				last = e.op
 
-			if len(batch) == i.batchSize {
-				wg.Add(1)
+				if err = flush(); err != nil {
+					done = true
 
-				go func(ports domain.Ports) {
-					defer wg.Done()
+					continue
+				}
+			}
+
+			switch e.op {
+			case opDelete:
+				deletes = append(deletes, e.port.ID)
+
+				if size := stats.size(); len(deletes) >= size {
+					ids := deletes
+					send(func() error { return i.bulkDelete(ctx, stats, ids) })
+
+					deletes = make([]string, 0, size)
+				}
+			default:
+				upserts = append(upserts, e.port)
 
-					err := i.portSvc.BulkUpsert(ctx, ports)
-					if err != nil {
-						errCh <- err
-					}
-				}(batch)
+				if size := stats.size(); len(upserts) >= size {
+					ports := upserts
+					send(func() error { return i.bulkUpsert(ctx, stats, ports) })
 
-				batch = make(domain.Ports, 0, i.batchSize)
+					upserts = make(domain.Ports, 0, size)
+				}
 			}
 		}
 	}
 
-	if !done && len(batch) > 0 {
-		err = i.portSvc.BulkUpsert(ctx, batch)
+	if !done && len(upserts) > 0 {
+		err = stats.within(ctx, func() error { return i.bulkUpsert(ctx, stats, upserts) })
+	}
+
+	if !done && err == nil && len(deletes) > 0 {
+		err = stats.within(ctx, func() error { return i.bulkDelete(ctx, stats, deletes) })
+	}
+
+	// wait for in-flight batches, keeping the first error found
+	go func() {
+		wg.Wait()
+		close(errCh)
+	}()
+
+	for bErr := range errCh {
+		if err == nil {
+			err = bErr
+		}
+	}
+
+	return err
+}
+
+// skip records err and returns nil when it is a malformed record to be
+// skipped, unless too many records were skipped already.
+func (i *PortIngestor) skip(ctx context.Context, stats *run, err error) error {
+	var rErr *recordError
+	if !errors.As(err, &rErr) || !rErr.recoverable {
+		return err
+	}
+
+	i.logger.WarnContext(ctx,
+		"[PortIngestor.Process] skipping malformed record",
+		slog.String("key", rErr.key),
+		slog.String("position", rErr.pos.String()),
+		logging.Error(err),
+	)
+
+	if n := stats.skip(rErr); i.maxErrors > 0 && n > i.maxErrors {
+		return fmt.Errorf("%w: %d skipped, max %d", ErrTooManyErrors, n, i.maxErrors)
+	}
+
+	return nil
+}
+
+func (i *PortIngestor) bulkUpsert(ctx context.Context, stats *run, ports domain.Ports) error {
+	return i.write(ctx, stats, len(ports), false, func() error {
+		return i.portSvc.BulkUpsert(ctx, ports)
+	})
+}
+
+func (i *PortIngestor) bulkDelete(ctx context.Context, stats *run, ids []string) error {
+	return i.write(ctx, stats, len(ids), true, func() error {
+		return i.portSvc.BulkDelete(ctx, ids)
+	})
+}
+
+// write sends a batch of n ports within the rate limit, retrying it when
+// adaptive, and records its outcome.
+func (i *PortIngestor) write(ctx context.Context, stats *run, n int, del bool, fn func() error) error {
+	stats.sent.Add(1)
+
+	for attempt := 0; ; attempt++ {
+		if err := i.waitRate(ctx, n); err != nil {
+			stats.batchDone(n, del, 0, err)
+			return err
+		}
+
+		start := time.Now()
+		err := fn()
+		latency := time.Since(start)
+
+		// rejected ports would be rejected again, and say nothing of the load
+		if stats.ctl == nil || ctx.Err() != nil || rejected(err) {
+			stats.batchDone(n, del, latency, err)
+			return err
+		}
+
+		stats.ctl.observe(latency, err)
+
+		if err == nil || attempt == stats.ctl.opts.MaxRetries {
+			stats.batchDone(n, del, latency, err)
+			return err
+		}
+
+		stats.retries.Add(1)
+
+		if bErr := stats.ctl.backoff(ctx, attempt+1); bErr != nil {
+			stats.batchDone(n, del, latency, err)
+			return err
+		}
+	}
+}
+
+// rejected reports whether err rejects the ports sent, rather than failing
+// to write them.
+func rejected(err error) bool {
+	var (
+		vErr  *rules.ViolationError
+		pvErr *domain.PortsValidationError
+		urErr *port.UnknownRegionsError
+	)
+
+	return errors.Is(err, port.ErrPortsRejected) ||
+		errors.As(err, &vErr) || errors.As(err, &pvErr) || errors.As(err, &urErr)
+}
+
+func (i *PortIngestor) rateLimit() float64 {
+	if i.limiter == nil {
+		return 0
 	}
 
-	wg.Wait()
+	return float64(i.limiter.Limit())
+}
 
+// finish logs the outcome of a run, reporting its final progress and
+// writing its summary when set to.
+func (i *PortIngestor) finish(ctx context.Context, stats *run, err error) {
 	if err != nil {
 		i.logger.ErrorContext(ctx,
 			"[PortIngestor.Process] failed to process file",
@@ -152,7 +416,73 @@ func (i *PortIngestor) Process(ctx context.Context, filename string) error {
 		)
 	}
 
-	return err
+	if i.progressFn != nil {
+		i.progressFn(stats.progress())
+	}
+
+	if i.reportPath == "" {
+		return
+	}
+
+	if err == nil {
+		// a cancelled run stops early without failing
+		err = ctx.Err()
+	}
+
+	if wErr := writeRunReport(i.reportPath, stats.summary(err)); wErr != nil {
+		i.logger.ErrorContext(ctx,
+			"[PortIngestor.Process] failed to write report",
+			logging.Error(wErr),
+		)
+	}
+}
+
+// ParseFormat parses an input format, an empty one being the JSON one.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case "":
+		return FormatJSON, nil
+	case FormatJSON, FormatUNLocode, FormatGeoJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported input format: '%s'", s)
+	}
+}
+
+func (i *PortIngestor) newReader(r io.Reader) (portReader, error) {
+	pr, err := i.newFormatReader(r)
+	if err != nil || i.script == nil {
+		return pr, err
+	}
+
+	return &scriptReader{portReader: pr, script: i.script}, nil
+}
+
+func (i *PortIngestor) newFormatReader(r io.Reader) (portReader, error) {
+	switch i.format {
+	case FormatJSON, "":
+		if i.decoders != 1 {
+			return newParallelJSONReader(r, i.decoders, i.portDecoder(true))
+		}
+
+		if i.lenient {
+			return newLenientJSONReader(r, i.portDecoder(false))
+		}
+
+		// without a mapping, ports are decoded straight from the input
+		var decode decodeFunc
+		if i.mapping != nil {
+			decode = i.mapping.decode
+		}
+
+		return newJSONReader(r, decode)
+	case FormatUNLocode:
+		return newUNLocodeReader(r), nil
+	case FormatGeoJSON:
+		return newGeoJSONReader(r)
+	default:
+		return nil, fmt.Errorf("unsupported input format: '%s'", i.format)
+	}
 }
 
 func WithBatchSize(v int) PortIngestorOption {
@@ -160,3 +490,10 @@ func WithBatchSize(v int) PortIngestorOption {
 		pi.batchSize = v
 	}
 }
+
+// WithFormat sets the layout of the files to be ingested.
+func WithFormat(f Format) PortIngestorOption {
+	return func(pi *PortIngestor) {
+		pi.format = f
+	}
+}
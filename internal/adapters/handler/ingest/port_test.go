@@ -9,10 +9,13 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/adapters/repository/memory"
 	"github.com/rafaeltg/goports/internal/core/domain"
 	"github.com/rafaeltg/goports/internal/core/domain/domaintest"
 	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/rafaeltg/goports/internal/core/service"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var loggerTest = slog.New(slog.NewJSONHandler(os.Stdout, nil))
@@ -112,3 +115,48 @@ func TestProcess(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestParseFormat(t *testing.T) {
+	tcs := []struct {
+		input       string
+		expected    ingest.Format
+		expectedErr string
+	}{
+		{input: "", expected: ingest.FormatJSON},
+		{input: "json", expected: ingest.FormatJSON},
+		{input: "GeoJSON", expected: ingest.FormatGeoJSON},
+		{input: "unlocode", expected: ingest.FormatUNLocode},
+		{input: "xml", expectedErr: "unsupported input format: 'xml'"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			f, err := ingest.ParseFormat(tc.input)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, f)
+		})
+	}
+}
+
+func TestProcess_SampleData(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewPortRepository(memory.NewDatabase(), loggerTest)
+
+	ingestor := ingest.NewPortIngestor(
+		service.NewPortService(repo, loggerTest),
+		loggerTest,
+		ingest.WithBatchSize(50),
+	)
+
+	err := ingestor.Process(ctx, "../../../../testdata/ports.json")
+	require.NoError(t, err)
+
+	ports, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, ports, 1632)
+}
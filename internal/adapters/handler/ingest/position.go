@@ -0,0 +1,97 @@
+package ingest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+type (
+	// position is a location in the input, with 1-based line and byte column.
+	position struct {
+		Line   int
+		Column int
+	}
+
+	// lineCounter tracks the newlines of the data read through it in order
+	// to convert input offsets into positions. Offsets must be converted in
+	// non-decreasing order, so only the newlines ahead of the last converted
+	// offset are kept.
+	lineCounter struct {
+		r         io.Reader
+		read      int64
+		newlines  []int64
+		line      int
+		lineStart int64
+	}
+
+	// recordError is an error located in the input. A recoverable error is
+	// restricted to a single record and doesn't prevent the following records
+	// from being read.
+	recordError struct {
+		key         string
+		path        string
+		pos         position
+		err         error
+		recoverable bool
+	}
+)
+
+func (p position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// advance returns the position after the given data read from p.
+func (p position) advance(b []byte) position {
+	if i := bytes.LastIndexByte(b, '\n'); i >= 0 {
+		return position{
+			Line:   p.Line + bytes.Count(b, []byte{'\n'}),
+			Column: len(b) - i,
+		}
+	}
+
+	return position{Line: p.Line, Column: p.Column + len(b)}
+}
+
+func newLineCounter(r io.Reader) *lineCounter {
+	return &lineCounter{r: r}
+}
+
+func (c *lineCounter) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+
+	for i, b := range p[:n] {
+		if b == '\n' {
+			c.newlines = append(c.newlines, c.read+int64(i))
+		}
+	}
+
+	c.read += int64(n)
+
+	return n, err
+}
+
+// position converts an input offset into a position.
+func (c *lineCounter) position(offset int64) position {
+	i := 0
+	for i < len(c.newlines) && c.newlines[i] < offset {
+		c.lineStart = c.newlines[i] + 1
+		i++
+	}
+
+	c.line += i
+	c.newlines = append(c.newlines[:0], c.newlines[i:]...)
+
+	return position{
+		Line:   c.line + 1,
+		Column: int(offset-c.lineStart) + 1,
+	}
+}
+
+func (e *recordError) Error() string {
+	return e.err.Error()
+}
+
+func (e *recordError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,339 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	progressIntervalDefault = 5 * time.Second
+	maxReportedFailures     = 100
+	reportFilePerm          = 0o600
+)
+
+type (
+	// Progress is a snapshot of a running ingestion.
+	Progress struct {
+		Elapsed time.Duration `json:"elapsed"`
+		// BytesRead is the number of input bytes read, before decompression.
+		BytesRead int64 `json:"bytesRead"`
+		// BytesTotal is the input size, or 0 when it is unknown.
+		BytesTotal     int64   `json:"bytesTotal"`
+		PortsDecoded   int64   `json:"portsDecoded"`
+		PortsSkipped   int64   `json:"portsSkipped"`
+		BatchesSent    int64   `json:"batchesSent"`
+		BatchesAcked   int64   `json:"batchesAcked"`
+		PortsPerSecond float64 `json:"portsPerSecond"`
+		// BatchSize is the current batch size.
+		BatchSize int `json:"batchSize"`
+		// Concurrency is the current limit of concurrent batches, or 0 when unbounded.
+		Concurrency int `json:"concurrency"`
+		// RateLimit is the limit of ports sent per second, or 0 when unlimited.
+		RateLimit float64 `json:"rateLimit"`
+		// ETA is the estimated remaining time, or 0 when the input size is unknown.
+		ETA time.Duration `json:"eta"`
+	}
+
+	// ProgressFunc receives the progress of a running ingestion.
+	ProgressFunc func(Progress)
+
+	// RunReport is the summary of a finished ingestion.
+	RunReport struct {
+		Filepath      string    `json:"filepath"`
+		StartedAt     time.Time `json:"startedAt"`
+		FinishedAt    time.Time `json:"finishedAt"`
+		DurationMs    float64   `json:"durationMs"`
+		Succeeded     bool      `json:"succeeded"`
+		BytesRead     int64     `json:"bytesRead"`
+		PortsDecoded  int64     `json:"portsDecoded"`
+		PortsUpserted int64     `json:"portsUpserted"`
+		PortsDeleted  int64     `json:"portsDeleted"`
+		PortsSkipped  int64     `json:"portsSkipped"`
+		Batches       int64     `json:"batches"`
+		FailedBatches int64     `json:"failedBatches"`
+		Retries       int64     `json:"retries"`
+		Failures      []string  `json:"failures,omitempty"`
+		// Skipped holds the malformed records skipped on a lenient ingestion.
+		Skipped      []Problem          `json:"skipped,omitempty"`
+		BatchLatency LatencyPercentiles `json:"batchLatency"`
+	}
+
+	// LatencyPercentiles holds latency percentiles, in milliseconds.
+	LatencyPercentiles struct {
+		P50 float64 `json:"p50Ms"`
+		P90 float64 `json:"p90Ms"`
+		P99 float64 `json:"p99Ms"`
+		Max float64 `json:"maxMs"`
+	}
+
+	// run holds the statistics of a single ingestion.
+	run struct {
+		filename  string
+		startedAt time.Time
+		in        *input
+		batchSize int
+		rateLimit float64
+		// ctl adjusts the batch size and concurrency, when adaptive.
+		ctl *controller
+
+		decoded  atomic.Int64
+		skipped  atomic.Int64
+		sent     atomic.Int64
+		acked    atomic.Int64
+		failed   atomic.Int64
+		upserted atomic.Int64
+		deleted  atomic.Int64
+		retries  atomic.Int64
+
+		mu        sync.Mutex
+		latencies []time.Duration
+		failures  []string
+		problems  []Problem
+	}
+)
+
+func newRun(filename string) *run {
+	return &run{
+		filename:  filename,
+		startedAt: time.Now(),
+	}
+}
+
+// bytes returns the input bytes read and the input size, if known.
+func (r *run) bytes() (read, total int64) {
+	if r.in == nil {
+		return 0, 0
+	}
+
+	return r.in.read.n.Load(), r.in.size
+}
+
+// settings returns the current batch size and concurrency limit.
+func (r *run) settings() (size, concurrency int) {
+	if r.ctl == nil {
+		return r.batchSize, 0
+	}
+
+	return r.ctl.settings()
+}
+
+// size returns the current batch size.
+func (r *run) size() int {
+	size, _ := r.settings()
+	return size
+}
+
+// acquire waits for a batch slot when the concurrency is limited.
+func (r *run) acquire(ctx context.Context) error {
+	if r.ctl == nil {
+		return nil
+	}
+
+	return r.ctl.acquire(ctx)
+}
+
+// release frees a batch slot taken by acquire.
+func (r *run) release() {
+	if r.ctl != nil {
+		r.ctl.release()
+	}
+}
+
+// within runs fn holding a batch slot.
+func (r *run) within(ctx context.Context, fn func() error) error {
+	if err := r.acquire(ctx); err != nil {
+		return err
+	}
+
+	defer r.release()
+
+	return fn()
+}
+
+// batchDone records the outcome of a batch of n ports.
+func (r *run) batchDone(n int, del bool, latency time.Duration, err error) {
+	r.mu.Lock()
+	r.latencies = append(r.latencies, latency)
+	r.mu.Unlock()
+
+	if err != nil {
+		r.failed.Add(1)
+		r.fail(err)
+
+		return
+	}
+
+	r.acked.Add(1)
+
+	if del {
+		r.deleted.Add(int64(n))
+	} else {
+		r.upserted.Add(int64(n))
+	}
+}
+
+// skip records a skipped malformed record, returning the number of records
+// skipped so far.
+func (r *run) skip(rErr *recordError) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.problems) < maxReportedFailures {
+		r.problems = append(r.problems, rErr.problem())
+	}
+
+	return int(r.skipped.Add(1))
+}
+
+// fail records an ingestion failure.
+func (r *run) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.failures) < maxReportedFailures {
+		r.failures = append(r.failures, err.Error())
+	}
+}
+
+func (r *run) progress() Progress {
+	read, total := r.bytes()
+	size, concurrency := r.settings()
+
+	p := Progress{
+		Elapsed:      time.Since(r.startedAt),
+		BytesRead:    read,
+		BytesTotal:   total,
+		PortsDecoded: r.decoded.Load(),
+		PortsSkipped: r.skipped.Load(),
+		BatchesSent:  r.sent.Load(),
+		BatchesAcked: r.acked.Load(),
+		BatchSize:    size,
+		Concurrency:  concurrency,
+		RateLimit:    r.rateLimit,
+	}
+
+	if secs := p.Elapsed.Seconds(); secs > 0 {
+		p.PortsPerSecond = float64(p.PortsDecoded) / secs
+	}
+
+	if p.BytesTotal > 0 && p.BytesRead > 0 && p.BytesRead < p.BytesTotal {
+		remaining := float64(p.BytesTotal-p.BytesRead) / float64(p.BytesRead)
+		p.ETA = time.Duration(float64(p.Elapsed) * remaining)
+	}
+
+	return p
+}
+
+// report reports the progress every interval until stop is closed.
+func (r *run) report(fn ProgressFunc, interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = progressIntervalDefault
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			fn(r.progress())
+		}
+	}
+}
+
+func (r *run) summary(err error) RunReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	finishedAt := time.Now()
+	read, _ := r.bytes()
+	failures := append([]string(nil), r.failures...)
+
+	if err != nil && len(failures) == 0 {
+		failures = append(failures, err.Error())
+	}
+
+	return RunReport{
+		Filepath:      r.filename,
+		StartedAt:     r.startedAt.UTC(),
+		FinishedAt:    finishedAt.UTC(),
+		DurationMs:    milliseconds(finishedAt.Sub(r.startedAt)),
+		Succeeded:     err == nil,
+		BytesRead:     read,
+		PortsDecoded:  r.decoded.Load(),
+		PortsUpserted: r.upserted.Load(),
+		PortsDeleted:  r.deleted.Load(),
+		PortsSkipped:  r.skipped.Load(),
+		Batches:       r.sent.Load(),
+		FailedBatches: r.failed.Load(),
+		Retries:       r.retries.Load(),
+		Failures:      failures,
+		Skipped:       append([]Problem(nil), r.problems...),
+		BatchLatency:  percentiles(r.latencies),
+	}
+}
+
+func percentiles(latencies []time.Duration) LatencyPercentiles {
+	if len(latencies) == 0 {
+		return LatencyPercentiles{}
+	}
+
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	at := func(p float64) float64 {
+		idx := int(p*float64(len(sorted))+0.5) - 1
+		idx = max(0, min(idx, len(sorted)-1))
+
+		return milliseconds(sorted[idx])
+	}
+
+	return LatencyPercentiles{
+		P50: at(0.50),
+		P90: at(0.90),
+		P99: at(0.99),
+		Max: milliseconds(sorted[len(sorted)-1]),
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func writeRunReport(path string, report RunReport) error {
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(filepath.Clean(path), b, reportFilePerm); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
+
+// WithProgress sets a function to receive the ingestion progress every
+// interval, and once more when the ingestion finishes.
+func WithProgress(fn ProgressFunc, interval time.Duration) PortIngestorOption {
+	return func(pi *PortIngestor) {
+		pi.progressFn = fn
+		pi.progressInterval = interval
+	}
+}
+
+// WithReportPath sets the path where the JSON summary of each run is written.
+func WithReportPath(path string) PortIngestorOption {
+	return func(pi *PortIngestor) {
+		pi.reportPath = path
+	}
+}
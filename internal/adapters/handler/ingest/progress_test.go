@@ -0,0 +1,118 @@
+package ingest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(2)
+
+		var events []ingest.Progress
+
+		reportPath := filepath.Join(t.TempDir(), "report.json")
+
+		ingestor := ingest.NewPortIngestor(
+			mockedPortSvc,
+			loggerTest,
+			ingest.WithBatchSize(3),
+			ingest.WithReportPath(reportPath),
+			ingest.WithProgress(func(p ingest.Progress) {
+				events = append(events, p)
+			}, time.Hour),
+		)
+
+		err := ingestor.Process(context.Background(), "testdata/ports_valid.json")
+		assert.NoError(t, err)
+
+		fi, err := os.Stat("testdata/ports_valid.json")
+		assert.NoError(t, err)
+
+		// only the final event is reported before the first tick
+		assert.Len(t, events, 1)
+		assert.Equal(t, int64(4), events[0].PortsDecoded)
+		assert.Equal(t, int64(2), events[0].BatchesSent)
+		assert.Equal(t, int64(2), events[0].BatchesAcked)
+		assert.Equal(t, fi.Size(), events[0].BytesTotal)
+		assert.Equal(t, fi.Size(), events[0].BytesRead)
+
+		report := readRunReport(t, reportPath)
+		assert.True(t, report.Succeeded)
+		assert.Equal(t, "testdata/ports_valid.json", report.Filepath)
+		assert.Equal(t, int64(4), report.PortsDecoded)
+		assert.Equal(t, int64(4), report.PortsUpserted)
+		assert.Equal(t, int64(2), report.Batches)
+		assert.Zero(t, report.FailedBatches)
+		assert.Empty(t, report.Failures)
+		assert.GreaterOrEqual(t, report.BatchLatency.Max, report.BatchLatency.P50)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(gomock.Any(), gomock.Any()).
+			Return(errors.New("some error"))
+
+		reportPath := filepath.Join(t.TempDir(), "report.json")
+
+		ingestor := ingest.NewPortIngestor(
+			mockedPortSvc,
+			loggerTest,
+			ingest.WithBatchSize(10),
+			ingest.WithReportPath(reportPath),
+		)
+
+		err := ingestor.Process(context.Background(), "testdata/ports_valid.json")
+		assert.EqualError(t, err, "some error")
+
+		report := readRunReport(t, reportPath)
+		assert.False(t, report.Succeeded)
+		assert.Equal(t, int64(1), report.Batches)
+		assert.Equal(t, int64(1), report.FailedBatches)
+		assert.Zero(t, report.PortsUpserted)
+		assert.Equal(t, []string{"some error"}, report.Failures)
+	})
+
+	t.Run("file not found", func(t *testing.T) {
+		reportPath := filepath.Join(t.TempDir(), "report.json")
+
+		ingestor := ingest.NewPortIngestor(nil, loggerTest, ingest.WithReportPath(reportPath))
+
+		err := ingestor.Process(context.Background(), "abc.json")
+		assert.Error(t, err)
+
+		report := readRunReport(t, reportPath)
+		assert.False(t, report.Succeeded)
+		assert.Equal(t, []string{err.Error()}, report.Failures)
+	})
+}
+
+func readRunReport(t *testing.T, path string) ingest.RunReport {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	var report ingest.RunReport
+	assert.NoError(t, json.Unmarshal(b, &report))
+
+	return report
+}
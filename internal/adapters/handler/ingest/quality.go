@@ -0,0 +1,35 @@
+package ingest
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/quality"
+)
+
+// Quality reads all the ports from the input, as Sync does, and analyses
+// their data quality without writing anything.
+func (i *PortIngestor) Quality(ctx context.Context, filename string) (*quality.Report, error) {
+	i.logger.InfoContext(ctx,
+		"[PortIngestor.Quality] processing",
+		slog.String("filepath", filename),
+	)
+
+	read, err := i.readAll(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	ports := make(domain.Ports, 0, len(read))
+	for _, p := range read {
+		ports = append(ports, p)
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].ID < ports[j].ID
+	})
+
+	return quality.Analyse(ports), nil
+}
@@ -0,0 +1,38 @@
+package ingest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/quality"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuality(t *testing.T) {
+	t.Run("file not found", func(t *testing.T) {
+		ingestor := ingest.NewPortIngestor(nil, loggerTest)
+
+		report, err := ingestor.Quality(context.Background(), "abc.json")
+		assert.EqualError(t, err, "failed to read file: open abc.json: no such file or directory")
+		assert.Nil(t, report)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ingestor := ingest.NewPortIngestor(nil, loggerTest)
+
+		report, err := ingestor.Quality(context.Background(), "../../../../testdata/ports.json")
+		require.NoError(t, err)
+		assert.Equal(t, 1632, report.Ports)
+		assert.Equal(t, 1, report.Counts[quality.CheckSuspiciousEncoding])
+		assert.Equal(t, 1, report.Counts[quality.CheckCountryMismatch])
+		assert.Equal(t, 9, report.Counts[quality.CheckSwappedCoordinates])
+		assert.Contains(t, report.Issues, quality.Issue{
+			PortID:  "AEAUH",
+			Check:   quality.CheckSuspiciousEncoding,
+			Field:   "province",
+			Message: "suspicious encoding of 'Abu Z¸aby [Abu Dhabi]'",
+		})
+	})
+}
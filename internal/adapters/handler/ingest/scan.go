@@ -0,0 +1,328 @@
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+)
+
+const scanBufferSize = 64 << 10
+
+type (
+	// memberScanner splits a JSON object keyed by port ID into its members
+	// without decoding their values. A malformed member, even one which is
+	// not valid JSON, is skipped up to the next member.
+	memberScanner struct {
+		br     *bufio.Reader
+		lines  *lineCounter
+		offset int64
+		done   bool
+		buf    bytes.Buffer
+	}
+
+	// member is an object member read by memberScanner.
+	member struct {
+		key string
+		// raw is the undecoded value.
+		raw []byte
+		// pos is the position of the key, and valuePos the one of the value.
+		pos      position
+		valuePos position
+		// err is set, as a recoverable error, when the member is malformed.
+		err error
+	}
+)
+
+func newMemberScanner(r io.Reader) (*memberScanner, error) {
+	lines := newLineCounter(r)
+	s := &memberScanner{
+		br:    bufio.NewReaderSize(lines, scanBufferSize),
+		lines: lines,
+	}
+
+	// read opening JSON delimiter
+	b, err := s.skipSpace()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read opening delimiter: %w", err)
+	}
+
+	if b != '{' {
+		return nil, fmt.Errorf("unexpected token encountered on reading opening delimiterr: %c", b)
+	}
+
+	return s, nil
+}
+
+// next returns the next member, or io.EOF when the object is over. The
+// member value is only valid until the following call, unless copied.
+func (s *memberScanner) next() (member, error) {
+	if s.done {
+		return member{}, io.EOF
+	}
+
+	b, err := s.skipSpace()
+	if err == nil && b == ',' {
+		b, err = s.skipSpace()
+	}
+
+	if err != nil {
+		return member{}, s.endError(err)
+	}
+
+	if b == '}' {
+		s.done = true
+
+		return member{}, io.EOF
+	}
+
+	m := member{pos: s.lines.position(s.offset - 1)}
+
+	if b != '"' {
+		return m, s.skipMember(&m, b, fmt.Errorf("invalid character '%c' looking for port key", b))
+	}
+
+	if m.key, err = s.readKey(); err != nil {
+		return m, err
+	}
+
+	if b, err = s.skipSpace(); err != nil {
+		return m, s.endError(err)
+	}
+
+	if b != ':' {
+		return m, s.skipMember(&m, b, fmt.Errorf("invalid character '%c' after port key", b))
+	}
+
+	if b, err = s.skipSpace(); err != nil {
+		return m, s.endError(err)
+	}
+
+	m.valuePos = s.lines.position(s.offset - 1)
+	m.raw, err = s.readValue(b)
+
+	return m, err
+}
+
+// skipMember skips the rest of a malformed member, starting at b, setting
+// the recoverable error describing it.
+func (s *memberScanner) skipMember(m *member, b byte, err error) error {
+	if _, rErr := s.readValue(b); rErr != nil {
+		return rErr
+	}
+
+	rErr := &recordError{
+		key:         m.key,
+		pos:         m.pos,
+		err:         err,
+		recoverable: true,
+	}
+
+	if m.key != "" {
+		rErr.path = keyPath(m.key)
+		rErr.err = fmt.Errorf("error on decoding port with id '%s': %w", m.key, err)
+	}
+
+	m.err = rErr
+
+	return nil
+}
+
+// readKey reads a member key, whose opening quote was already read.
+func (s *memberScanner) readKey() (string, error) {
+	s.buf.Reset()
+	s.buf.WriteByte('"')
+
+	escaped := false
+
+	for {
+		b, err := s.readByte()
+		if err != nil {
+			return "", s.endError(err)
+		}
+
+		s.buf.WriteByte(b)
+
+		switch {
+		case escaped:
+			escaped = false
+		case b == '\\':
+			escaped = true
+		case b == '"':
+			var key string
+			if err = json.Unmarshal(s.buf.Bytes(), &key); err != nil {
+				return "", fmt.Errorf("failed to read port key: %w", err)
+			}
+
+			return key, nil
+		}
+	}
+}
+
+// readValue reads a member value, starting at b, up to the comma or closing
+// brace ending it, which is left unread. Brackets and braces are balanced
+// regardless of their kind, so a mismatched one is found when decoding.
+func (s *memberScanner) readValue(b byte) ([]byte, error) {
+	s.buf.Reset()
+
+	var (
+		depth   int
+		inStr   bool
+		escaped bool
+	)
+
+	// b is scanned along with the buffered data following it
+	s.unreadByte()
+
+	for {
+		data, err := s.peek()
+		if err != nil {
+			return nil, s.endError(err)
+		}
+
+		for idx, c := range data {
+			if inStr {
+				switch {
+				case escaped:
+					escaped = false
+				case c == '\\':
+					escaped = true
+				case c == '"':
+					inStr = false
+				}
+
+				continue
+			}
+
+			switch c {
+			case '"':
+				inStr = true
+			case '{', '[':
+				depth++
+			case ',', '}':
+				if depth == 0 {
+					s.buf.Write(data[:idx])
+					s.discard(idx)
+
+					return s.buf.Bytes(), nil
+				}
+
+				if c == '}' {
+					depth--
+				}
+			case ']':
+				depth = max(depth-1, 0)
+			}
+		}
+
+		s.buf.Write(data)
+		s.discard(len(data))
+	}
+}
+
+// peek returns the buffered input, reading more when there is none.
+func (s *memberScanner) peek() ([]byte, error) {
+	if s.br.Buffered() == 0 {
+		if _, err := s.br.Peek(1); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.br.Peek(s.br.Buffered())
+}
+
+func (s *memberScanner) discard(n int) {
+	_, _ = s.br.Discard(n)
+	s.offset += int64(n)
+}
+
+func (s *memberScanner) readByte() (byte, error) {
+	b, err := s.br.ReadByte()
+	if err == nil {
+		s.offset++
+	}
+
+	return b, err
+}
+
+func (s *memberScanner) unreadByte() {
+	_ = s.br.UnreadByte()
+	s.offset--
+}
+
+func (s *memberScanner) skipSpace() (byte, error) {
+	for {
+		b, err := s.readByte()
+		if err != nil {
+			return 0, err
+		}
+
+		switch b {
+		case ' ', '\t', '\n', '\r':
+		default:
+			return b, nil
+		}
+	}
+}
+
+// endError converts an unexpected end of the input into a located error.
+func (s *memberScanner) endError(err error) error {
+	if !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return &recordError{
+		pos: s.lines.position(s.offset),
+		err: errors.New("unexpected end of JSON input"),
+	}
+}
+
+// decode decodes the member port. It is safe to call concurrently on
+// different members.
+func (m *member) decode(fn decodeFunc) (entry, error) {
+	if m.err != nil {
+		return entry{}, m.err
+	}
+
+	var port domain.Port
+
+	if err := fn(m.raw, &port); err != nil {
+		return entry{}, m.decodeError(err)
+	}
+
+	port.ID = m.key
+
+	return entry{port: port, op: opUpsert, key: m.key, path: keyPath(m.key), pos: m.pos}, nil
+}
+
+func (m *member) decodeError(err error) error {
+	rErr := &recordError{
+		key:         m.key,
+		path:        keyPath(m.key),
+		pos:         m.pos,
+		err:         fmt.Errorf("error on decoding port with id '%s': %w", m.key, err),
+		recoverable: true,
+	}
+
+	var (
+		typeErr  *json.UnmarshalTypeError
+		synErr   *json.SyntaxError
+		fieldErr *fieldError
+	)
+
+	switch {
+	case errors.As(err, &fieldErr):
+		rErr.path += "." + fieldErr.field
+	case errors.As(err, &typeErr):
+		rErr.path += fieldPath(typeErr.Field)
+		rErr.pos = m.valuePos.advance(m.raw[:min(typeErr.Offset, int64(len(m.raw)))])
+	case errors.As(err, &synErr):
+		rErr.pos = m.valuePos.advance(m.raw[:min(max(synErr.Offset-1, 0), int64(len(m.raw)))])
+	}
+
+	return rErr
+}
@@ -0,0 +1,342 @@
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+const (
+	// transformFunc is the name of the script function transforming the ports.
+	transformFunc = "transform"
+	// maxScriptSteps bounds the computation of a single transform call.
+	maxScriptSteps = 1_000_000
+)
+
+type (
+	// Script is a Starlark script transforming each port read from the input.
+	// It must define a transform(port) function receiving the port as a dict
+	// keyed by its JSON field names and returning either the port (the given
+	// dict, modified or not, or a new one), None to drop it or a list of ports
+	// to split it into several ones.
+	//
+	// Scripts are sandboxed: there are no built-ins to reach the filesystem or
+	// the network, load statements are not allowed and each call is limited
+	// to a number of computation steps. Global values, like lookup tables, are
+	// frozen once the script is loaded.
+	Script struct {
+		name      string
+		transform starlark.Callable
+		logger    *slog.Logger
+	}
+
+	// scriptReader transforms the ports read by a portReader through a script.
+	scriptReader struct {
+		portReader
+		script  *Script
+		pending []entry
+	}
+)
+
+// LoadScript loads and runs the top-level statements of the Starlark script
+// at the given path.
+func LoadScript(path string, logger *slog.Logger) (*Script, error) {
+	src, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script: %w", err)
+	}
+
+	s := &Script{name: filepath.Base(path), logger: logger}
+
+	thread := s.thread()
+
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, thread, s.name, src, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid script: %w", err)
+	}
+
+	fn, ok := globals[transformFunc].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("invalid script: missing %s(port) function", transformFunc)
+	}
+
+	s.transform = fn
+
+	return s, nil
+}
+
+// thread returns a new thread to run the script on, without load support.
+func (s *Script) thread() *starlark.Thread {
+	thread := &starlark.Thread{
+		Name: s.name,
+		Print: func(_ *starlark.Thread, msg string) {
+			s.logger.Debug("[Script] print", slog.String("script", s.name), slog.String("msg", msg))
+		},
+	}
+
+	thread.SetMaxExecutionSteps(maxScriptSteps)
+
+	return thread
+}
+
+// apply runs the script on a port, returning the resulting ports.
+func (s *Script) apply(port *domain.Port) (domain.Ports, error) {
+	in, err := portToStarlark(port)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := starlark.Call(s.thread(), s.transform, starlark.Tuple{in}, nil)
+	if err != nil {
+		var evalErr *starlark.EvalError
+		if errors.As(err, &evalErr) {
+			return nil, errors.New(evalErr.Backtrace())
+		}
+
+		return nil, err
+	}
+
+	var values []starlark.Value
+
+	switch out := out.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case *starlark.List:
+		for idx := 0; idx < out.Len(); idx++ {
+			values = append(values, out.Index(idx))
+		}
+	default:
+		values = []starlark.Value{out}
+	}
+
+	ports := make(domain.Ports, 0, len(values))
+	ids := make(map[string]bool, len(values))
+
+	for _, v := range values {
+		p, err := portFromStarlark(v)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.ID == "" {
+			p.ID = port.ID
+		}
+
+		if ids[p.ID] {
+			return nil, fmt.Errorf("%s returned several ports with id '%s'", transformFunc, p.ID)
+		}
+
+		ids[p.ID] = true
+		ports = append(ports, *p)
+	}
+
+	return ports, nil
+}
+
+func (r *scriptReader) next() (entry, error) {
+	for len(r.pending) == 0 {
+		e, err := r.portReader.next()
+		if err != nil || e.op != opUpsert {
+			return e, err
+		}
+
+		ports, err := r.script.apply(&e.port)
+		if err != nil {
+			return entry{}, &recordError{
+				key:         e.key,
+				path:        e.path,
+				pos:         e.pos,
+				err:         fmt.Errorf("error on transforming port with id '%s': %w", e.key, err),
+				recoverable: true,
+			}
+		}
+
+		for _, p := range ports {
+			t := e
+			t.port = p
+
+			if p.ID != e.key {
+				t.key = p.ID
+				t.path = keyPath(p.ID)
+			}
+
+			r.pending = append(r.pending, t)
+		}
+	}
+
+	e := r.pending[0]
+	r.pending = r.pending[1:]
+
+	return e, nil
+}
+
+// Close releases the resources held by the wrapped reader.
+func (r *scriptReader) Close() error {
+	closeReader(r.portReader)
+
+	return nil
+}
+
+func portToStarlark(port *domain.Port) (*starlark.Dict, error) {
+	b, err := json.Marshal(port)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	// empty lists are omitted from JSON, but scripts may rely on them
+	for _, k := range []string{"alias", "regions", "coordinates", "unlocs"} {
+		if _, ok := fields[k]; !ok {
+			fields[k] = []any{}
+		}
+	}
+
+	v, err := toStarlark(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*starlark.Dict), nil
+}
+
+func portFromStarlark(v starlark.Value) (*domain.Port, error) {
+	if _, ok := v.(*starlark.Dict); !ok {
+		return nil, fmt.Errorf("%s must return a dict, a list of dicts or None, got %s", transformFunc, v.Type())
+	}
+
+	fields, err := fromStarlark(v)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var port domain.Port
+	if err = json.Unmarshal(b, &port); err != nil {
+		return nil, fmt.Errorf("invalid port returned by %s: %w", transformFunc, err)
+	}
+
+	return &port, nil
+}
+
+func toStarlark(v any) (starlark.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return starlark.None, nil
+	case bool:
+		return starlark.Bool(v), nil
+	case string:
+		return starlark.String(v), nil
+	case float64:
+		return starlark.Float(v), nil
+	case []any:
+		items := make([]starlark.Value, 0, len(v))
+
+		for _, item := range v {
+			sv, err := toStarlark(item)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, sv)
+		}
+
+		return starlark.NewList(items), nil
+	case map[string]any:
+		d := starlark.NewDict(len(v))
+
+		for k, item := range v {
+			sv, err := toStarlark(item)
+			if err != nil {
+				return nil, err
+			}
+
+			if err = d.SetKey(starlark.String(k), sv); err != nil {
+				return nil, err
+			}
+		}
+
+		return d, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", v)
+	}
+}
+
+func fromStarlark(v starlark.Value) (any, error) {
+	switch v := v.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.Bool:
+		return bool(v), nil
+	case starlark.String:
+		return string(v), nil
+	case starlark.Int:
+		i, ok := v.Int64()
+		if !ok {
+			return nil, fmt.Errorf("integer %s out of range", v)
+		}
+
+		return i, nil
+	case starlark.Float:
+		return float64(v), nil
+	case *starlark.List, starlark.Tuple:
+		iter := starlark.Iterate(v)
+		defer iter.Done()
+
+		items := []any{}
+
+		var item starlark.Value
+		for iter.Next(&item) {
+			gv, err := fromStarlark(item)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, gv)
+		}
+
+		return items, nil
+	case *starlark.Dict:
+		m := make(map[string]any, v.Len())
+
+		for _, kv := range v.Items() {
+			k, ok := kv[0].(starlark.String)
+			if !ok {
+				return nil, fmt.Errorf("dict keys must be strings, got %s", kv[0].Type())
+			}
+
+			gv, err := fromStarlark(kv[1])
+			if err != nil {
+				return nil, err
+			}
+
+			m[string(k)] = gv
+		}
+
+		return m, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %s", v.Type())
+	}
+}
+
+// WithScript sets a script transforming each port read from the input
+// before it is sent.
+func WithScript(s *Script) PortIngestorOption {
+	return func(pi *PortIngestor) {
+		pi.script = s
+	}
+}
@@ -0,0 +1,119 @@
+package ingest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/domain/domaintest"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadScript(t *testing.T) {
+	write := func(t *testing.T, src string) string {
+		filename := filepath.Join(t.TempDir(), "script.star")
+		assert.NoError(t, os.WriteFile(filename, []byte(src), 0o600))
+
+		return filename
+	}
+
+	t.Run("file not found", func(t *testing.T) {
+		_, err := ingest.LoadScript("abc.star", loggerTest)
+		assert.EqualError(t, err, "failed to read script: open abc.star: no such file or directory")
+	})
+
+	t.Run("missing transform", func(t *testing.T) {
+		_, err := ingest.LoadScript(write(t, "x = 1\n"), loggerTest)
+		assert.EqualError(t, err, "invalid script: missing transform(port) function")
+	})
+
+	t.Run("load not allowed", func(t *testing.T) {
+		_, err := ingest.LoadScript(write(t, `load("os.star", "os")`+"\n"), loggerTest)
+		assert.ErrorContains(t, err, "invalid script")
+	})
+}
+
+func TestScript(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("transforms ports", func(t *testing.T) {
+		script, err := ingest.LoadScript("testdata/transform.star", loggerTest)
+		assert.NoError(t, err)
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			BulkUpsert(
+				gomock.Any(),
+				domaintest.PortsMatcher(
+					domain.Ports{
+						{
+							ID:       "AEAJM",
+							Name:     "Ajman",
+							Country:  "United Arab Emirates",
+							Alias:    []string{"Ajman Port"},
+							Unlocs:   []string{"AEAJM"},
+							Timezone: "Asia/Dubai",
+						},
+						{
+							ID:       "AEAUH",
+							Name:     "Abu Dhabi",
+							Country:  "United Arab Emirates",
+							Unlocs:   []string{"AEAUH"},
+							Timezone: "Asia/Abu_Dhabi",
+						},
+						{
+							ID:       "AEAUH-T",
+							Name:     "Abu Dhabi Terminal",
+							Country:  "United Arab Emirates",
+							Unlocs:   []string{"AEAUH"},
+							Timezone: "Asia/Abu_Dhabi",
+						},
+					},
+				),
+			).
+			Return(nil)
+
+		ingestor := ingest.NewPortIngestor(mockedPortSvc, loggerTest, ingest.WithScript(script))
+
+		err = ingestor.Process(context.Background(), "testdata/ports_script.json")
+		assert.NoError(t, err)
+	})
+
+	t.Run("runtime error", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "script.star")
+		assert.NoError(t, os.WriteFile(filename, []byte("def transform(port):\n    return port[\"missing\"]\n"), 0o600))
+
+		script, err := ingest.LoadScript(filename, loggerTest)
+		assert.NoError(t, err)
+
+		ingestor := ingest.NewPortIngestor(porttest.NewMockPortService(ctrl), loggerTest, ingest.WithScript(script))
+
+		report, err := ingestor.DryRun(context.Background(), "testdata/ports_script.json")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, report.Malformed)
+		assert.Equal(t, "AEAJM", report.Problems[0].Key)
+		assert.Equal(t, 2, report.Problems[0].Line)
+		assert.Contains(t, report.Problems[0].Message, "error on transforming port with id 'AEAJM'")
+		assert.Contains(t, report.Problems[0].Message, `key "missing" not in dict`)
+	})
+
+	t.Run("endless loop", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "script.star")
+		src := "def transform(port):\n    for i in range(1000000000):\n        pass\n    return port\n"
+		assert.NoError(t, os.WriteFile(filename, []byte(src), 0o600))
+
+		script, err := ingest.LoadScript(filename, loggerTest)
+		assert.NoError(t, err)
+
+		ingestor := ingest.NewPortIngestor(porttest.NewMockPortService(ctrl), loggerTest, ingest.WithScript(script))
+
+		err = ingestor.Process(context.Background(), "testdata/ports_script.json")
+		assert.ErrorContains(t, err, "too many steps")
+	})
+}
@@ -0,0 +1,234 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/pkg/logging"
+)
+
+const maxDeletePercentDefault float64 = 10
+
+// ErrDeleteThreshold is returned when pruning would delete more ports than allowed.
+var ErrDeleteThreshold = errors.New("too many ports would be deleted")
+
+type (
+	// SyncReport holds the differences between an input and the stored ports.
+	SyncReport struct {
+		Added     []string     `json:"added"`
+		Changed   []PortChange `json:"changed"`
+		Removed   []string     `json:"removed"`
+		Unchanged int          `json:"unchanged"`
+		// Applied tells whether the added and changed ports were upserted.
+		Applied bool `json:"applied"`
+		// Pruned tells whether the removed ports were deleted.
+		Pruned bool `json:"pruned"`
+	}
+
+	// PortChange holds the field differences of a changed port.
+	PortChange struct {
+		ID     string             `json:"id"`
+		Fields []domain.FieldDiff `json:"fields"`
+	}
+
+	// SyncOptions sets which differences are written by Sync.
+	SyncOptions struct {
+		// Apply upserts the added and changed ports.
+		Apply bool
+		// Prune deletes the stored ports missing from the input.
+		Prune bool
+		// MaxDeletePercent aborts pruning when more than this percentage of
+		// the stored ports would be deleted.
+		MaxDeletePercent float64
+	}
+)
+
+// Sync compares the ports read from the input against the stored ones,
+// reporting the added, changed and removed ports. The differences are only
+// written as set by the options. Ports marked for deletion in the input are
+// handled as missing from it.
+func (i *PortIngestor) Sync(ctx context.Context, filename string, opts SyncOptions) (*SyncReport, error) {
+	l := i.logger.With(
+		slog.String("filepath", filename),
+	)
+
+	l.InfoContext(ctx, "[PortIngestor.Sync] processing")
+
+	wanted, err := i.readAll(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// both sides are compared normalised, as the server normalises the
+	// ports on writes, but may have stored some before it did
+	for id, p := range wanted {
+		p.Normalize()
+		wanted[id] = p
+	}
+
+	stored, err := i.portSvc.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ports: %w", err)
+	}
+
+	for idx := range stored {
+		stored[idx].Normalize()
+	}
+
+	report, upserts := diffPorts(wanted, stored)
+
+	if opts.Prune && len(report.Removed) > 0 {
+		maxPercent := opts.MaxDeletePercent
+		if maxPercent <= 0 {
+			maxPercent = maxDeletePercentDefault
+		}
+
+		if percent := 100 * float64(len(report.Removed)) / float64(len(stored)); percent > maxPercent {
+			return report, fmt.Errorf("%w: %d of %d (%.2f%%), max %.2f%%",
+				ErrDeleteThreshold, len(report.Removed), len(stored), percent, maxPercent)
+		}
+	}
+
+	if opts.Apply {
+		for _, batch := range batches(upserts, i.batchSize) {
+			if err = i.portSvc.BulkUpsert(ctx, batch); err != nil {
+				return report, err
+			}
+		}
+
+		report.Applied = true
+	}
+
+	if opts.Prune {
+		for _, batch := range batches(report.Removed, i.batchSize) {
+			if err = i.portSvc.BulkDelete(ctx, batch); err != nil {
+				return report, err
+			}
+		}
+
+		report.Pruned = true
+	}
+
+	return report, nil
+}
+
+// readAll reads all the ports to be upserted from the input, keyed by ID.
+func (i *PortIngestor) readAll(ctx context.Context, filename string) (map[string]domain.Port, error) {
+	in, err := i.openInput(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := in.Close(); err != nil {
+			i.logger.Error(
+				"error on closing file",
+				logging.Error(err),
+			)
+		}
+	}()
+
+	r, err := i.newReader(in)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeReader(r)
+
+	ports := make(map[string]domain.Port)
+
+	for {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		e, err := r.next()
+		if errors.Is(err, io.EOF) {
+			return ports, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if e.op == opDelete {
+			delete(ports, e.port.ID)
+		} else {
+			ports[e.port.ID] = e.port
+		}
+	}
+}
+
+// diffPorts compares the wanted ports against the stored ones, returning
+// the report and the ports to be upserted.
+func diffPorts(wanted map[string]domain.Port, stored domain.Ports) (*SyncReport, domain.Ports) {
+	report := &SyncReport{
+		Added:   []string{},
+		Changed: []PortChange{},
+		Removed: []string{},
+	}
+
+	var upserts domain.Ports
+
+	seen := make(map[string]bool, len(stored))
+
+	for idx := range stored {
+		s := &stored[idx]
+		seen[s.ID] = true
+
+		w, ok := wanted[s.ID]
+		if !ok {
+			report.Removed = append(report.Removed, s.ID)
+			continue
+		}
+
+		if diffs := s.Diff(&w); len(diffs) > 0 {
+			report.Changed = append(report.Changed, PortChange{ID: s.ID, Fields: diffs})
+			upserts = append(upserts, w)
+
+			continue
+		}
+
+		report.Unchanged++
+	}
+
+	for id := range wanted {
+		if !seen[id] {
+			report.Added = append(report.Added, id)
+		}
+	}
+
+	sort.Strings(report.Added)
+	sort.Strings(report.Removed)
+	sort.Slice(report.Changed, func(i, j int) bool {
+		return report.Changed[i].ID < report.Changed[j].ID
+	})
+
+	for _, id := range report.Added {
+		upserts = append(upserts, wanted[id])
+	}
+
+	return report, upserts
+}
+
+// batches splits vs into slices of up to size elements.
+func batches[T any](vs []T, size int) [][]T {
+	if size <= 0 {
+		size = batchSizeDefault
+	}
+
+	var bs [][]T
+
+	for len(vs) > 0 {
+		n := min(size, len(vs))
+		bs = append(bs, vs[:n])
+		vs = vs[n:]
+	}
+
+	return bs
+}
@@ -0,0 +1,151 @@
+package ingest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/domain/domaintest"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSync(t *testing.T) {
+	// testdata/ports_valid.json holds AEAJM, AEAUH, AEDXB and AEFJR
+	stored := domain.Ports{
+		{
+			ID:          "AEAJM",
+			Name:        "Ajman",
+			City:        "Ajman",
+			Country:     "United Arab Emirates",
+			Coordinates: []float64{55.5136433, 25.4052165},
+			Province:    "Ajman",
+			Timezone:    "Asia/Dubai",
+			Unlocs:      []string{"AEAJM"},
+			Code:        "52000",
+		},
+		{
+			ID:          "AEAUH",
+			Name:        "Abu Dabi",
+			City:        "Abu Dhabi",
+			Country:     "United Arab Emirates",
+			Coordinates: []float64{54.37, 24.47},
+			Province:    "Abu Z¸aby [Abu Dhabi]",
+			Timezone:    "Asia/Dubai",
+			Unlocs:      []string{"AEAUH"},
+			Code:        "52001",
+		},
+		{
+			ID:   "AEQIW",
+			Name: "Umm al Qaiwain",
+		},
+	}
+
+	expectedReport := &ingest.SyncReport{
+		Added: []string{"AEDXB", "AEFJR"},
+		Changed: []ingest.PortChange{
+			{
+				ID: "AEAUH",
+				Fields: []domain.FieldDiff{
+					{Field: "name", Old: "Abu Dabi", New: "Abu Dhabi"},
+				},
+			},
+		},
+		Removed:   []string{"AEQIW"},
+		Unchanged: 1,
+	}
+
+	t.Run("list error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			List(gomock.Any()).
+			Return(nil, errors.New("list err"))
+
+		ingestor := ingest.NewPortIngestor(mockedPortSvc, loggerTest)
+
+		report, err := ingestor.Sync(context.Background(), "testdata/ports_valid.json", ingest.SyncOptions{})
+		assert.EqualError(t, err, "failed to list ports: list err")
+		assert.Nil(t, report)
+	})
+
+	t.Run("report only", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			List(gomock.Any()).
+			Return(stored, nil)
+
+		ingestor := ingest.NewPortIngestor(mockedPortSvc, loggerTest)
+
+		report, err := ingestor.Sync(context.Background(), "testdata/ports_valid.json", ingest.SyncOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedReport, report)
+	})
+
+	t.Run("delete threshold", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			List(gomock.Any()).
+			Return(stored, nil)
+
+		ingestor := ingest.NewPortIngestor(mockedPortSvc, loggerTest)
+
+		report, err := ingestor.Sync(context.Background(), "testdata/ports_valid.json", ingest.SyncOptions{
+			Apply:            true,
+			Prune:            true,
+			MaxDeletePercent: 30,
+		})
+		assert.ErrorIs(t, err, ingest.ErrDeleteThreshold)
+		assert.EqualError(t, err, "too many ports would be deleted: 1 of 3 (33.33%), max 30.00%")
+		assert.Equal(t, expectedReport, report)
+	})
+
+	t.Run("apply and prune", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		mockedPortSvc.EXPECT().
+			List(gomock.Any()).
+			Return(stored, nil)
+
+		mockedPortSvc.EXPECT().
+			BulkUpsert(
+				gomock.Any(),
+				domaintest.PortsMatcher(
+					domain.Ports{
+						{ID: "AEAUH", Name: "Abu Dhabi"},
+						{ID: "AEDXB", Name: "Dubai"},
+						{ID: "AEFJR", Name: "Al Fujayrah"},
+					},
+				),
+			).
+			Return(nil)
+
+		mockedPortSvc.EXPECT().
+			BulkDelete(gomock.Any(), []string{"AEQIW"}).
+			Return(nil)
+
+		ingestor := ingest.NewPortIngestor(mockedPortSvc, loggerTest)
+
+		report, err := ingestor.Sync(context.Background(), "testdata/ports_valid.json", ingest.SyncOptions{
+			Apply:            true,
+			Prune:            true,
+			MaxDeletePercent: 50,
+		})
+		assert.NoError(t, err)
+		assert.True(t, report.Applied)
+		assert.True(t, report.Pruned)
+	})
+}
@@ -0,0 +1,264 @@
+package ingest
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+)
+
+// UN/LOCODE CSV columns.
+const (
+	unlocChange = iota
+	unlocCountry
+	unlocLocation
+	unlocName
+	unlocNameWoDiacritics
+	unlocSubdivision
+	unlocFunction
+	unlocStatus
+	unlocDate
+	unlocIATA
+	unlocCoordinates
+	unlocColumns
+)
+
+// UN/LOCODE change indicators.
+const (
+	unlocAdded        = "+"
+	unlocNameChanged  = "#"
+	unlocChanged      = "|"
+	unlocRemoved      = "X"
+	unlocReferenceFor = "="
+)
+
+// unlocPortFunction is the function classifier flagging a location as a port.
+const unlocPortFunction = '1'
+
+// unlocodeReader reads ports from the UNECE UN/LOCODE code list CSV.
+//
+// Each country is introduced by a row without location whose name is the
+// country name prefixed by a dot, followed by its locations. Only locations
+// with the port function are read, and the change indicator of each row is
+// mapped to the operation to apply:
+//   - '+' (added), '#' (name changed), '|' (changed) and unmarked rows are upserted;
+//   - 'X' (marked for deletion) rows are deleted;
+//   - '=' (reference entry) rows are skipped.
+//
+// Changed rows that no longer have the port function are deleted.
+type unlocodeReader struct {
+	csv     *csv.Reader
+	country string
+}
+
+func newUNLocodeReader(r io.Reader) *unlocodeReader {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.ReuseRecord = true
+
+	return &unlocodeReader{csv: cr}
+}
+
+func (r *unlocodeReader) next() (entry, error) {
+	for {
+		record, err := r.csv.Read()
+		if errors.Is(err, io.EOF) {
+			return entry{}, io.EOF
+		}
+
+		if err != nil {
+			return entry{}, fmt.Errorf("failed to read UN/LOCODE record: %w", err)
+		}
+
+		line, _ := r.csv.FieldPos(0)
+
+		if len(record) < unlocColumns {
+			return entry{}, fmt.Errorf("invalid UN/LOCODE record at line %d: expected at least %d columns, got %d",
+				line, unlocColumns, len(record))
+		}
+
+		for i := range record {
+			record[i] = latin1ToUTF8(strings.TrimSpace(record[i]))
+		}
+
+		if line == 1 && strings.EqualFold(record[unlocCountry], "country") {
+			// header row
+			continue
+		}
+
+		if record[unlocLocation] == "" {
+			r.country = countryName(record[unlocName])
+
+			continue
+		}
+
+		e, ok, err := r.entry(record)
+		if err != nil {
+			return entry{}, &recordError{
+				key:         e.key,
+				pos:         position{Line: line, Column: 1},
+				err:         fmt.Errorf("invalid UN/LOCODE record at line %d: %w", line, err),
+				recoverable: true,
+			}
+		}
+
+		if ok {
+			e.pos = position{Line: line, Column: 1}
+
+			return e, nil
+		}
+	}
+}
+
+func (r *unlocodeReader) entry(record []string) (entry, bool, error) {
+	id := record[unlocCountry] + record[unlocLocation]
+	isPort := strings.IndexRune(record[unlocFunction], unlocPortFunction) == 0
+	deletion := entry{port: domain.Port{ID: id}, op: opDelete, key: id}
+
+	switch record[unlocChange] {
+	case unlocReferenceFor:
+		return entry{}, false, nil
+	case unlocRemoved:
+		return deletion, true, nil
+	case unlocChanged, unlocNameChanged:
+		if !isPort {
+			return deletion, true, nil
+		}
+	default:
+		if !isPort {
+			return entry{}, false, nil
+		}
+	}
+
+	p := domain.Port{
+		ID:       id,
+		Name:     record[unlocName],
+		City:     record[unlocName],
+		Country:  r.country,
+		Province: record[unlocSubdivision],
+		Unlocs:   []string{id},
+	}
+
+	if alias := record[unlocNameWoDiacritics]; alias != "" && alias != p.Name {
+		p.Alias = []string{alias}
+	}
+
+	if coords := record[unlocCoordinates]; coords != "" {
+		lon, lat, err := parseUNLocodeCoordinates(coords)
+		if err != nil {
+			return entry{key: id}, false, err
+		}
+
+		p.Coordinates = []float64{lon, lat}
+	}
+
+	return entry{port: p, op: opUpsert, key: id}, true, nil
+}
+
+// parseUNLocodeCoordinates parses coordinates in the "DDMMN DDDMME" format,
+// returning them as decimal longitude and latitude.
+func parseUNLocodeCoordinates(s string) (float64, float64, error) {
+	parts := strings.Fields(s)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid coordinates '%s'", s)
+	}
+
+	lat, err := parseUNLocodeDegrees(parts[0], 2, 'N', 'S')
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid latitude '%s': %w", parts[0], err)
+	}
+
+	lon, err := parseUNLocodeDegrees(parts[1], 3, 'E', 'W')
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid longitude '%s': %w", parts[1], err)
+	}
+
+	return lon, lat, nil
+}
+
+func parseUNLocodeDegrees(s string, degDigits int, pos, neg byte) (float64, error) {
+	if len(s) != degDigits+3 {
+		return 0, errors.New("unexpected length")
+	}
+
+	deg, err := strconv.Atoi(s[:degDigits])
+	if err != nil {
+		return 0, err
+	}
+
+	minutes, err := strconv.Atoi(s[degDigits : degDigits+2])
+	if err != nil {
+		return 0, err
+	}
+
+	if minutes >= 60 {
+		return 0, errors.New("minutes out of range")
+	}
+
+	v := float64(deg) + float64(minutes)/60
+
+	switch s[len(s)-1] {
+	case pos:
+		return v, nil
+	case neg:
+		return -v, nil
+	default:
+		return 0, fmt.Errorf("unexpected hemisphere '%c'", s[len(s)-1])
+	}
+}
+
+// countryName converts a UN/LOCODE country row name (".UNITED ARAB EMIRATES")
+// into a title-cased name ("United Arab Emirates").
+func countryName(s string) string {
+	words := strings.Fields(strings.ToLower(strings.TrimPrefix(s, ".")))
+
+	for i, w := range words {
+		switch w {
+		case "of", "and", "the", "da", "du":
+			if i > 0 {
+				continue
+			}
+		}
+
+		words[i] = titleWord(w)
+	}
+
+	return strings.Join(words, " ")
+}
+
+func titleWord(w string) string {
+	b := []rune(w)
+	upper := true
+
+	for i, c := range b {
+		if upper {
+			b[i] = unicode.ToUpper(c)
+		}
+
+		upper = c == '(' || c == '-' || c == '\''
+	}
+
+	return string(b)
+}
+
+// latin1ToUTF8 converts s from ISO 8859-1 to UTF-8 when it is not valid UTF-8,
+// as older code list releases are distributed in that encoding.
+func latin1ToUTF8(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+
+	b := make([]rune, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		b = append(b, rune(s[i]))
+	}
+
+	return string(b)
+}
@@ -0,0 +1,162 @@
+package ingest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/domain/domaintest"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess_UNLocode(t *testing.T) {
+	t.Run("invalid coordinates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ingestor := ingest.NewPortIngestor(
+			porttest.NewMockPortService(ctrl),
+			loggerTest,
+			ingest.WithFormat(ingest.FormatUNLocode),
+		)
+
+		err := ingestor.Process(context.Background(), "testdata/unlocode_invalid.csv")
+		assert.EqualError(t, err,
+			"invalid UN/LOCODE record at line 2: invalid latitude '2525X': unexpected hemisphere 'X'",
+		)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var upserted domain.Ports
+
+		// batches are written in the order their records were read
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		gomock.InOrder(
+			mockedPortSvc.EXPECT().
+				BulkUpsert(
+					gomock.Any(),
+					domaintest.PortsMatcher(
+						domain.Ports{
+							{
+								ID:   "AEAJM",
+								Name: "Ajman",
+							},
+							{
+								ID:   "AEAUH",
+								Name: "Abu Dhabi",
+							},
+						},
+					),
+				).
+				DoAndReturn(func(_ context.Context, ports domain.Ports) error {
+					upserted = append(upserted, ports...)
+					return nil
+				}),
+			mockedPortSvc.EXPECT().
+				BulkUpsert(
+					gomock.Any(),
+					domaintest.PortsMatcher(
+						domain.Ports{
+							{
+								ID:   "AEDXB",
+								Name: "Dubai",
+							},
+						},
+					),
+				).
+				Return(nil),
+			mockedPortSvc.EXPECT().
+				BulkDelete(gomock.Any(), []string{"AEQIW"}).
+				Return(nil),
+			mockedPortSvc.EXPECT().
+				BulkUpsert(
+					gomock.Any(),
+					domaintest.PortsMatcher(
+						domain.Ports{
+							{
+								ID:   "BRRIO",
+								Name: "Río de Janeiro",
+							},
+						},
+					),
+				).
+				Return(nil),
+			mockedPortSvc.EXPECT().
+				BulkDelete(gomock.Any(), []string{"BRSSZ"}).
+				Return(nil),
+		)
+
+		ingestor := ingest.NewPortIngestor(
+			mockedPortSvc,
+			loggerTest,
+			ingest.WithBatchSize(2),
+			ingest.WithFormat(ingest.FormatUNLocode),
+		)
+
+		err := ingestor.Process(context.Background(), "testdata/unlocode.csv")
+		assert.NoError(t, err)
+
+		assert.Equal(t,
+			domain.Port{
+				ID:          "AEAJM",
+				Name:        "Ajman",
+				City:        "Ajman",
+				Country:     "United Arab Emirates",
+				Province:    "AJ",
+				Coordinates: []float64{55 + 27.0/60, 25 + 25.0/60},
+				Unlocs:      []string{"AEAJM"},
+			},
+			upserted[0],
+		)
+	})
+
+	t.Run("deleted and re-added", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		filename := filepath.Join(t.TempDir(), "unlocode.csv")
+		require.NoError(t, os.WriteFile(filename, []byte(
+			`,"AE","AJM","Ajman","Ajman","AJ","1-3-----","AI","9307","","",""`+"\n"+
+				`,"AE","AUH","Abu Dhabi","Abu Dhabi","AZ","1-345---","AI","0307","","",""`+"\n"+
+				`X,"AE","AJM","Ajman","Ajman","AJ","1-3-----","AI","9307","","",""`+"\n"+
+				`+,"AE","AJM","Ajman","Ajman","AJ","1-3-----","AI","9307","","",""`+"\n",
+		), 0o600))
+
+		mockedPortSvc := porttest.NewMockPortService(ctrl)
+		gomock.InOrder(
+			mockedPortSvc.EXPECT().
+				BulkUpsert(gomock.Any(), domaintest.PortsMatcher(domain.Ports{
+					{ID: "AEAJM", Name: "Ajman"},
+					{ID: "AEAUH", Name: "Abu Dhabi"},
+				})).
+				Return(nil),
+			mockedPortSvc.EXPECT().
+				BulkDelete(gomock.Any(), []string{"AEAJM"}).
+				Return(nil),
+			mockedPortSvc.EXPECT().
+				BulkUpsert(gomock.Any(), domaintest.PortsMatcher(domain.Ports{
+					{ID: "AEAJM", Name: "Ajman"},
+				})).
+				Return(nil),
+		)
+
+		ingestor := ingest.NewPortIngestor(
+			mockedPortSvc,
+			loggerTest,
+			ingest.WithBatchSize(10),
+			ingest.WithFormat(ingest.FormatUNLocode),
+		)
+
+		err := ingestor.Process(context.Background(), filename)
+		assert.NoError(t, err)
+	})
+}
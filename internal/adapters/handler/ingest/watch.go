@@ -0,0 +1,334 @@
+package ingest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rafaeltg/goports/pkg/logging"
+)
+
+const (
+	processedDirName  = "processed"
+	failedDirName     = "failed"
+	ledgerFileName    = ".ledger.json"
+	settleDefault     = 2 * time.Second
+	ledgerMaxEntries  = 10000
+	watchDirPerm      = 0o750
+	watchLedgerPerm   = 0o640
+	partialFileSuffix = ".part"
+)
+
+type (
+	// Watcher watches a directory and ingests the files dropped into it,
+	// moving each one to the processed or failed subfolder afterwards.
+	Watcher struct {
+		ingestor *PortIngestor
+		dir      string
+		settle   time.Duration
+		ledger   *ledger
+		logger   *slog.Logger
+	}
+
+	WatcherOption func(*Watcher)
+
+	// ledger keeps the hashes of the files already ingested.
+	ledger struct {
+		path    string
+		mu      sync.Mutex
+		entries map[string]ledgerEntry
+	}
+
+	ledgerEntry struct {
+		Name       string    `json:"name"`
+		IngestedAt time.Time `json:"ingestedAt"`
+	}
+)
+
+// NewWatcher creates a watcher ingesting the files written to dir.
+func NewWatcher(ingestor *PortIngestor, dir string, logger *slog.Logger, opts ...WatcherOption) *Watcher {
+	w := &Watcher{
+		ingestor: ingestor,
+		dir:      filepath.Clean(dir),
+		settle:   settleDefault,
+		logger:   logger.With(slog.String("dir", dir)),
+	}
+
+	w.ledger = &ledger{
+		path:    filepath.Join(w.dir, ledgerFileName),
+		entries: make(map[string]ledgerEntry),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Run watches the directory until the context is cancelled. Files already
+// in the directory are ingested on start. A file is ingested once no writes
+// have been seen on it for the settle period.
+func (w *Watcher) Run(ctx context.Context) error {
+	for _, d := range []string{processedDirName, failedDirName} {
+		if err := os.MkdirAll(filepath.Join(w.dir, d), watchDirPerm); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	if err := w.ledger.load(); err != nil {
+		return err
+	}
+
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+
+	defer func() {
+		_ = fw.Close()
+	}()
+
+	if err = fw.Add(w.dir); err != nil {
+		return fmt.Errorf("failed to watch directory: %w", err)
+	}
+
+	ready := make(chan string)
+	timers := make(map[string]*time.Timer)
+
+	schedule := func(name string) {
+		if !w.watchable(name) {
+			return
+		}
+
+		if t, ok := timers[name]; ok {
+			t.Reset(w.settle)
+			return
+		}
+
+		timers[name] = time.AfterFunc(w.settle, func() {
+			select {
+			case ready <- name:
+			case <-ctx.Done():
+			}
+		})
+	}
+
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	for _, e := range entries {
+		schedule(filepath.Join(w.dir, e.Name()))
+	}
+
+	w.logger.InfoContext(ctx, "[Watcher.Run] watching")
+
+	for {
+		select {
+		case <-ctx.Done():
+			for _, t := range timers {
+				t.Stop()
+			}
+
+			return nil
+		case ev, ok := <-fw.Events:
+			if !ok {
+				return nil
+			}
+
+			if ev.Has(fsnotify.Create) || ev.Has(fsnotify.Write) {
+				schedule(ev.Name)
+			}
+		case err, ok := <-fw.Errors:
+			if !ok {
+				return nil
+			}
+
+			w.logger.ErrorContext(ctx,
+				"[Watcher.Run] watch error",
+				logging.Error(err),
+			)
+		case name := <-ready:
+			delete(timers, name)
+			w.ingest(ctx, name)
+		}
+	}
+}
+
+// watchable reports whether the named file should be ingested. Hidden and
+// partially uploaded files, as well as directories, are ignored.
+func (w *Watcher) watchable(name string) bool {
+	base := filepath.Base(name)
+	if strings.HasPrefix(base, ".") || strings.HasSuffix(base, partialFileSuffix) {
+		return false
+	}
+
+	fi, err := os.Stat(name)
+
+	return err == nil && fi.Mode().IsRegular()
+}
+
+func (w *Watcher) ingest(ctx context.Context, name string) {
+	l := w.logger.With(slog.String("filepath", name))
+
+	if !w.watchable(name) {
+		// moved or removed while settling
+		return
+	}
+
+	hash, err := fileHash(name)
+	if err != nil {
+		l.ErrorContext(ctx, "[Watcher.ingest] failed to hash file", logging.Error(err))
+		return
+	}
+
+	dest := processedDirName
+
+	switch {
+	case w.ledger.has(hash):
+		l.InfoContext(ctx, "[Watcher.ingest] file already ingested, skipping")
+	default:
+		err = w.ingestor.Process(ctx, name)
+		if err != nil {
+			if ctx.Err() != nil {
+				// interrupted: leave the file to be ingested on the next run
+				return
+			}
+
+			dest = failedDirName
+
+			l.ErrorContext(ctx, "[Watcher.ingest] failed to ingest file", logging.Error(err))
+
+			break
+		}
+
+		if err = w.ledger.add(hash, filepath.Base(name)); err != nil {
+			l.ErrorContext(ctx, "[Watcher.ingest] failed to update ledger", logging.Error(err))
+		}
+
+		l.InfoContext(ctx, "[Watcher.ingest] file ingested")
+	}
+
+	if err = moveFile(name, filepath.Join(w.dir, dest)); err != nil {
+		l.ErrorContext(ctx, "[Watcher.ingest] failed to move file", logging.Error(err))
+	}
+}
+
+// moveFile moves the named file into dir, suffixing it with a timestamp
+// when a file with the same name is already there.
+func moveFile(name, dir string) error {
+	dest := filepath.Join(dir, filepath.Base(name))
+
+	if _, err := os.Stat(dest); err == nil {
+		dest = fmt.Sprintf("%s.%s", dest, time.Now().UTC().Format("20060102T150405.000000000"))
+	}
+
+	return os.Rename(name, dest)
+}
+
+func fileHash(name string) (string, error) {
+	f, err := os.Open(filepath.Clean(name))
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (l *ledger) load() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, err := os.ReadFile(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read ledger: %w", err)
+	}
+
+	if err = json.Unmarshal(b, &l.entries); err != nil {
+		return fmt.Errorf("failed to decode ledger: %w", err)
+	}
+
+	return nil
+}
+
+func (l *ledger) has(hash string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, ok := l.entries[hash]
+
+	return ok
+}
+
+// add records a file hash and saves the ledger, dropping the oldest entries
+// when it grows beyond ledgerMaxEntries.
+func (l *ledger) add(hash, name string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries[hash] = ledgerEntry{
+		Name:       name,
+		IngestedAt: time.Now().UTC(),
+	}
+
+	if len(l.entries) > ledgerMaxEntries {
+		hashes := make([]string, 0, len(l.entries))
+		for h := range l.entries {
+			hashes = append(hashes, h)
+		}
+
+		sort.Slice(hashes, func(i, j int) bool {
+			return l.entries[hashes[i]].IngestedAt.Before(l.entries[hashes[j]].IngestedAt)
+		})
+
+		for _, h := range hashes[:len(hashes)-ledgerMaxEntries] {
+			delete(l.entries, h)
+		}
+	}
+
+	b, err := json.Marshal(l.entries)
+	if err != nil {
+		return err
+	}
+
+	tmp := l.path + partialFileSuffix
+	if err = os.WriteFile(tmp, b, watchLedgerPerm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, l.path)
+}
+
+// WithSettle sets how long a file must go without writes before being ingested.
+func WithSettle(d time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		w.settle = d
+	}
+}
@@ -0,0 +1,70 @@
+package ingest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/adapters/handler/ingest"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+
+	valid, err := os.ReadFile("testdata/ports_valid.json")
+	assert.NoError(t, err)
+
+	invalid, err := os.ReadFile("testdata/ports_invalid.json")
+	assert.NoError(t, err)
+
+	// a file present before the watcher starts is ingested too
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "first.json"), valid, 0o600))
+
+	mockedPortSvc := porttest.NewMockPortService(ctrl)
+	mockedPortSvc.EXPECT().
+		BulkUpsert(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	ingestor := ingest.NewPortIngestor(mockedPortSvc, loggerTest)
+	watcher := ingest.NewWatcher(
+		ingestor,
+		dir,
+		loggerTest,
+		ingest.WithSettle(20*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+
+	go func() {
+		errCh <- watcher.Run(ctx)
+	}()
+
+	exists := func(name ...string) func() bool {
+		return func() bool {
+			_, err := os.Stat(filepath.Join(append([]string{dir}, name...)...))
+			return err == nil
+		}
+	}
+
+	assert.Eventually(t, exists("processed", "first.json"), time.Second, 10*time.Millisecond)
+
+	// same content under another name is not ingested again
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "second.json"), valid, 0o600))
+	assert.Eventually(t, exists("processed", "second.json"), time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "third.json"), invalid, 0o600))
+	assert.Eventually(t, exists("failed", "third.json"), time.Second, 10*time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-errCh)
+}
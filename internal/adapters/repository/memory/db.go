@@ -25,8 +25,27 @@ func (db *Database) Get(_ context.Context, key string) (any, bool) {
 	return v, ok
 }
 
+// Values returns a snapshot of all the stored values, in no particular order.
+func (db *Database) Values(_ context.Context) []any {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	values := make([]any, 0, len(db.data))
+	for _, v := range db.data {
+		values = append(values, v)
+	}
+
+	return values
+}
+
 func (db *Database) Set(_ context.Context, key string, value any) {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 	db.data[key] = value
 }
+
+func (db *Database) Delete(_ context.Context, key string) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	delete(db.data, key)
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+)
+
+type (
+	// index maps the values of a secondary key to the IDs of the ports
+	// claiming them.
+	index map[string]map[string]bool
+
+	// indexes are the secondary key indexes of the stored ports, kept up to
+	// date on each write.
+	indexes map[domain.PortKey]index
+)
+
+func newIndexes() indexes {
+	idxs := make(indexes)
+	for _, k := range domain.PortKeys() {
+		idxs[k] = make(index)
+	}
+
+	return idxs
+}
+
+// add indexes the keys claimed by the port p.
+func (idxs indexes) add(p *domain.Port) {
+	for k, idx := range idxs {
+		for _, v := range p.Keys(k) {
+			ids, ok := idx[v]
+			if !ok {
+				ids = make(map[string]bool)
+				idx[v] = ids
+			}
+
+			ids[p.ID] = true
+		}
+	}
+}
+
+// remove stops indexing the keys claimed by the port p.
+func (idxs indexes) remove(p *domain.Port) {
+	for k, idx := range idxs {
+		for _, v := range p.Keys(k) {
+			delete(idx[v], p.ID)
+
+			if len(idx[v]) == 0 {
+				delete(idx, v)
+			}
+		}
+	}
+}
+
+// lookup returns the sorted IDs of the ports claiming the value of the key.
+func (idxs indexes) lookup(k domain.PortKey, value string) []string {
+	claimants := idxs[k][k.Normalize(value)]
+
+	ids := make([]string, 0, len(claimants))
+	for id := range claimants {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
@@ -3,6 +3,9 @@ package memory
 import (
 	"context"
 	"log/slog"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/rafaeltg/goports/internal/core/domain"
 	"github.com/rafaeltg/goports/internal/core/port"
@@ -12,13 +15,24 @@ import (
 type PortRepository struct {
 	db     *Database
 	logger *slog.Logger
+
+	// mu serialises the writes, so that the stats and the indexes are kept
+	// consistent with the stored ports.
+	mu      sync.Mutex
+	stats   *stats
+	indexes indexes
+
+	// regions is the region catalogue, sorted by ID.
+	regions domain.Regions
 }
 
 // NewPortRepository creates a new port repository instance.
 func NewPortRepository(db *Database, logger *slog.Logger) *PortRepository {
 	return &PortRepository{
-		db:     db,
-		logger: logger,
+		db:      db,
+		logger:  logger,
+		stats:   newStats(),
+		indexes: newIndexes(),
 	}
 }
 
@@ -36,20 +50,216 @@ func (r *PortRepository) Get(ctx context.Context, id string) (*domain.Port, erro
 	return result.(*domain.Port), nil
 }
 
+// List returns all the ports, sorted by ID.
+func (r *PortRepository) List(ctx context.Context) (domain.Ports, error) {
+	r.logger.DebugContext(ctx, "[PortRepository.List] executing")
+
+	values := r.db.Values(ctx)
+
+	ports := make(domain.Ports, 0, len(values))
+	for _, v := range values {
+		ports = append(ports, *v.(*domain.Port))
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].ID < ports[j].ID
+	})
+
+	return ports, nil
+}
+
+// Each calls fn on a copy of every port, sorted by ID, without copying them
+// all first. It stops at the first error returned by fn, or once ctx is done.
+func (r *PortRepository) Each(ctx context.Context, fn func(*domain.Port) error) error {
+	r.logger.DebugContext(ctx, "[PortRepository.Each] executing")
+
+	values := r.db.Values(ctx)
+
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].(*domain.Port).ID < values[j].(*domain.Port).ID
+	})
+
+	for _, v := range values {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		p := *v.(*domain.Port)
+		if err := fn(&p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BulkUpsert writes the ports, unless any of them references regions which
+// are not in the region catalogue, rejecting them all with a
+// *port.UnknownRegionsError.
 func (r *PortRepository) BulkUpsert(ctx context.Context, ports domain.Ports) error {
 	r.logger.DebugContext(ctx,
 		"[PortRepository.BulkUpsert] executing",
 		slog.Int("ports.length", len(ports)),
 	)
 
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// checked along with the write, so that the catalogue can't be replaced
+	// in between
+	if unknown := r.unknownRegions(ports); len(unknown) > 0 {
+		return &port.UnknownRegionsError{Regions: unknown}
+	}
+
+	now := time.Now()
+
 	for i := range ports {
 		select {
 		case <-ctx.Done():
 			return nil
 		default:
+			if old, ok := r.db.Get(ctx, ports[i].ID); ok {
+				r.stats.remove(old.(*domain.Port), now)
+				r.indexes.remove(old.(*domain.Port))
+			}
+
 			r.db.Set(ctx, ports[i].ID, &ports[i])
+			r.stats.add(&ports[i], now)
+			r.indexes.add(&ports[i])
 		}
 	}
 
 	return nil
 }
+
+func (r *PortRepository) BulkDelete(ctx context.Context, ids []string) error {
+	r.logger.DebugContext(ctx,
+		"[PortRepository.BulkDelete] executing",
+		slog.Int("ids.length", len(ids)),
+	)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	for _, id := range ids {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+			if old, ok := r.db.Get(ctx, id); ok {
+				r.db.Delete(ctx, id)
+				r.stats.remove(old.(*domain.Port), now)
+				r.indexes.remove(old.(*domain.Port))
+			}
+		}
+	}
+
+	return nil
+}
+
+// FindByKey returns the ports claiming the value of the secondary key, sorted
+// by ID.
+func (r *PortRepository) FindByKey(ctx context.Context, key domain.PortKey, value string) (domain.Ports, error) {
+	r.logger.DebugContext(ctx,
+		"[PortRepository.FindByKey] executing",
+		slog.String("key", string(key)),
+		slog.String("value", value),
+	)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := r.indexes.lookup(key, value)
+	ports := make(domain.Ports, 0, len(ids))
+
+	for _, id := range ids {
+		if v, ok := r.db.Get(ctx, id); ok {
+			ports = append(ports, *v.(*domain.Port))
+		}
+	}
+
+	return ports, nil
+}
+
+// Stats returns the counts of the stored ports, which are kept up to date on
+// each write.
+func (r *PortRepository) Stats(ctx context.Context) (*domain.Stats, error) {
+	r.logger.DebugContext(ctx, "[PortRepository.Stats] executing")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.stats.snapshot(), nil
+}
+
+// Regions returns the region catalogue, sorted by ID.
+func (r *PortRepository) Regions(ctx context.Context) (domain.Regions, error) {
+	r.logger.DebugContext(ctx, "[PortRepository.Regions] executing")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	regions := make(domain.Regions, len(r.regions))
+	copy(regions, r.regions)
+
+	return regions, nil
+}
+
+// ReplaceRegions replaces the whole region catalogue, unless it is missing
+// regions still referenced by ports, rejecting it with a
+// *port.RegionsInUseError.
+func (r *PortRepository) ReplaceRegions(ctx context.Context, regions domain.Regions) error {
+	r.logger.DebugContext(ctx,
+		"[PortRepository.ReplaceRegions] executing",
+		slog.Int("regions.length", len(regions)),
+	)
+
+	stored := make(domain.Regions, len(regions))
+	copy(stored, regions)
+	stored.Sort()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the stats hold every region referenced by the stored ports
+	var inUse []string
+
+	for _, id := range sortedKeys(r.stats.regions) {
+		if _, ok := stored.Get(id); !ok {
+			inUse = append(inUse, id)
+		}
+	}
+
+	if len(inUse) > 0 {
+		return &port.RegionsInUseError{Regions: inUse}
+	}
+
+	r.regions = stored
+
+	return nil
+}
+
+// unknownRegions returns the sorted regions referenced by the ports which are
+// not in the region catalogue.
+func (r *PortRepository) unknownRegions(ports domain.Ports) []string {
+	unknown := make(map[string]bool)
+
+	for idx := range ports {
+		for _, id := range ports[idx].Regions {
+			if _, ok := r.regions.Get(id); !ok {
+				unknown[id] = true
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(unknown))
+	for id := range unknown {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
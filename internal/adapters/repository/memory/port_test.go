@@ -0,0 +1,433 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/adapters/repository/memory"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var loggerTest = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+func TestPortRepository_List(t *testing.T) {
+	tcs := []struct {
+		name        string
+		ports       domain.Ports
+		deleted     []string
+		expectedIDs []string
+	}{
+		{
+			name:        "empty",
+			expectedIDs: []string{},
+		},
+		{
+			name: "sorted by id",
+			ports: domain.Ports{
+				{ID: "USLGB", Name: "Long Beach"},
+				{ID: "AEAJM", Name: "Ajman"},
+				{ID: "NLRTM", Name: "Rotterdam"},
+				{ID: "CNDAL", Name: "Dalian"},
+			},
+			expectedIDs: []string{"AEAJM", "CNDAL", "NLRTM", "USLGB"},
+		},
+		{
+			name: "without deleted ports",
+			ports: domain.Ports{
+				{ID: "USLGB", Name: "Long Beach"},
+				{ID: "AEAJM", Name: "Ajman"},
+				{ID: "NLRTM", Name: "Rotterdam"},
+			},
+			deleted:     []string{"AEAJM"},
+			expectedIDs: []string{"NLRTM", "USLGB"},
+		},
+		{
+			name: "upserted twice",
+			ports: domain.Ports{
+				{ID: "NLRTM", Name: "Rotterdam"},
+				{ID: "AEAJM", Name: "Ajman"},
+				{ID: "NLRTM", Name: "Rotterdam 2"},
+			},
+			expectedIDs: []string{"AEAJM", "NLRTM"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewPortRepository(memory.NewDatabase(), loggerTest)
+
+			require.NoError(t, repo.BulkUpsert(ctx, tc.ports))
+			require.NoError(t, repo.BulkDelete(ctx, tc.deleted))
+
+			ports, err := repo.List(ctx)
+			require.NoError(t, err)
+
+			ids := []string{}
+			for _, p := range ports {
+				ids = append(ids, p.ID)
+			}
+
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+
+	t.Run("copies", func(t *testing.T) {
+		ctx := context.Background()
+		repo := memory.NewPortRepository(memory.NewDatabase(), loggerTest)
+
+		require.NoError(t, repo.BulkUpsert(ctx, domain.Ports{{ID: "AEAJM", Name: "Ajman"}}))
+
+		ports, err := repo.List(ctx)
+		require.NoError(t, err)
+
+		ports[0].Name = "changed"
+
+		stored, err := repo.Get(ctx, "AEAJM")
+		require.NoError(t, err)
+		assert.Equal(t, "Ajman", stored.Name)
+	})
+}
+
+func TestPortRepository_Stats(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewPortRepository(memory.NewDatabase(), loggerTest)
+
+	t.Run("empty", func(t *testing.T) {
+		stats, err := repo.Stats(ctx)
+		require.NoError(t, err)
+
+		assert.Zero(t, stats.Ports)
+		assert.Nil(t, stats.UpdatedAt)
+		assert.Empty(t, stats.Countries)
+		assert.Empty(t, stats.Regions)
+		assert.Empty(t, stats.Timezones)
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		require.NoError(t, repo.ReplaceRegions(ctx, domain.Regions{
+			{ID: "Gulf", Name: "Persian Gulf"},
+			{ID: "South Atlantic", Name: "South Atlantic"},
+		}))
+
+		require.NoError(t, repo.BulkUpsert(ctx, domain.Ports{
+			{
+				ID:          "AEAJM",
+				CountryCode: "AE",
+				Regions:     []string{"Gulf", "Gulf"},
+				Timezone:    "Asia/Dubai",
+				Coordinates: domain.NewCoordinates(25.4052165, 55.5136433),
+			},
+			{ID: "AEDXB", CountryCode: "AE", Timezone: "Asia/Dubai"},
+			{ID: "BRSSZ", CountryCode: "BR", Regions: []string{"South Atlantic"}},
+			{ID: "XXXXX"},
+		}))
+
+		stats, err := repo.Stats(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, stats.Ports)
+		assert.Equal(t, 3, stats.MissingCoordinates)
+		require.NotNil(t, stats.UpdatedAt)
+
+		require.Len(t, stats.Countries, 2)
+		assert.Equal(t, "AE", stats.Countries[0].Country)
+		assert.Equal(t, 2, stats.Countries[0].Ports)
+		assert.Equal(t, 1, stats.Countries[0].MissingCoordinates)
+		assert.Equal(t, "BR", stats.Countries[1].Country)
+		assert.Equal(t, 1, stats.Countries[1].Ports)
+
+		require.Len(t, stats.Regions, 2)
+		assert.Equal(t, "Gulf", stats.Regions[0].Region)
+		assert.Equal(t, 1, stats.Regions[0].Ports)
+		assert.Equal(t, "South Atlantic", stats.Regions[1].Region)
+
+		require.Len(t, stats.Timezones, 1)
+		assert.Equal(t, "Asia/Dubai", stats.Timezones[0].Timezone)
+		assert.Equal(t, 2, stats.Timezones[0].Ports)
+
+		assert.Equal(t, domain.StatsSummary{
+			PortCounts: stats.PortCounts,
+			Countries:  2,
+			Regions:    2,
+			Timezones:  1,
+		}, stats.Summary())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		before, err := repo.Stats(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.BulkUpsert(ctx, domain.Ports{
+			{
+				ID:          "BRSSZ",
+				CountryCode: "BR",
+				Timezone:    "America/Sao_Paulo",
+				Coordinates: domain.NewCoordinates(-23.9608, -46.3336),
+			},
+		}))
+
+		stats, err := repo.Stats(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, stats.Ports)
+		assert.Equal(t, 2, stats.MissingCoordinates)
+		assert.False(t, stats.UpdatedAt.Before(*before.UpdatedAt))
+
+		// the region of the previous version is gone
+		require.Len(t, stats.Regions, 1)
+		assert.Equal(t, "Gulf", stats.Regions[0].Region)
+
+		require.Len(t, stats.Timezones, 2)
+		assert.Equal(t, "America/Sao_Paulo", stats.Timezones[0].Timezone)
+		assert.Equal(t, 1, stats.Timezones[0].Ports)
+
+		// untouched groups keep their update time
+		assert.Equal(t, before.Countries[0], stats.Countries[0])
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, repo.BulkDelete(ctx, []string{"AEAJM", "AEDXB", "NLXXX"}))
+
+		stats, err := repo.Stats(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, stats.Ports)
+		assert.Equal(t, 1, stats.MissingCoordinates)
+
+		require.Len(t, stats.Countries, 1)
+		assert.Equal(t, "BR", stats.Countries[0].Country)
+		assert.Empty(t, stats.Regions)
+		require.Len(t, stats.Timezones, 1)
+		assert.Equal(t, "America/Sao_Paulo", stats.Timezones[0].Timezone)
+	})
+
+	t.Run("consistent with a full scan", func(t *testing.T) {
+		ports, err := repo.List(ctx)
+		require.NoError(t, err)
+
+		stats, err := repo.Stats(ctx)
+		require.NoError(t, err)
+
+		assert.Len(t, ports, stats.Ports)
+	})
+}
+
+func TestPortRepository_Regions(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewPortRepository(memory.NewDatabase(), loggerTest)
+
+	regions, err := repo.Regions(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, regions)
+
+	replaced := domain.Regions{
+		{ID: "gulf", Name: "Persian Gulf", Parent: "asia"},
+		{ID: "asia", Name: "Asia"},
+	}
+	require.NoError(t, repo.ReplaceRegions(ctx, replaced))
+
+	// the stored catalogue is a sorted copy
+	replaced[0].Name = "Arabian Gulf"
+
+	regions, err = repo.Regions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Regions{
+		{ID: "asia", Name: "Asia"},
+		{ID: "gulf", Name: "Persian Gulf", Parent: "asia"},
+	}, regions)
+
+	t.Run("unknown regions", func(t *testing.T) {
+		err := repo.BulkUpsert(ctx, domain.Ports{
+			{ID: "AEAJM", Regions: []string{"gulf"}},
+			{ID: "NLRTM", Regions: []string{"north-sea", "europe"}},
+		})
+
+		var urErr *port.UnknownRegionsError
+		require.ErrorAs(t, err, &urErr)
+		assert.Equal(t, []string{"europe", "north-sea"}, urErr.Regions)
+
+		// none of the ports was written
+		_, err = repo.Get(ctx, "AEAJM")
+		assert.ErrorIs(t, err, port.ErrPortNotFound)
+	})
+
+	t.Run("regions in use", func(t *testing.T) {
+		require.NoError(t, repo.BulkUpsert(ctx, domain.Ports{
+			{ID: "AEAJM", Regions: []string{"gulf"}},
+		}))
+
+		err := repo.ReplaceRegions(ctx, domain.Regions{{ID: "asia", Name: "Asia"}})
+
+		var iuErr *port.RegionsInUseError
+		require.ErrorAs(t, err, &iuErr)
+		assert.Equal(t, []string{"gulf"}, iuErr.Regions)
+
+		// the catalogue was kept
+		regions, err := repo.Regions(ctx)
+		require.NoError(t, err)
+		assert.Len(t, regions, 2)
+
+		require.NoError(t, repo.BulkDelete(ctx, []string{"AEAJM"}))
+		assert.NoError(t, repo.ReplaceRegions(ctx, domain.Regions{{ID: "asia", Name: "Asia"}}))
+	})
+}
+
+func TestPortRepository_Regions_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewPortRepository(memory.NewDatabase(), loggerTest)
+
+	withGulf := domain.Regions{{ID: "asia", Name: "Asia"}, {ID: "gulf", Name: "Persian Gulf"}}
+	withoutGulf := domain.Regions{{ID: "asia", Name: "Asia"}}
+
+	var wg sync.WaitGroup
+
+	for idx := 0; idx < 50; idx++ {
+		wg.Add(2)
+
+		go func(idx int) {
+			defer wg.Done()
+
+			// either fails, with ports referencing gulf, or drops gulf
+			if idx%2 == 0 {
+				_ = repo.ReplaceRegions(ctx, withoutGulf)
+			} else {
+				_ = repo.ReplaceRegions(ctx, withGulf)
+			}
+		}(idx)
+
+		go func(idx int) {
+			defer wg.Done()
+
+			_ = repo.BulkUpsert(ctx, domain.Ports{
+				{ID: fmt.Sprintf("AE%03d", idx), Regions: []string{"gulf"}},
+			})
+		}(idx)
+	}
+
+	wg.Wait()
+
+	regions, err := repo.Regions(ctx)
+	require.NoError(t, err)
+
+	ports, err := repo.List(ctx)
+	require.NoError(t, err)
+
+	for _, p := range ports {
+		for _, id := range p.Regions {
+			_, ok := regions.Get(id)
+			assert.True(t, ok, "port '%s' references unknown region '%s'", p.ID, id)
+		}
+	}
+}
+
+func TestPortRepository_FindByKey(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewPortRepository(memory.NewDatabase(), loggerTest)
+
+	require.NoError(t, repo.BulkUpsert(ctx, domain.Ports{
+		{ID: "CNDAL", Unlocs: []string{"CNDAL", "CNDLC"}, Code: "57000", Alias: []string{"Dalian"}},
+		{ID: "CNDLC", Unlocs: []string{"CNDLC"}, Code: "57000"},
+		{ID: "USLGB", Unlocs: []string{"USLGB"}, Code: "2709", Alias: []string{"Long Beach"}},
+	}))
+
+	ids := func(key domain.PortKey, value string) []string {
+		ports, err := repo.FindByKey(ctx, key, value)
+		require.NoError(t, err)
+
+		ids := []string{}
+		for _, p := range ports {
+			ids = append(ids, p.ID)
+		}
+
+		return ids
+	}
+
+	assert.Equal(t, []string{"CNDAL"}, ids(domain.PortKeyUnloc, "cn dal"))
+	assert.Equal(t, []string{"CNDAL", "CNDLC"}, ids(domain.PortKeyUnloc, "CNDLC"))
+	assert.Equal(t, []string{"CNDAL", "CNDLC"}, ids(domain.PortKeyCode, "57000"))
+	assert.Equal(t, []string{"USLGB"}, ids(domain.PortKeyAlias, "LONG BEACH"))
+	assert.Equal(t, []string{}, ids(domain.PortKeyAlias, "Rotterdam"))
+
+	t.Run("update", func(t *testing.T) {
+		require.NoError(t, repo.BulkUpsert(ctx, domain.Ports{
+			{ID: "CNDAL", Unlocs: []string{"CNDAL"}, Code: "57001"},
+		}))
+
+		assert.Equal(t, []string{"CNDLC"}, ids(domain.PortKeyUnloc, "CNDLC"))
+		assert.Equal(t, []string{"CNDLC"}, ids(domain.PortKeyCode, "57000"))
+		assert.Equal(t, []string{"CNDAL"}, ids(domain.PortKeyCode, "57001"))
+		assert.Equal(t, []string{}, ids(domain.PortKeyAlias, "Dalian"))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, repo.BulkDelete(ctx, []string{"USLGB"}))
+
+		assert.Equal(t, []string{}, ids(domain.PortKeyUnloc, "USLGB"))
+		assert.Equal(t, []string{}, ids(domain.PortKeyAlias, "Long Beach"))
+	})
+}
+
+func TestPortRepository_Each(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewPortRepository(memory.NewDatabase(), loggerTest)
+
+	require.NoError(t, repo.BulkUpsert(ctx, domain.Ports{
+		{ID: "USLGB", Name: "Long Beach"},
+		{ID: "CNDAL", Name: "Dalian"},
+		{ID: "NLRTM", Name: "Rotterdam"},
+	}))
+
+	t.Run("sorted by id", func(t *testing.T) {
+		var ids []string
+
+		err := repo.Each(ctx, func(p *domain.Port) error {
+			ids = append(ids, p.ID)
+
+			// changing the yielded port doesn't change the stored one
+			p.Name = "changed"
+
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"CNDAL", "NLRTM", "USLGB"}, ids)
+
+		stored, err := repo.Get(ctx, "CNDAL")
+		require.NoError(t, err)
+		assert.Equal(t, "Dalian", stored.Name)
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		calls := 0
+
+		err := repo.Each(ctx, func(*domain.Port) error {
+			calls++
+			return errors.New("each err")
+		})
+		assert.EqualError(t, err, "each err")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("stops once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		calls := 0
+
+		err := repo.Each(ctx, func(*domain.Port) error {
+			calls++
+			cancel()
+
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, calls)
+	})
+}
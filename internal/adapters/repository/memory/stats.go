@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+)
+
+type (
+	// counts are the counts of a group of ports.
+	counts struct {
+		ports              int
+		missingCoordinates int
+		updatedAt          time.Time
+	}
+
+	// stats are the counts of the stored ports, kept up to date on each
+	// write instead of scanning all the ports when requested.
+	stats struct {
+		total     counts
+		countries map[string]*counts
+		regions   map[string]*counts
+		timezones map[string]*counts
+	}
+)
+
+func newStats() *stats {
+	return &stats{
+		countries: make(map[string]*counts),
+		regions:   make(map[string]*counts),
+		timezones: make(map[string]*counts),
+	}
+}
+
+// add counts the port p, updated at t.
+func (s *stats) add(p *domain.Port, t time.Time) {
+	s.update(p, t, 1)
+}
+
+// remove stops counting the port p, updated at t.
+func (s *stats) remove(p *domain.Port, t time.Time) {
+	s.update(p, t, -1)
+}
+
+func (s *stats) update(p *domain.Port, t time.Time, delta int) {
+	missing := 0
+	if len(p.Coordinates) == 0 {
+		missing = delta
+	}
+
+	apply := func(c *counts) {
+		c.ports += delta
+		c.missingCoordinates += missing
+		c.updatedAt = t
+	}
+
+	apply(&s.total)
+
+	group := func(groups map[string]*counts, key string) {
+		if key == "" {
+			return
+		}
+
+		c, ok := groups[key]
+		if !ok {
+			c = &counts{}
+			groups[key] = c
+		}
+
+		apply(c)
+
+		if c.ports <= 0 {
+			delete(groups, key)
+		}
+	}
+
+	group(s.countries, p.CountryCode)
+	group(s.timezones, p.Timezone)
+
+	// a port listing a region more than once is counted once on it
+	seen := make(map[string]bool, len(p.Regions))
+
+	for _, r := range p.Regions {
+		if !seen[r] {
+			seen[r] = true
+			group(s.regions, r)
+		}
+	}
+}
+
+// snapshot returns a copy of the stats.
+func (s *stats) snapshot() *domain.Stats {
+	result := &domain.Stats{
+		PortCounts: s.total.portCounts(),
+		Countries:  make([]domain.CountryStats, 0, len(s.countries)),
+		Regions:    make([]domain.RegionStats, 0, len(s.regions)),
+		Timezones:  make([]domain.TimezoneStats, 0, len(s.timezones)),
+	}
+
+	for _, k := range sortedKeys(s.countries) {
+		result.Countries = append(result.Countries, domain.CountryStats{
+			Country:    k,
+			PortCounts: s.countries[k].portCounts(),
+		})
+	}
+
+	for _, k := range sortedKeys(s.regions) {
+		result.Regions = append(result.Regions, domain.RegionStats{
+			Region:     k,
+			PortCounts: s.regions[k].portCounts(),
+		})
+	}
+
+	for _, k := range sortedKeys(s.timezones) {
+		result.Timezones = append(result.Timezones, domain.TimezoneStats{
+			Timezone:   k,
+			PortCounts: s.timezones[k].portCounts(),
+		})
+	}
+
+	return result
+}
+
+func (c *counts) portCounts() domain.PortCounts {
+	pc := domain.PortCounts{
+		Ports:              c.ports,
+		MissingCoordinates: c.missingCoordinates,
+	}
+
+	// nothing was ever written
+	if !c.updatedAt.IsZero() {
+		t := c.updatedAt
+		pc.UpdatedAt = &t
+	}
+
+	return pc
+}
+
+func sortedKeys(m map[string]*counts) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/caarlos0/env/v10"
 )
@@ -25,6 +26,27 @@ type (
 		Application AppMetadata `envPrefix:"APP_"`
 		Server      Server      `envPrefix:"SERVER_"`
 		Ingestor    Ingestor    `envPrefix:"INGESTOR_"`
+		// RulesPath is the path of the file holding the rules the ports are
+		// checked against.
+		RulesPath string `env:"RULES_PATH"`
+		// KeepRawPorts keeps the raw original of the ports changed by the
+		// normalisation applied on writes.
+		KeepRawPorts bool `env:"KEEP_RAW_PORTS"`
+		// TileCacheSize is the number of vector tiles of the ports cached.
+		TileCacheSize int `env:"TILE_CACHE_SIZE" envDefault:"1024"`
+		// DistanceMatrixMaxCells is the largest number of distances, origins
+		// times destinations, of a distance matrix.
+		DistanceMatrixMaxCells int `env:"DISTANCE_MATRIX_MAX_CELLS" envDefault:"250000"`
+		// ImportMaxBytes is the size limit of the files uploaded to be
+		// imported.
+		ImportMaxBytes int64 `env:"IMPORT_MAX_BYTES" envDefault:"104857600"`
+		// ImportMaxInputBytes is the size limit of the uploaded files once
+		// decompressed.
+		ImportMaxInputBytes int64 `env:"IMPORT_MAX_INPUT_BYTES" envDefault:"1073741824"`
+		// ImportMaxJobs is the number of import jobs running at once.
+		ImportMaxJobs int `env:"IMPORT_MAX_JOBS" envDefault:"4"`
+		// ImportJobsTTL is how long finished import jobs are kept.
+		ImportJobsTTL time.Duration `env:"IMPORT_JOBS_TTL" envDefault:"24h"`
 	}
 
 	// AppMetadata contains the application's metadata.
@@ -41,8 +63,43 @@ type (
 
 	// Ingestor contains ingestor environment variables.
 	Ingestor struct {
-		BatchSize int    `env:"BATCH_SIZE" envDefault:"50"`
-		Filepath  string `env:"FILEPATH"`
+		Mode        string        `env:"MODE" envDefault:"ingest"`
+		BatchSize   int           `env:"BATCH_SIZE" envDefault:"50"`
+		Filepath    string        `env:"FILEPATH"`
+		Format      string        `env:"FORMAT" envDefault:"json"`
+		Lenient     bool          `env:"LENIENT"`
+		MaxErrors   int           `env:"MAX_ERRORS" envDefault:"100"`
+		Decoders    int           `env:"DECODERS" envDefault:"1"`
+		MappingPath string        `env:"MAPPING_PATH"`
+		ScriptPath  string        `env:"SCRIPT_PATH"`
+		WatchDir    string        `env:"WATCH_DIR"`
+		WatchSettle time.Duration `env:"WATCH_SETTLE" envDefault:"2s"`
+		Sync        Sync          `envPrefix:"SYNC_"`
+
+		QualityFormat string `env:"QUALITY_FORMAT" envDefault:"json"`
+
+		ProgressInterval time.Duration `env:"PROGRESS_INTERVAL" envDefault:"5s"`
+		ReportPath       string        `env:"REPORT_PATH"`
+
+		Adaptive  Adaptive `envPrefix:"ADAPTIVE_"`
+		RateLimit float64  `env:"RATE_LIMIT"`
+	}
+
+	// Sync contains the ingestor sync mode environment variables.
+	Sync struct {
+		Apply            bool    `env:"APPLY"`
+		Prune            bool    `env:"PRUNE"`
+		MaxDeletePercent float64 `env:"MAX_DELETE_PERCENT" envDefault:"10"`
+	}
+
+	// Adaptive contains the ingestor adaptive batching environment variables.
+	Adaptive struct {
+		Enabled        bool          `env:"ENABLED"`
+		MinBatchSize   int           `env:"MIN_BATCH_SIZE" envDefault:"1"`
+		MaxBatchSize   int           `env:"MAX_BATCH_SIZE" envDefault:"1000"`
+		MaxConcurrency int           `env:"MAX_CONCURRENCY" envDefault:"8"`
+		TargetLatency  time.Duration `env:"TARGET_LATENCY" envDefault:"500ms"`
+		MaxRetries     int           `env:"MAX_RETRIES" envDefault:"3"`
 	}
 )
 
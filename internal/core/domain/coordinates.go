@@ -0,0 +1,291 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rafaeltg/goports/pkg/geohash"
+)
+
+const (
+	// CoordinatesArray renders coordinates as a [longitude, latitude] array.
+	CoordinatesArray CoordinatesFormat = "array"
+	// CoordinatesObject renders coordinates as a {"lat": ..., "lon": ...} object.
+	CoordinatesObject CoordinatesFormat = "object"
+	// CoordinatesDMS renders coordinates as degrees, minutes and seconds,
+	// like 25°24'18.78"N 55°30'49.12"E.
+	CoordinatesDMS CoordinatesFormat = "dms"
+	// CoordinatesGeohash renders coordinates as a geohash.
+	CoordinatesGeohash CoordinatesFormat = "geohash"
+)
+
+// GeohashPrecision is the number of characters of the rendered geohashes,
+// which is about 5 meters.
+const GeohashPrecision = 9
+
+// dmsPattern matches a single DMS coordinate, like 25°24'18.78"N.
+var dmsPattern = regexp.MustCompile(
+	`(\d+(?:\.\d+)?)\s*°\s*(?:(\d+(?:\.\d+)?)\s*['′]\s*)?(?:(\d+(?:\.\d+)?)\s*(?:"|″|'')\s*)?([NSEWnsew])`,
+)
+
+type (
+	// Coordinates holds the longitude and latitude of a point, in this order,
+	// and is encoded as a [longitude, latitude] JSON array. It may be decoded
+	// from any of its rendered forms: an array, a {"lat", "lon"} object, a
+	// DMS string or a geohash. Empty coordinates are missing ones.
+	Coordinates []float64
+
+	// CoordinatesFormat is a way of rendering coordinates.
+	CoordinatesFormat string
+
+	// LatLon is the object form of coordinates.
+	LatLon struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	}
+)
+
+// NewCoordinates returns the coordinates of a point.
+func NewCoordinates(lat, lon float64) Coordinates {
+	return Coordinates{lon, lat}
+}
+
+// ParseCoordinates parses coordinates given either as a DMS string or as a
+// geohash, whose cell center is taken.
+func ParseCoordinates(s string) (Coordinates, error) {
+	s = strings.TrimSpace(s)
+
+	if strings.ContainsAny(s, "°") {
+		return parseDMS(s)
+	}
+
+	lat, lon, _, _, err := geohash.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coordinates '%s': neither DMS nor a geohash", s)
+	}
+
+	return NewCoordinates(lat, lon), nil
+}
+
+// ParseCoordinatesFormat parses a coordinates format, an empty one being the
+// array one.
+func ParseCoordinatesFormat(s string) (CoordinatesFormat, error) {
+	switch f := CoordinatesFormat(strings.ToLower(s)); f {
+	case "":
+		return CoordinatesArray, nil
+	case CoordinatesArray, CoordinatesObject, CoordinatesDMS, CoordinatesGeohash:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown coordinates format '%s'", s)
+	}
+}
+
+// Lon returns the longitude, or zero when missing.
+func (c Coordinates) Lon() float64 {
+	if len(c) < 2 {
+		return 0
+	}
+
+	return c[0]
+}
+
+// Lat returns the latitude, or zero when missing.
+func (c Coordinates) Lat() float64 {
+	if len(c) < 2 {
+		return 0
+	}
+
+	return c[1]
+}
+
+// Valid reports whether there are both a longitude and a latitude, within
+// their ranges.
+func (c Coordinates) Valid() bool {
+	return c.Validate() == nil && len(c) == 2
+}
+
+// Validate checks the coordinates, if any, have both a longitude and a
+// latitude within their ranges.
+func (c Coordinates) Validate() error {
+	switch {
+	case len(c) == 0:
+		return nil
+	case len(c) != 2:
+		return fmt.Errorf("must have 2 values, got %d", len(c))
+	case c.Lon() < -180 || c.Lon() > 180:
+		return fmt.Errorf("longitude %v out of range", c.Lon())
+	case c.Lat() < -90 || c.Lat() > 90:
+		return fmt.Errorf("latitude %v out of range", c.Lat())
+	default:
+		return nil
+	}
+}
+
+// Geohash returns the geohash of the coordinates with the given number of
+// characters, or an empty string when they are not valid.
+func (c Coordinates) Geohash(precision int) string {
+	if !c.Valid() {
+		return ""
+	}
+
+	return geohash.Encode(c.Lat(), c.Lon(), precision)
+}
+
+// DMS returns the coordinates as degrees, minutes and seconds, latitude
+// first, or an empty string when they are not valid.
+func (c Coordinates) DMS() string {
+	if !c.Valid() {
+		return ""
+	}
+
+	return dms(c.Lat(), "N", "S") + " " + dms(c.Lon(), "E", "W")
+}
+
+// Format returns the coordinates rendered in the given format, or nil when
+// they are missing.
+func (c Coordinates) Format(f CoordinatesFormat) any {
+	if len(c) == 0 {
+		return nil
+	}
+
+	switch f {
+	case CoordinatesObject:
+		return LatLon{Lat: c.Lat(), Lon: c.Lon()}
+	case CoordinatesDMS:
+		return c.DMS()
+	case CoordinatesGeohash:
+		return c.Geohash(GeohashPrecision)
+	default:
+		return []float64(c)
+	}
+}
+
+// UnmarshalJSON decodes coordinates from any of their rendered forms. Their
+// ranges aren't checked, whatever the form, as ports are validated on writes.
+func (c *Coordinates) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+
+	switch {
+	case len(b) == 0, bytes.Equal(b, []byte("null")):
+		*c = nil
+		return nil
+	case b[0] == '{':
+		var obj struct {
+			Lat       *float64 `json:"lat"`
+			Latitude  *float64 `json:"latitude"`
+			Lon       *float64 `json:"lon"`
+			Lng       *float64 `json:"lng"`
+			Longitude *float64 `json:"longitude"`
+		}
+
+		if err := json.Unmarshal(b, &obj); err != nil {
+			return err
+		}
+
+		lat, lon := firstSet(obj.Lat, obj.Latitude), firstSet(obj.Lon, obj.Lng, obj.Longitude)
+		if lat == nil || lon == nil {
+			return errors.New("coordinates object must have a latitude and a longitude")
+		}
+
+		*c = NewCoordinates(*lat, *lon)
+
+		return nil
+	case b[0] == '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+
+		parsed, err := ParseCoordinates(s)
+		if err != nil {
+			return err
+		}
+
+		*c = parsed
+
+		return nil
+	default:
+		return json.Unmarshal(b, (*[]float64)(c))
+	}
+}
+
+func firstSet(values ...*float64) *float64 {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+
+	return nil
+}
+
+func dms(v float64, pos, neg string) string {
+	hemisphere := pos
+	if v < 0 {
+		hemisphere = neg
+	}
+
+	// rounded to hundredths of seconds first, so that 59.999 seconds are
+	// carried over to the minutes
+	total := math.Round(math.Abs(v) * 360000)
+
+	deg := math.Floor(total / 360000)
+	min := math.Floor((total - deg*360000) / 6000)
+	sec := (total - deg*360000 - min*6000) / 100
+
+	return fmt.Sprintf(`%d°%d'%s"%s`, int(deg), int(min), strconv.FormatFloat(sec, 'f', -1, 64), hemisphere)
+}
+
+// parseDMS parses a latitude and a longitude given in DMS, in any order.
+func parseDMS(s string) (Coordinates, error) {
+	matches := dmsPattern.FindAllStringSubmatch(s, -1)
+	if len(matches) != 2 {
+		return nil, fmt.Errorf("invalid DMS coordinates '%s'", s)
+	}
+
+	var lat, lon *float64
+
+	for _, m := range matches {
+		v := 0.0
+
+		for idx, div := range []float64{1, 60, 3600} {
+			if m[idx+1] == "" {
+				continue
+			}
+
+			f, err := strconv.ParseFloat(m[idx+1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid DMS coordinates '%s'", s)
+			}
+
+			v += f / div
+		}
+
+		switch strings.ToUpper(m[4]) {
+		case "S":
+			v = -v
+			fallthrough
+		case "N":
+			lat = &v
+		case "W":
+			v = -v
+			fallthrough
+		default:
+			lon = &v
+		}
+	}
+
+	if lat == nil || lon == nil {
+		return nil, fmt.Errorf("invalid DMS coordinates '%s': a latitude and a longitude are required", s)
+	}
+
+	c := NewCoordinates(*lat, *lon)
+
+	return c, c.Validate()
+}
@@ -0,0 +1,142 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoordinates_Format(t *testing.T) {
+	c := domain.NewCoordinates(25.4052165, 55.5136433)
+
+	assert.Equal(t, 25.4052165, c.Lat())
+	assert.Equal(t, 55.5136433, c.Lon())
+
+	assert.Equal(t, []float64{55.5136433, 25.4052165}, c.Format(domain.CoordinatesArray))
+	assert.Equal(t, domain.LatLon{Lat: 25.4052165, Lon: 55.5136433}, c.Format(domain.CoordinatesObject))
+	assert.Equal(t, `25°24'18.78"N 55°30'49.12"E`, c.Format(domain.CoordinatesDMS))
+	assert.Equal(t, "thx2x0zu1", c.Format(domain.CoordinatesGeohash))
+
+	assert.Equal(t, `33°52'4.08"S 151°12'36"W`, domain.NewCoordinates(-33.8678, -151.21).DMS())
+	assert.Nil(t, domain.Coordinates(nil).Format(domain.CoordinatesObject))
+	assert.Empty(t, domain.Coordinates{1}.DMS())
+
+	b, err := json.Marshal(domain.Port{ID: "AEAJM", Coordinates: c})
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"coordinates":[55.5136433,25.4052165]`)
+}
+
+func TestCoordinates_Validate(t *testing.T) {
+	assert.NoError(t, domain.Coordinates(nil).Validate())
+	assert.NoError(t, domain.NewCoordinates(-90, 180).Validate())
+	assert.EqualError(t, domain.Coordinates{1, 2, 3}.Validate(), "must have 2 values, got 3")
+	assert.EqualError(t, domain.Coordinates{181, 0}.Validate(), "longitude 181 out of range")
+	assert.EqualError(t, domain.Coordinates{0, -91}.Validate(), "latitude -91 out of range")
+
+	assert.False(t, domain.Coordinates(nil).Valid())
+	assert.True(t, domain.NewCoordinates(0, 0).Valid())
+}
+
+func TestCoordinates_UnmarshalJSON(t *testing.T) {
+	tcs := []struct {
+		name        string
+		input       string
+		expected    domain.Coordinates
+		expectedErr string
+	}{
+		{
+			name:     "array",
+			input:    `[55.5136433, 25.4052165]`,
+			expected: domain.Coordinates{55.5136433, 25.4052165},
+		},
+		{
+			name:  "null",
+			input: `null`,
+		},
+		{
+			name:     "object",
+			input:    `{"lat": 25.4052165, "lon": 55.5136433}`,
+			expected: domain.Coordinates{55.5136433, 25.4052165},
+		},
+		{
+			name:     "object with long names",
+			input:    `{"latitude": 25.4052165, "longitude": 55.5136433}`,
+			expected: domain.Coordinates{55.5136433, 25.4052165},
+		},
+		{
+			name:     "object with lng",
+			input:    `{"lat": 25.4052165, "lng": 55.5136433}`,
+			expected: domain.Coordinates{55.5136433, 25.4052165},
+		},
+		{
+			name:        "object without longitude",
+			input:       `{"lat": 25.4052165}`,
+			expectedErr: "coordinates object must have a latitude and a longitude",
+		},
+		{
+			name:     "dms",
+			input:    `"25°24'18.78\"N 55°30'49.12\"E"`,
+			expected: domain.Coordinates{55.51364444444445, 25.405216666666668},
+		},
+		{
+			name:     "dms longitude first",
+			input:    `"151°12'36\"W, 33°52'4.08\"S"`,
+			expected: domain.Coordinates{-151.21, -33.8678},
+		},
+		{
+			name:     "dms degrees only",
+			input:    `"25°N 55°E"`,
+			expected: domain.Coordinates{55, 25},
+		},
+		{
+			name:        "dms without longitude",
+			input:       `"25°24'18.78\"N 55°30'49.12\"N"`,
+			expectedErr: `invalid DMS coordinates '25°24'18.78"N 55°30'49.12"N': a latitude and a longitude are required`,
+		},
+		{
+			name:        "dms out of range",
+			input:       `"95°N 55°E"`,
+			expectedErr: "latitude 95 out of range",
+		},
+		{
+			name:     "geohash",
+			input:    `"ezs42"`,
+			expected: domain.Coordinates{-5.60302734375, 42.60498046875},
+		},
+		{
+			name:        "invalid string",
+			input:       `"abc"`,
+			expectedErr: "invalid coordinates 'abc': neither DMS nor a geohash",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var c domain.Coordinates
+
+			err := json.Unmarshal([]byte(tc.input), &c)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.InDeltaSlice(t, tc.expected, c, 1e-9)
+		})
+	}
+}
+
+func TestParseCoordinatesFormat(t *testing.T) {
+	f, err := domain.ParseCoordinatesFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CoordinatesArray, f)
+
+	f, err = domain.ParseCoordinatesFormat("DMS")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CoordinatesDMS, f)
+
+	_, err = domain.ParseCoordinatesFormat("wkt")
+	assert.EqualError(t, err, "unknown coordinates format 'wkt'")
+}
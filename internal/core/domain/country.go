@@ -0,0 +1,519 @@
+package domain
+
+import "strings"
+
+type (
+	// Country is an ISO 3166-1 country.
+	Country struct {
+		// Code is the ISO 3166-1 alpha-2 code.
+		Code string `json:"code"`
+		Name string `json:"name"`
+	}
+
+	// CountrySummary is a country along with its number of ports.
+	CountrySummary struct {
+		Country
+		Ports int `json:"ports"`
+	}
+)
+
+// countries holds the ISO 3166-1 countries, by code, along with the withdrawn
+// Netherlands Antilles which is still found on UN/LOCODEs.
+var countries = []Country{
+	{Code: "AD", Name: "Andorra"},
+	{Code: "AE", Name: "United Arab Emirates"},
+	{Code: "AF", Name: "Afghanistan"},
+	{Code: "AG", Name: "Antigua and Barbuda"},
+	{Code: "AI", Name: "Anguilla"},
+	{Code: "AL", Name: "Albania"},
+	{Code: "AM", Name: "Armenia"},
+	{Code: "AN", Name: "Netherlands Antilles"},
+	{Code: "AO", Name: "Angola"},
+	{Code: "AQ", Name: "Antarctica"},
+	{Code: "AR", Name: "Argentina"},
+	{Code: "AS", Name: "American Samoa"},
+	{Code: "AT", Name: "Austria"},
+	{Code: "AU", Name: "Australia"},
+	{Code: "AW", Name: "Aruba"},
+	{Code: "AX", Name: "Åland Islands"},
+	{Code: "AZ", Name: "Azerbaijan"},
+	{Code: "BA", Name: "Bosnia and Herzegovina"},
+	{Code: "BB", Name: "Barbados"},
+	{Code: "BD", Name: "Bangladesh"},
+	{Code: "BE", Name: "Belgium"},
+	{Code: "BF", Name: "Burkina Faso"},
+	{Code: "BG", Name: "Bulgaria"},
+	{Code: "BH", Name: "Bahrain"},
+	{Code: "BI", Name: "Burundi"},
+	{Code: "BJ", Name: "Benin"},
+	{Code: "BL", Name: "Saint Barthélemy"},
+	{Code: "BM", Name: "Bermuda"},
+	{Code: "BN", Name: "Brunei Darussalam"},
+	{Code: "BO", Name: "Bolivia, Plurinational State of"},
+	{Code: "BQ", Name: "Bonaire, Sint Eustatius and Saba"},
+	{Code: "BR", Name: "Brazil"},
+	{Code: "BS", Name: "Bahamas"},
+	{Code: "BT", Name: "Bhutan"},
+	{Code: "BV", Name: "Bouvet Island"},
+	{Code: "BW", Name: "Botswana"},
+	{Code: "BY", Name: "Belarus"},
+	{Code: "BZ", Name: "Belize"},
+	{Code: "CA", Name: "Canada"},
+	{Code: "CC", Name: "Cocos (Keeling) Islands"},
+	{Code: "CD", Name: "Congo, The Democratic Republic of the"},
+	{Code: "CF", Name: "Central African Republic"},
+	{Code: "CG", Name: "Congo"},
+	{Code: "CH", Name: "Switzerland"},
+	{Code: "CI", Name: "Côte d'Ivoire"},
+	{Code: "CK", Name: "Cook Islands"},
+	{Code: "CL", Name: "Chile"},
+	{Code: "CM", Name: "Cameroon"},
+	{Code: "CN", Name: "China"},
+	{Code: "CO", Name: "Colombia"},
+	{Code: "CR", Name: "Costa Rica"},
+	{Code: "CU", Name: "Cuba"},
+	{Code: "CV", Name: "Cabo Verde"},
+	{Code: "CW", Name: "Curaçao"},
+	{Code: "CX", Name: "Christmas Island"},
+	{Code: "CY", Name: "Cyprus"},
+	{Code: "CZ", Name: "Czechia"},
+	{Code: "DE", Name: "Germany"},
+	{Code: "DJ", Name: "Djibouti"},
+	{Code: "DK", Name: "Denmark"},
+	{Code: "DM", Name: "Dominica"},
+	{Code: "DO", Name: "Dominican Republic"},
+	{Code: "DZ", Name: "Algeria"},
+	{Code: "EC", Name: "Ecuador"},
+	{Code: "EE", Name: "Estonia"},
+	{Code: "EG", Name: "Egypt"},
+	{Code: "EH", Name: "Western Sahara"},
+	{Code: "ER", Name: "Eritrea"},
+	{Code: "ES", Name: "Spain"},
+	{Code: "ET", Name: "Ethiopia"},
+	{Code: "FI", Name: "Finland"},
+	{Code: "FJ", Name: "Fiji"},
+	{Code: "FK", Name: "Falkland Islands (Malvinas)"},
+	{Code: "FM", Name: "Micronesia, Federated States of"},
+	{Code: "FO", Name: "Faroe Islands"},
+	{Code: "FR", Name: "France"},
+	{Code: "GA", Name: "Gabon"},
+	{Code: "GB", Name: "United Kingdom"},
+	{Code: "GD", Name: "Grenada"},
+	{Code: "GE", Name: "Georgia"},
+	{Code: "GF", Name: "French Guiana"},
+	{Code: "GG", Name: "Guernsey"},
+	{Code: "GH", Name: "Ghana"},
+	{Code: "GI", Name: "Gibraltar"},
+	{Code: "GL", Name: "Greenland"},
+	{Code: "GM", Name: "Gambia"},
+	{Code: "GN", Name: "Guinea"},
+	{Code: "GP", Name: "Guadeloupe"},
+	{Code: "GQ", Name: "Equatorial Guinea"},
+	{Code: "GR", Name: "Greece"},
+	{Code: "GS", Name: "South Georgia and the South Sandwich Islands"},
+	{Code: "GT", Name: "Guatemala"},
+	{Code: "GU", Name: "Guam"},
+	{Code: "GW", Name: "Guinea-Bissau"},
+	{Code: "GY", Name: "Guyana"},
+	{Code: "HK", Name: "Hong Kong"},
+	{Code: "HM", Name: "Heard Island and McDonald Islands"},
+	{Code: "HN", Name: "Honduras"},
+	{Code: "HR", Name: "Croatia"},
+	{Code: "HT", Name: "Haiti"},
+	{Code: "HU", Name: "Hungary"},
+	{Code: "ID", Name: "Indonesia"},
+	{Code: "IE", Name: "Ireland"},
+	{Code: "IL", Name: "Israel"},
+	{Code: "IM", Name: "Isle of Man"},
+	{Code: "IN", Name: "India"},
+	{Code: "IO", Name: "British Indian Ocean Territory"},
+	{Code: "IQ", Name: "Iraq"},
+	{Code: "IR", Name: "Iran, Islamic Republic of"},
+	{Code: "IS", Name: "Iceland"},
+	{Code: "IT", Name: "Italy"},
+	{Code: "JE", Name: "Jersey"},
+	{Code: "JM", Name: "Jamaica"},
+	{Code: "JO", Name: "Jordan"},
+	{Code: "JP", Name: "Japan"},
+	{Code: "KE", Name: "Kenya"},
+	{Code: "KG", Name: "Kyrgyzstan"},
+	{Code: "KH", Name: "Cambodia"},
+	{Code: "KI", Name: "Kiribati"},
+	{Code: "KM", Name: "Comoros"},
+	{Code: "KN", Name: "Saint Kitts and Nevis"},
+	{Code: "KP", Name: "Korea, Democratic People's Republic of"},
+	{Code: "KR", Name: "Korea, Republic of"},
+	{Code: "KW", Name: "Kuwait"},
+	{Code: "KY", Name: "Cayman Islands"},
+	{Code: "KZ", Name: "Kazakhstan"},
+	{Code: "LA", Name: "Lao People's Democratic Republic"},
+	{Code: "LB", Name: "Lebanon"},
+	{Code: "LC", Name: "Saint Lucia"},
+	{Code: "LI", Name: "Liechtenstein"},
+	{Code: "LK", Name: "Sri Lanka"},
+	{Code: "LR", Name: "Liberia"},
+	{Code: "LS", Name: "Lesotho"},
+	{Code: "LT", Name: "Lithuania"},
+	{Code: "LU", Name: "Luxembourg"},
+	{Code: "LV", Name: "Latvia"},
+	{Code: "LY", Name: "Libya"},
+	{Code: "MA", Name: "Morocco"},
+	{Code: "MC", Name: "Monaco"},
+	{Code: "MD", Name: "Moldova, Republic of"},
+	{Code: "ME", Name: "Montenegro"},
+	{Code: "MF", Name: "Saint Martin (French part)"},
+	{Code: "MG", Name: "Madagascar"},
+	{Code: "MH", Name: "Marshall Islands"},
+	{Code: "MK", Name: "North Macedonia"},
+	{Code: "ML", Name: "Mali"},
+	{Code: "MM", Name: "Myanmar"},
+	{Code: "MN", Name: "Mongolia"},
+	{Code: "MO", Name: "Macao"},
+	{Code: "MP", Name: "Northern Mariana Islands"},
+	{Code: "MQ", Name: "Martinique"},
+	{Code: "MR", Name: "Mauritania"},
+	{Code: "MS", Name: "Montserrat"},
+	{Code: "MT", Name: "Malta"},
+	{Code: "MU", Name: "Mauritius"},
+	{Code: "MV", Name: "Maldives"},
+	{Code: "MW", Name: "Malawi"},
+	{Code: "MX", Name: "Mexico"},
+	{Code: "MY", Name: "Malaysia"},
+	{Code: "MZ", Name: "Mozambique"},
+	{Code: "NA", Name: "Namibia"},
+	{Code: "NC", Name: "New Caledonia"},
+	{Code: "NE", Name: "Niger"},
+	{Code: "NF", Name: "Norfolk Island"},
+	{Code: "NG", Name: "Nigeria"},
+	{Code: "NI", Name: "Nicaragua"},
+	{Code: "NL", Name: "Netherlands"},
+	{Code: "NO", Name: "Norway"},
+	{Code: "NP", Name: "Nepal"},
+	{Code: "NR", Name: "Nauru"},
+	{Code: "NU", Name: "Niue"},
+	{Code: "NZ", Name: "New Zealand"},
+	{Code: "OM", Name: "Oman"},
+	{Code: "PA", Name: "Panama"},
+	{Code: "PE", Name: "Peru"},
+	{Code: "PF", Name: "French Polynesia"},
+	{Code: "PG", Name: "Papua New Guinea"},
+	{Code: "PH", Name: "Philippines"},
+	{Code: "PK", Name: "Pakistan"},
+	{Code: "PL", Name: "Poland"},
+	{Code: "PM", Name: "Saint Pierre and Miquelon"},
+	{Code: "PN", Name: "Pitcairn"},
+	{Code: "PR", Name: "Puerto Rico"},
+	{Code: "PS", Name: "Palestine, State of"},
+	{Code: "PT", Name: "Portugal"},
+	{Code: "PW", Name: "Palau"},
+	{Code: "PY", Name: "Paraguay"},
+	{Code: "QA", Name: "Qatar"},
+	{Code: "RE", Name: "Réunion"},
+	{Code: "RO", Name: "Romania"},
+	{Code: "RS", Name: "Serbia"},
+	{Code: "RU", Name: "Russian Federation"},
+	{Code: "RW", Name: "Rwanda"},
+	{Code: "SA", Name: "Saudi Arabia"},
+	{Code: "SB", Name: "Solomon Islands"},
+	{Code: "SC", Name: "Seychelles"},
+	{Code: "SD", Name: "Sudan"},
+	{Code: "SE", Name: "Sweden"},
+	{Code: "SG", Name: "Singapore"},
+	{Code: "SH", Name: "Saint Helena, Ascension and Tristan da Cunha"},
+	{Code: "SI", Name: "Slovenia"},
+	{Code: "SJ", Name: "Svalbard and Jan Mayen"},
+	{Code: "SK", Name: "Slovakia"},
+	{Code: "SL", Name: "Sierra Leone"},
+	{Code: "SM", Name: "San Marino"},
+	{Code: "SN", Name: "Senegal"},
+	{Code: "SO", Name: "Somalia"},
+	{Code: "SR", Name: "Suriname"},
+	{Code: "SS", Name: "South Sudan"},
+	{Code: "ST", Name: "Sao Tome and Principe"},
+	{Code: "SV", Name: "El Salvador"},
+	{Code: "SX", Name: "Sint Maarten (Dutch part)"},
+	{Code: "SY", Name: "Syrian Arab Republic"},
+	{Code: "SZ", Name: "Eswatini"},
+	{Code: "TC", Name: "Turks and Caicos Islands"},
+	{Code: "TD", Name: "Chad"},
+	{Code: "TF", Name: "French Southern Territories"},
+	{Code: "TG", Name: "Togo"},
+	{Code: "TH", Name: "Thailand"},
+	{Code: "TJ", Name: "Tajikistan"},
+	{Code: "TK", Name: "Tokelau"},
+	{Code: "TL", Name: "Timor-Leste"},
+	{Code: "TM", Name: "Turkmenistan"},
+	{Code: "TN", Name: "Tunisia"},
+	{Code: "TO", Name: "Tonga"},
+	{Code: "TR", Name: "Türkiye"},
+	{Code: "TT", Name: "Trinidad and Tobago"},
+	{Code: "TV", Name: "Tuvalu"},
+	{Code: "TW", Name: "Taiwan, Province of China"},
+	{Code: "TZ", Name: "Tanzania, United Republic of"},
+	{Code: "UA", Name: "Ukraine"},
+	{Code: "UG", Name: "Uganda"},
+	{Code: "UM", Name: "United States Minor Outlying Islands"},
+	{Code: "US", Name: "United States"},
+	{Code: "UY", Name: "Uruguay"},
+	{Code: "UZ", Name: "Uzbekistan"},
+	{Code: "VA", Name: "Holy See (Vatican City State)"},
+	{Code: "VC", Name: "Saint Vincent and the Grenadines"},
+	{Code: "VE", Name: "Venezuela, Bolivarian Republic of"},
+	{Code: "VG", Name: "Virgin Islands, British"},
+	{Code: "VI", Name: "Virgin Islands, U.S."},
+	{Code: "VN", Name: "Viet Nam"},
+	{Code: "VU", Name: "Vanuatu"},
+	{Code: "WF", Name: "Wallis and Futuna"},
+	{Code: "WS", Name: "Samoa"},
+	{Code: "YE", Name: "Yemen"},
+	{Code: "YT", Name: "Mayotte"},
+	{Code: "ZA", Name: "South Africa"},
+	{Code: "ZM", Name: "Zambia"},
+	{Code: "ZW", Name: "Zimbabwe"},
+}
+
+// countryAliases maps the common and former names of countries to their codes.
+var countryAliases = map[string]string{
+	"Arab Republic of Egypt":           "EG",
+	"Argentine Republic":               "AR",
+	"Bolivarian Republic of Venezuela": "VE",
+	"Bolivia":                          "BO",
+	"British Virgin Islands":           "VG",
+	"Brunei":                           "BN",
+	"Burma":                            "MM",
+	"Cape Verde":                       "CV",
+	"Commonwealth of Dominica":         "DM",
+	"Commonwealth of the Bahamas":      "BS",
+	"Commonwealth of the Northern Mariana Islands":     "MP",
+	"Congo, Democratic Republic of the":                "CD",
+	"Cote d'Ivoire":                                    "CI",
+	"Curacao":                                          "CW",
+	"Czech Republic":                                   "CZ",
+	"Democratic People's Republic of Korea":            "KP",
+	"Democratic Republic of Sao Tome and Principe":     "ST",
+	"Democratic Republic of Timor-Leste":               "TL",
+	"Democratic Republic of the Congo":                 "CD",
+	"Democratic Socialist Republic of Sri Lanka":       "LK",
+	"East Timor":                                       "TL",
+	"Eastern Republic of Uruguay":                      "UY",
+	"Falkland Islands":                                 "FK",
+	"Federal Democratic Republic of Ethiopia":          "ET",
+	"Federal Democratic Republic of Nepal":             "NP",
+	"Federal Republic of Germany":                      "DE",
+	"Federal Republic of Nigeria":                      "NG",
+	"Federal Republic of Somalia":                      "SO",
+	"Federated States of Micronesia":                   "FM",
+	"Federative Republic of Brazil":                    "BR",
+	"French Republic":                                  "FR",
+	"Gabonese Republic":                                "GA",
+	"Grand Duchy of Luxembourg":                        "LU",
+	"Great Britain":                                    "GB",
+	"Hashemite Kingdom of Jordan":                      "JO",
+	"Hellenic Republic":                                "GR",
+	"Holland":                                          "NL",
+	"Hong Kong Special Administrative Region of China": "HK",
+	"Independent State of Papua New Guinea":            "PG",
+	"Independent State of Samoa":                       "WS",
+	"Iran":                                             "IR",
+	"Islamic Republic of Afghanistan":                  "AF",
+	"Islamic Republic of Iran":                         "IR",
+	"Islamic Republic of Mauritania":                   "MR",
+	"Islamic Republic of Pakistan":                     "PK",
+	"Italian Republic":                                 "IT",
+	"Ivory Coast":                                      "CI",
+	"Kingdom of Bahrain":                               "BH",
+	"Kingdom of Belgium":                               "BE",
+	"Kingdom of Bhutan":                                "BT",
+	"Kingdom of Cambodia":                              "KH",
+	"Kingdom of Denmark":                               "DK",
+	"Kingdom of Eswatini":                              "SZ",
+	"Kingdom of Lesotho":                               "LS",
+	"Kingdom of Morocco":                               "MA",
+	"Kingdom of Norway":                                "NO",
+	"Kingdom of Saudi Arabia":                          "SA",
+	"Kingdom of Spain":                                 "ES",
+	"Kingdom of Sweden":                                "SE",
+	"Kingdom of Thailand":                              "TH",
+	"Kingdom of Tonga":                                 "TO",
+	"Kingdom of the Netherlands":                       "NL",
+	"Kyrgyz Republic":                                  "KG",
+	"Laos":                                             "LA",
+	"Lebanese Republic":                                "LB",
+	"Macao Special Administrative Region of China":     "MO",
+	"Macau":       "MO",
+	"Macedonia":   "MK",
+	"Micronesia":  "FM",
+	"Moldova":     "MD",
+	"North Korea": "KP",
+	"Palestine":   "PS",
+	"People's Democratic Republic of Algeria": "DZ",
+	"People's Republic of Bangladesh":         "BD",
+	"People's Republic of China":              "CN",
+	"Plurinational State of Bolivia":          "BO",
+	"Portuguese Republic":                     "PT",
+	"Principality of Andorra":                 "AD",
+	"Principality of Liechtenstein":           "LI",
+	"Principality of Monaco":                  "MC",
+	"Republic of Albania":                     "AL",
+	"Republic of Angola":                      "AO",
+	"Republic of Armenia":                     "AM",
+	"Republic of Austria":                     "AT",
+	"Republic of Azerbaijan":                  "AZ",
+	"Republic of Belarus":                     "BY",
+	"Republic of Benin":                       "BJ",
+	"Republic of Bosnia and Herzegovina":      "BA",
+	"Republic of Botswana":                    "BW",
+	"Republic of Bulgaria":                    "BG",
+	"Republic of Burundi":                     "BI",
+	"Republic of Cabo Verde":                  "CV",
+	"Republic of Cameroon":                    "CM",
+	"Republic of Chad":                        "TD",
+	"Republic of Chile":                       "CL",
+	"Republic of Colombia":                    "CO",
+	"Republic of Costa Rica":                  "CR",
+	"Republic of Croatia":                     "HR",
+	"Republic of Cuba":                        "CU",
+	"Republic of Cyprus":                      "CY",
+	"Republic of Côte d'Ivoire":               "CI",
+	"Republic of Djibouti":                    "DJ",
+	"Republic of Ecuador":                     "EC",
+	"Republic of El Salvador":                 "SV",
+	"Republic of Equatorial Guinea":           "GQ",
+	"Republic of Estonia":                     "EE",
+	"Republic of Fiji":                        "FJ",
+	"Republic of Finland":                     "FI",
+	"Republic of Ghana":                       "GH",
+	"Republic of Guatemala":                   "GT",
+	"Republic of Guinea":                      "GN",
+	"Republic of Guinea-Bissau":               "GW",
+	"Republic of Guyana":                      "GY",
+	"Republic of Haiti":                       "HT",
+	"Republic of Honduras":                    "HN",
+	"Republic of Iceland":                     "IS",
+	"Republic of India":                       "IN",
+	"Republic of Indonesia":                   "ID",
+	"Republic of Iraq":                        "IQ",
+	"Republic of Kazakhstan":                  "KZ",
+	"Republic of Kenya":                       "KE",
+	"Republic of Kiribati":                    "KI",
+	"Republic of Latvia":                      "LV",
+	"Republic of Liberia":                     "LR",
+	"Republic of Lithuania":                   "LT",
+	"Republic of Madagascar":                  "MG",
+	"Republic of Malawi":                      "MW",
+	"Republic of Maldives":                    "MV",
+	"Republic of Mali":                        "ML",
+	"Republic of Malta":                       "MT",
+	"Republic of Mauritius":                   "MU",
+	"Republic of Moldova":                     "MD",
+	"Republic of Mozambique":                  "MZ",
+	"Republic of Myanmar":                     "MM",
+	"Republic of Namibia":                     "NA",
+	"Republic of Nauru":                       "NR",
+	"Republic of Nicaragua":                   "NI",
+	"Republic of North Macedonia":             "MK",
+	"Republic of Palau":                       "PW",
+	"Republic of Panama":                      "PA",
+	"Republic of Paraguay":                    "PY",
+	"Republic of Peru":                        "PE",
+	"Republic of Poland":                      "PL",
+	"Republic of San Marino":                  "SM",
+	"Republic of Senegal":                     "SN",
+	"Republic of Serbia":                      "RS",
+	"Republic of Seychelles":                  "SC",
+	"Republic of Sierra Leone":                "SL",
+	"Republic of Singapore":                   "SG",
+	"Republic of Slovenia":                    "SI",
+	"Republic of South Africa":                "ZA",
+	"Republic of South Sudan":                 "SS",
+	"Republic of Suriname":                    "SR",
+	"Republic of Tajikistan":                  "TJ",
+	"Republic of Trinidad and Tobago":         "TT",
+	"Republic of Tunisia":                     "TN",
+	"Republic of Türkiye":                     "TR",
+	"Republic of Uganda":                      "UG",
+	"Republic of Uzbekistan":                  "UZ",
+	"Republic of Vanuatu":                     "VU",
+	"Republic of Yemen":                       "YE",
+	"Republic of Zambia":                      "ZM",
+	"Republic of Zimbabwe":                    "ZW",
+	"Republic of the Congo":                   "CG",
+	"Republic of the Gambia":                  "GM",
+	"Republic of the Marshall Islands":        "MH",
+	"Republic of the Niger":                   "NE",
+	"Republic of the Philippines":             "PH",
+	"Republic of the Sudan":                   "SD",
+	"Reunion":                                 "RE",
+	"Russia":                                  "RU",
+	"Rwandese Republic":                       "RW",
+	"Saint Martin":                            "MF",
+	"Sint Maarten":                            "SX",
+	"Slovak Republic":                         "SK",
+	"Socialist Republic of Viet Nam":          "VN",
+	"South Korea":                             "KR",
+	"State of Israel":                         "IL",
+	"State of Kuwait":                         "KW",
+	"State of Qatar":                          "QA",
+	"Sultanate of Oman":                       "OM",
+	"Swaziland":                               "SZ",
+	"Swiss Confederation":                     "CH",
+	"Syria":                                   "SY",
+	"Taiwan":                                  "TW",
+	"Tanzania":                                "TZ",
+	"Togolese Republic":                       "TG",
+	"Turkey":                                  "TR",
+	"UK":                                      "GB",
+	"US Virgin Islands":                       "VI",
+	"USA":                                     "US",
+	"Union of the Comoros":                    "KM",
+	"United Kingdom of Great Britain and Northern Ireland": "GB",
+	"United Mexican States":                                "MX",
+	"United Republic of Tanzania":                          "TZ",
+	"United States of America":                             "US",
+	"Vatican":                                              "VA",
+	"Vatican City":                                         "VA",
+	"Venezuela":                                            "VE",
+	"Vietnam":                                              "VN",
+	"Virgin Islands of the United States":                  "VI",
+	"the State of Eritrea":                                 "ER",
+	"the State of Palestine":                               "PS",
+}
+
+var (
+	countriesByCode = make(map[string]Country, len(countries))
+	countriesByName = make(map[string]Country, len(countries)+len(countryAliases))
+)
+
+func init() {
+	for _, c := range countries {
+		countriesByCode[c.Code] = c
+		countriesByName[countryKey(c.Name)] = c
+	}
+
+	for name, code := range countryAliases {
+		countriesByName[countryKey(name)] = countriesByCode[code]
+	}
+}
+
+// Countries returns all the known countries, sorted by code.
+func Countries() []Country {
+	return append([]Country(nil), countries...)
+}
+
+// CountryByCode returns the country with the given ISO 3166-1 alpha-2 code.
+func CountryByCode(code string) (Country, bool) {
+	c, ok := countriesByCode[strings.ToUpper(strings.TrimSpace(code))]
+	return c, ok
+}
+
+// CountryByName returns the country with the given name, either its ISO
+// 3166-1 name or a common or former one. Names are matched ignoring case and
+// surrounding spaces.
+func CountryByName(name string) (Country, bool) {
+	c, ok := countriesByName[countryKey(name)]
+	return c, ok
+}
+
+func countryKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
@@ -0,0 +1,42 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountryByName(t *testing.T) {
+	tests := map[string]string{
+		"United Arab Emirates":            "AE",
+		"  united   arab emirates ":       "AE",
+		"Bolivia, Plurinational State of": "BO",
+		"South Korea":                     "KR",
+		"Korea, Republic of":              "KR",
+		"Turkey":                          "TR",
+		"Netherlands Antilles":            "AN",
+	}
+
+	for name, code := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, ok := domain.CountryByName(name)
+			assert.True(t, ok)
+			assert.Equal(t, code, c.Code)
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		_, ok := domain.CountryByName("Atlantis")
+		assert.False(t, ok)
+	})
+}
+
+func TestCountryByCode(t *testing.T) {
+	c, ok := domain.CountryByCode("ae")
+	assert.True(t, ok)
+	assert.Equal(t, domain.Country{Code: "AE", Name: "United Arab Emirates"}, c)
+
+	_, ok = domain.CountryByCode("XX")
+	assert.False(t, ok)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldDiff is a difference on a single port field.
+type FieldDiff struct {
+	// Field is the JSON name of the field.
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// Diff returns the differences from p to other, field by field. Nil and
+// empty slices are considered equal, as they have the same JSON encoding.
+// The raw original ports are not compared.
+func (p *Port) Diff(other *Port) []FieldDiff {
+	var diffs []FieldDiff
+
+	pv := reflect.ValueOf(p).Elem()
+	ov := reflect.ValueOf(other).Elem()
+	t := pv.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if field == "" || field == "-" || field == "raw" {
+			continue
+		}
+
+		oldV, newV := pv.Field(i), ov.Field(i)
+
+		if oldV.Kind() == reflect.Slice && oldV.Len() == 0 && newV.Len() == 0 {
+			continue
+		}
+
+		if reflect.DeepEqual(oldV.Interface(), newV.Interface()) {
+			continue
+		}
+
+		diffs = append(diffs, FieldDiff{
+			Field: field,
+			Old:   oldV.Interface(),
+			New:   newV.Interface(),
+		})
+	}
+
+	return diffs
+}
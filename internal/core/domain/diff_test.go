@@ -0,0 +1,38 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPort_Diff(t *testing.T) {
+	old := domain.Port{
+		ID:          "AEAJM",
+		Name:        "Ajman",
+		Alias:       []string{},
+		Coordinates: []float64{55.5136433, 25.4052165},
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		other := old
+		other.Alias = nil
+
+		assert.Empty(t, old.Diff(&other))
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		other := old
+		other.Name = "Ajman Port"
+		other.Coordinates = []float64{55.51, 25.40}
+
+		assert.Equal(t,
+			[]domain.FieldDiff{
+				{Field: "name", Old: "Ajman", New: "Ajman Port"},
+				{Field: "coordinates", Old: domain.Coordinates{55.5136433, 25.4052165}, New: domain.Coordinates{55.51, 25.40}},
+			},
+			old.Diff(&other),
+		)
+	})
+}
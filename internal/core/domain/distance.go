@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// DistanceHaversine computes great-circle distances on a sphere, which
+	// are up to 0.5% off.
+	DistanceHaversine DistanceMethod = "haversine"
+	// DistanceVincenty computes distances on the WGS 84 ellipsoid.
+	DistanceVincenty DistanceMethod = "vincenty"
+)
+
+type (
+	// DistanceMethod is a way of computing the distance between two points.
+	DistanceMethod string
+
+	// DistanceMatrixRequest asks for the distances between each origin port
+	// and each destination port.
+	DistanceMatrixRequest struct {
+		Origins      []string       `json:"origins"`
+		Destinations []string       `json:"destinations"`
+		Method       DistanceMethod `json:"method,omitempty"`
+	}
+
+	// DistanceMatrix holds the distances between each origin port and each
+	// destination port: Distances[i][j] is the distance from Origins[i] to
+	// Destinations[j].
+	DistanceMatrix struct {
+		Origins      []string       `json:"origins"`
+		Destinations []string       `json:"destinations"`
+		Method       DistanceMethod `json:"method"`
+		Distances    [][]Distance   `json:"distances"`
+	}
+
+	// Distance is a distance in kilometres and in nautical miles.
+	Distance struct {
+		Km float64 `json:"km"`
+		NM float64 `json:"nm"`
+	}
+)
+
+// ParseDistanceMethod parses a distance method, an empty one being the
+// haversine one.
+func ParseDistanceMethod(s string) (DistanceMethod, error) {
+	switch m := DistanceMethod(strings.ToLower(s)); m {
+	case "":
+		return DistanceHaversine, nil
+	case DistanceHaversine, DistanceVincenty:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown distance method '%s'", s)
+	}
+}
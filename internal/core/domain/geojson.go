@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// GeoJSONFeature is the GeoJSON type of a feature.
+	GeoJSONFeature = "Feature"
+	// GeoJSONFeatureCollection is the GeoJSON type of a collection of features.
+	GeoJSONFeatureCollection = "FeatureCollection"
+	// GeoJSONPoint is the GeoJSON type of a point geometry.
+	GeoJSONPoint = "Point"
+)
+
+type (
+	// Feature is a GeoJSON feature. Ports are point features whose
+	// properties are their other fields.
+	Feature struct {
+		Type       string          `json:"type"`
+		ID         any             `json:"id,omitempty"`
+		Geometry   *Geometry       `json:"geometry"`
+		Properties json.RawMessage `json:"properties"`
+	}
+
+	// Geometry is a GeoJSON geometry. Only points are supported, but the
+	// coordinates of any geometry are read, for it to be reported.
+	Geometry struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+)
+
+// Feature returns the port as a GeoJSON point feature, whose geometry is
+// null when the port has no coordinates.
+func (p *Port) Feature() (*Feature, error) {
+	props := *p
+	props.Coordinates = nil
+
+	b, err := json.Marshal(&props)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Feature{
+		Type:       GeoJSONFeature,
+		ID:         p.ID,
+		Properties: b,
+	}
+
+	if len(p.Coordinates) > 0 {
+		coordinates, err := json.Marshal(p.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+
+		f.Geometry = &Geometry{
+			Type:        GeoJSONPoint,
+			Coordinates: coordinates,
+		}
+	}
+
+	return f, nil
+}
+
+// Port returns the port of a GeoJSON point feature. The ID is taken from
+// the feature, when set, or else from its properties.
+func (f *Feature) Port() (*Port, error) {
+	if f.Type != GeoJSONFeature {
+		return nil, fmt.Errorf("unexpected GeoJSON type '%s', want '%s'", f.Type, GeoJSONFeature)
+	}
+
+	var p Port
+
+	if len(f.Properties) > 0 {
+		if err := json.Unmarshal(f.Properties, &p); err != nil {
+			return nil, fmt.Errorf("properties: %w", err)
+		}
+	}
+
+	switch id := f.ID.(type) {
+	case nil:
+	case string:
+		p.ID = id
+	case float64:
+		p.ID = strconv.FormatFloat(id, 'f', -1, 64)
+	default:
+		return nil, fmt.Errorf("unexpected feature id type '%T'", id)
+	}
+
+	if p.ID == "" {
+		return nil, errors.New("missing feature id")
+	}
+
+	if f.Geometry != nil {
+		if f.Geometry.Type != GeoJSONPoint {
+			return nil, fmt.Errorf("unsupported geometry type '%s', want '%s'", f.Geometry.Type, GeoJSONPoint)
+		}
+
+		if err := json.Unmarshal(f.Geometry.Coordinates, &p.Coordinates); err != nil {
+			return nil, fmt.Errorf("geometry: %w", err)
+		}
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,96 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPort_Feature(t *testing.T) {
+	p := domain.Port{
+		ID:          "AEAJM",
+		Name:        "Ajman",
+		Coordinates: domain.Coordinates{55.5136433, 25.4052165},
+		Unlocs:      []string{"AEAJM"},
+	}
+
+	f, err := p.Feature()
+	require.NoError(t, err)
+
+	b, err := json.Marshal(f)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "Feature",
+		"id": "AEAJM",
+		"geometry": {"type": "Point", "coordinates": [55.5136433, 25.4052165]},
+		"properties": {
+			"id": "AEAJM", "name": "Ajman", "city": "", "country": "", "province": "",
+			"timezone": "", "code": "", "unlocs": ["AEAJM"]
+		}
+	}`, string(b))
+
+	var decoded domain.Feature
+	require.NoError(t, json.Unmarshal(b, &decoded))
+
+	back, err := decoded.Port()
+	require.NoError(t, err)
+	assert.Equal(t, p, *back)
+
+	f, err = (&domain.Port{ID: "AEAUH"}).Feature()
+	require.NoError(t, err)
+	assert.Nil(t, f.Geometry)
+}
+
+func TestFeature_Port(t *testing.T) {
+	tcs := []struct {
+		name        string
+		input       string
+		expected    domain.Port
+		expectedErr string
+	}{
+		{
+			name:     "numeric id",
+			input:    `{"type": "Feature", "id": 42, "geometry": null, "properties": {"name": "Ajman"}}`,
+			expected: domain.Port{ID: "42", Name: "Ajman"},
+		},
+		{
+			name:     "id from properties",
+			input:    `{"type": "Feature", "geometry": null, "properties": {"id": "AEAJM"}}`,
+			expected: domain.Port{ID: "AEAJM"},
+		},
+		{
+			name:        "missing id",
+			input:       `{"type": "Feature", "geometry": null, "properties": null}`,
+			expectedErr: "missing feature id",
+		},
+		{
+			name:        "not a feature",
+			input:       `{"type": "FeatureCollection"}`,
+			expectedErr: "unexpected GeoJSON type 'FeatureCollection', want 'Feature'",
+		},
+		{
+			name:        "line",
+			input:       `{"type": "Feature", "id": "AEAJM", "geometry": {"type": "LineString", "coordinates": [[1, 2], [3, 4]]}}`,
+			expectedErr: "unsupported geometry type 'LineString', want 'Point'",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var f domain.Feature
+			require.NoError(t, json.Unmarshal([]byte(tc.input), &f))
+
+			p, err := f.Port()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, *p)
+		})
+	}
+}
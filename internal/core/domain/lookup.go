@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"strings"
+)
+
+// PortKey is a secondary key ports are looked up by, besides their ID.
+// Unlike IDs, several ports may claim the same key value.
+type PortKey string
+
+const (
+	// PortKeyUnloc looks ports up by any of their UN/LOCODEs.
+	PortKeyUnloc PortKey = "unloc"
+	// PortKeyCode looks ports up by their customs code.
+	PortKeyCode PortKey = "code"
+	// PortKeyAlias looks ports up by any of their aliases, ignoring case.
+	PortKeyAlias PortKey = "alias"
+)
+
+// PortKeys returns all the secondary keys, in the order ports are resolved
+// by.
+func PortKeys() []PortKey {
+	return []PortKey{PortKeyUnloc, PortKeyCode, PortKeyAlias}
+}
+
+// Normalize normalises a value of the key, so that values written in
+// different ways, like "ae ajm" and "AEAJM", are looked up the same.
+func (k PortKey) Normalize(value string) string {
+	switch k {
+	case PortKeyUnloc:
+		return strings.ToUpper(strings.Join(strings.Fields(value), ""))
+	case PortKeyAlias:
+		return strings.ToLower(normalizeText(value))
+	default:
+		return strings.TrimSpace(value)
+	}
+}
+
+// Keys returns the normalised values of the key claimed by the port,
+// without duplicates.
+func (p *Port) Keys(k PortKey) []string {
+	var values []string
+
+	switch k {
+	case PortKeyUnloc:
+		values = p.Unlocs
+	case PortKeyCode:
+		values = []string{p.Code}
+	case PortKeyAlias:
+		values = p.Alias
+	}
+
+	keys := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+
+	for _, v := range values {
+		v = k.Normalize(v)
+		if v != "" && !seen[v] {
+			seen[v] = true
+			keys = append(keys, v)
+		}
+	}
+
+	return keys
+}
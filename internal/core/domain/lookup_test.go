@@ -0,0 +1,29 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortKey_Normalize(t *testing.T) {
+	assert.Equal(t, "AEAJM", domain.PortKeyUnloc.Normalize(" ae ajm "))
+	assert.Equal(t, "52000", domain.PortKeyCode.Normalize(" 52000\n"))
+	assert.Equal(t, "long beach", domain.PortKeyAlias.Normalize("  Long   BEACH "))
+}
+
+func TestPort_Keys(t *testing.T) {
+	p := domain.Port{
+		ID:     "USLGB",
+		Unlocs: []string{"USLGB", "us lgb", "USLAX"},
+		Code:   "2709",
+		Alias:  []string{"Long Beach", "long beach", " "},
+	}
+
+	assert.Equal(t, []string{"USLGB", "USLAX"}, p.Keys(domain.PortKeyUnloc))
+	assert.Equal(t, []string{"2709"}, p.Keys(domain.PortKeyCode))
+	assert.Equal(t, []string{"long beach"}, p.Keys(domain.PortKeyAlias))
+
+	assert.Empty(t, (&domain.Port{ID: "XXXXX"}).Keys(domain.PortKeyCode))
+}
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxRepairs bounds the number of times a text is decoded again, for texts
+// which were wrongly encoded several times.
+const maxRepairs = 3
+
+// maxAbbreviation is the number of letters up to which all upper case texts
+// are taken as abbreviations.
+const maxAbbreviation = 3
+
+// cp1252 maps the Windows-1252 characters which are not Latin-1 ones to
+// their byte, as UTF-8 text is often wrongly decoded as Windows-1252.
+var cp1252 = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// Normalize normalises the port text fields, returning whether any of them
+// changed, a derived country code aside:
+//   - texts are repaired from UTF-8 wrongly decoded as Latin-1 ("SÃ£o Paulo"),
+//     put in Unicode NFC and their spaces trimmed and collapsed;
+//   - all upper or lower case names, cities and provinces are title cased;
+//   - the country is set to its ISO 3166 name, when known, and the missing
+//     country code is derived from the UN/LOCODEs, or the country name;
+//   - aliases and regions are deduplicated, ignoring case, and sorted;
+//   - UN/LOCODEs are upper cased, deduplicated and sorted.
+//
+// The ID is kept as is.
+func (p *Port) Normalize() bool {
+	before := *p
+
+	p.Name = fixCase(normalizeText(p.Name))
+	p.City = fixCase(normalizeText(p.City))
+	p.Province = fixCase(normalizeText(p.Province))
+	p.Country = normalizeText(p.Country)
+	p.Timezone = normalizeText(p.Timezone)
+	p.Code = normalizeText(p.Code)
+
+	if c, ok := CountryByName(p.Country); ok {
+		p.Country = c.Name
+	}
+
+	p.CountryCode = strings.ToUpper(normalizeText(p.CountryCode))
+
+	p.Alias = normalizeList(p.Alias, normalizeText)
+	p.Regions = normalizeList(p.Regions, normalizeText)
+	p.Unlocs = normalizeList(p.Unlocs, func(s string) string {
+		return strings.ToUpper(normalizeText(s))
+	})
+
+	changed := len(before.Diff(p)) > 0
+
+	if p.CountryCode == "" {
+		p.CountryCode = p.deriveCountryCode()
+	}
+
+	return changed
+}
+
+// deriveCountryCode returns the country code from the prefix of the first
+// valid UN/LOCODE, or from the country name, or an empty string.
+func (p *Port) deriveCountryCode() string {
+	for _, u := range p.Unlocs {
+		if !unlocPattern.MatchString(u) {
+			continue
+		}
+
+		if c, ok := CountryByCode(u[:2]); ok {
+			return c.Code
+		}
+	}
+
+	if c, ok := CountryByName(p.Country); ok {
+		return c.Code
+	}
+
+	return ""
+}
+
+// normalizeText repairs, puts in NFC and trims a text, collapsing its spaces.
+func normalizeText(s string) string {
+	s = norm.NFC.String(repairEncoding(s))
+
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// normalizeList normalises the items of a list, dropping the empty and the
+// duplicated ones, ignoring case, and sorting them. Empty lists are kept as
+// they were, either nil or empty.
+func normalizeList(items []string, normalize func(string) string) []string {
+	if len(items) == 0 {
+		return items
+	}
+
+	seen := make(map[string]bool, len(items))
+	list := make([]string, 0, len(items))
+
+	for _, item := range items {
+		item = normalize(item)
+
+		key := strings.ToLower(item)
+		if item == "" || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		list = append(list, item)
+	}
+
+	sort.Strings(list)
+
+	return list
+}
+
+// repairEncoding decodes again UTF-8 text which was wrongly decoded as
+// Latin-1, or Windows-1252, like "SÃ£o Paulo". Texts which can't be
+// decoded again into valid UTF-8 are returned as they are.
+func repairEncoding(s string) string {
+	for n := 0; n < maxRepairs && doubleEncoded(s); n++ {
+		b := make([]byte, 0, len(s))
+
+		for _, r := range s {
+			switch c, ok := cp1252[r]; {
+			case ok:
+				b = append(b, c)
+			case r < 0x100:
+				b = append(b, byte(r))
+			default:
+				return s
+			}
+		}
+
+		if !utf8.Valid(b) {
+			return s
+		}
+
+		s = string(b)
+	}
+
+	return s
+}
+
+// doubleEncoded reports whether a text holds a Latin-1 character which
+// starts a UTF-8 sequence followed by one which continues it, like "Ã£".
+func doubleEncoded(s string) bool {
+	var prev rune
+
+	for _, r := range s {
+		if prev >= 0xc2 && prev <= 0xf4 && isContinuation(r) {
+			return true
+		}
+
+		prev = r
+	}
+
+	return false
+}
+
+// isContinuation reports whether a Latin-1, or Windows-1252, character is
+// a UTF-8 continuation byte.
+func isContinuation(r rune) bool {
+	_, ok := cp1252[r]
+
+	return ok || r >= 0x80 && r <= 0xbf
+}
+
+// fixCase title cases texts which are all upper or lower case, like
+// "SANTOS", leaving mixed case texts and abbreviations, like "NY", as they
+// are.
+func fixCase(s string) string {
+	hasUpper, hasLower, letters := false, false, 0
+
+	for _, r := range s {
+		hasUpper = hasUpper || unicode.IsUpper(r)
+		hasLower = hasLower || unicode.IsLower(r)
+
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+
+	if hasUpper == hasLower || letters <= maxAbbreviation {
+		return s
+	}
+
+	words := strings.Fields(strings.ToLower(s))
+	for idx, w := range words {
+		r, size := utf8.DecodeRuneInString(w)
+		words[idx] = string(unicode.ToTitle(r)) + w[size:]
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,153 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPort_Normalize(t *testing.T) {
+	tests := map[string]struct {
+		port     domain.Port
+		expected domain.Port
+		changed  bool
+	}{
+		"already normalised": {
+			port: domain.Port{
+				ID:          "AEAJM",
+				Name:        "Ajman",
+				Country:     "United Arab Emirates",
+				CountryCode: "AE",
+				Province:    "Abu Z¸aby [Abu Dhabi]",
+				Alias:       []string{},
+				Unlocs:      []string{"AEAJM"},
+			},
+			expected: domain.Port{
+				ID:          "AEAJM",
+				Name:        "Ajman",
+				Country:     "United Arab Emirates",
+				CountryCode: "AE",
+				Province:    "Abu Z¸aby [Abu Dhabi]",
+				Alias:       []string{},
+				Unlocs:      []string{"AEAJM"},
+			},
+		},
+		"whitespace": {
+			port: domain.Port{
+				Name:     "  Port \t of Spain ",
+				City:     "Port of Spain\n",
+				Timezone: " America/Port_of_Spain",
+				Code:     "27400 ",
+			},
+			expected: domain.Port{
+				Name:     "Port of Spain",
+				City:     "Port of Spain",
+				Timezone: "America/Port_of_Spain",
+				Code:     "27400",
+			},
+			changed: true,
+		},
+		"double encoding": {
+			port: domain.Port{
+				Name:     "SÃ£o Paulo",
+				City:     "MaceiÃ³",
+				Province: "CearÃ¡",
+				Alias:    []string{"Ã‰vora"},
+			},
+			expected: domain.Port{
+				Name:     "São Paulo",
+				City:     "Maceió",
+				Province: "Ceará",
+				Alias:    []string{"Évora"},
+			},
+			changed: true,
+		},
+		"nfc": {
+			port: domain.Port{
+				Name: "Sa\u0303o Tome\u0301",
+			},
+			expected: domain.Port{
+				Name: "São Tomé",
+			},
+			changed: true,
+		},
+		"casing": {
+			port: domain.Port{
+				Name:     "SANTOS",
+				City:     "são paulo",
+				Province: "SP",
+			},
+			expected: domain.Port{
+				Name:     "Santos",
+				City:     "São Paulo",
+				Province: "SP",
+			},
+			changed: true,
+		},
+		"lists": {
+			port: domain.Port{
+				Alias:   []string{"Jebel Ali", " jebel ali", "", "Dubai"},
+				Regions: []string{"Gulf", "Gulf"},
+				Unlocs:  []string{"aejea", "AEDXB", "AEJEA"},
+			},
+			expected: domain.Port{
+				CountryCode: "AE",
+				Alias:       []string{"Dubai", "Jebel Ali"},
+				Regions:     []string{"Gulf"},
+				Unlocs:      []string{"AEDXB", "AEJEA"},
+			},
+			changed: true,
+		},
+		"country": {
+			port: domain.Port{
+				Country: "south korea",
+			},
+			expected: domain.Port{
+				Country:     "Korea, Republic of",
+				CountryCode: "KR",
+			},
+			changed: true,
+		},
+		"country code from unlocs": {
+			port: domain.Port{
+				Country: "Netherlands",
+				Unlocs:  []string{"invalid", "ANEUX"},
+			},
+			expected: domain.Port{
+				Country:     "Netherlands",
+				CountryCode: "AN",
+				Unlocs:      []string{"ANEUX", "INVALID"},
+			},
+			changed: true,
+		},
+		"country code given": {
+			port: domain.Port{
+				Country:     "Brazil",
+				CountryCode: " br",
+			},
+			expected: domain.Port{
+				Country:     "Brazil",
+				CountryCode: "BR",
+			},
+			changed: true,
+		},
+		"unknown country": {
+			port: domain.Port{
+				Country: " Atlantis ",
+			},
+			expected: domain.Port{
+				Country: "Atlantis",
+			},
+			changed: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			changed := tc.port.Normalize()
+			assert.Equal(t, tc.changed, changed)
+			assert.Equal(t, tc.expected, tc.port)
+		})
+	}
+}
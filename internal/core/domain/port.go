@@ -7,17 +7,21 @@ import (
 type (
 	// Port is a struct representing the data structure of each port.
 	Port struct {
-		ID          string    `json:"id"`
-		Name        string    `json:"name"`
-		City        string    `json:"city"`
-		Country     string    `json:"country"`
-		Alias       []string  `json:"alias,omitempty"`
-		Regions     []string  `json:"regions,omitempty"`
-		Coordinates []float64 `json:"coordinates,omitempty"`
-		Province    string    `json:"province"`
-		Timezone    string    `json:"timezone"`
-		Unlocs      []string  `json:"unlocs,omitempty"`
-		Code        string    `json:"code"`
+		ID          string      `json:"id"`
+		Name        string      `json:"name"`
+		City        string      `json:"city"`
+		Country     string      `json:"country"`
+		CountryCode string      `json:"countryCode,omitempty"`
+		Alias       []string    `json:"alias,omitempty"`
+		Regions     []string    `json:"regions,omitempty"`
+		Coordinates Coordinates `json:"coordinates,omitempty"`
+		Province    string      `json:"province"`
+		Timezone    string      `json:"timezone"`
+		Unlocs      []string    `json:"unlocs,omitempty"`
+		Code        string      `json:"code"`
+		// Raw holds the port as it was written, before being normalised,
+		// when set to be kept.
+		Raw *Port `json:"raw,omitempty"`
 	}
 
 	Ports []Port
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type (
+	// Region is an entry of the region catalogue, which ports reference by
+	// ID. Regions are nested under their parent, if any.
+	Region struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Parent string `json:"parent,omitempty"`
+	}
+
+	Regions []Region
+)
+
+// Validate checks the region catalogue: every region must have an unique ID
+// and a name, and its parent must be another region of the catalogue, without
+// cycles.
+func (regions Regions) Validate() error {
+	parents := make(map[string]string, len(regions))
+
+	for idx, r := range regions {
+		if strings.TrimSpace(r.ID) == "" {
+			return fmt.Errorf("region %d: missing id", idx)
+		}
+
+		if _, ok := parents[r.ID]; ok {
+			return fmt.Errorf("region '%s': duplicated id", r.ID)
+		}
+
+		if strings.TrimSpace(r.Name) == "" {
+			return fmt.Errorf("region '%s': missing name", r.ID)
+		}
+
+		parents[r.ID] = r.Parent
+	}
+
+	for _, r := range regions {
+		if r.Parent == "" {
+			continue
+		}
+
+		if _, ok := parents[r.Parent]; !ok {
+			return fmt.Errorf("region '%s': unknown parent '%s'", r.ID, r.Parent)
+		}
+
+		// walking up from a region must reach a root, within as many steps as
+		// there are regions
+		id := r.ID
+		for steps := 0; id != ""; steps++ {
+			if steps > len(regions) {
+				return fmt.Errorf("region '%s': cyclic parents", r.ID)
+			}
+
+			id = parents[id]
+		}
+	}
+
+	return nil
+}
+
+// Get returns the region with the given ID.
+func (regions Regions) Get(id string) (Region, bool) {
+	for _, r := range regions {
+		if r.ID == id {
+			return r, true
+		}
+	}
+
+	return Region{}, false
+}
+
+// Descendants returns the IDs of the region with the given ID and of all the
+// regions nested under it, sorted.
+func (regions Regions) Descendants(id string) []string {
+	children := make(map[string][]string, len(regions))
+	for _, r := range regions {
+		if r.Parent != "" {
+			children[r.Parent] = append(children[r.Parent], r.ID)
+		}
+	}
+
+	ids := []string{id}
+	for idx := 0; idx < len(ids); idx++ {
+		ids = append(ids, children[ids[idx]]...)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// Sort sorts the regions by ID.
+func (regions Regions) Sort() {
+	sort.Slice(regions, func(i, j int) bool {
+		return regions[i].ID < regions[j].ID
+	})
+}
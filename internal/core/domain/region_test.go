@@ -0,0 +1,68 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var regionsTest = domain.Regions{
+	{ID: "asia", Name: "Asia"},
+	{ID: "middle-east", Name: "Middle East", Parent: "asia"},
+	{ID: "gulf", Name: "Persian Gulf", Parent: "middle-east"},
+	{ID: "red-sea", Name: "Red Sea", Parent: "middle-east"},
+	{ID: "europe", Name: "Europe"},
+}
+
+func TestRegions_Validate(t *testing.T) {
+	assert.NoError(t, regionsTest.Validate())
+	assert.NoError(t, domain.Regions{}.Validate())
+
+	tests := map[string]struct {
+		regions domain.Regions
+		err     string
+	}{
+		"missing id": {
+			regions: domain.Regions{{Name: "Asia"}},
+			err:     "region 0: missing id",
+		},
+		"duplicated id": {
+			regions: domain.Regions{{ID: "asia", Name: "Asia"}, {ID: "asia", Name: "Asia"}},
+			err:     "region 'asia': duplicated id",
+		},
+		"missing name": {
+			regions: domain.Regions{{ID: "asia", Name: " "}},
+			err:     "region 'asia': missing name",
+		},
+		"unknown parent": {
+			regions: domain.Regions{{ID: "gulf", Name: "Gulf", Parent: "asia"}},
+			err:     "region 'gulf': unknown parent 'asia'",
+		},
+		"own parent": {
+			regions: domain.Regions{{ID: "asia", Name: "Asia", Parent: "asia"}},
+			err:     "region 'asia': cyclic parents",
+		},
+		"cyclic parents": {
+			regions: domain.Regions{
+				{ID: "a", Name: "A", Parent: "c"},
+				{ID: "b", Name: "B", Parent: "a"},
+				{ID: "c", Name: "C", Parent: "b"},
+			},
+			err: "region 'a': cyclic parents",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.EqualError(t, tc.regions.Validate(), tc.err)
+		})
+	}
+}
+
+func TestRegions_Descendants(t *testing.T) {
+	assert.Equal(t, []string{"asia", "gulf", "middle-east", "red-sea"}, regionsTest.Descendants("asia"))
+	assert.Equal(t, []string{"gulf", "middle-east", "red-sea"}, regionsTest.Descendants("middle-east"))
+	assert.Equal(t, []string{"gulf"}, regionsTest.Descendants("gulf"))
+	assert.Equal(t, []string{"europe"}, regionsTest.Descendants("europe"))
+}
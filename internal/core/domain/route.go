@@ -0,0 +1,32 @@
+package domain
+
+import "encoding/json"
+
+// GeoJSONLineString is the GeoJSON type of a line geometry.
+const GeoJSONLineString = "LineString"
+
+// Route is a sea route between two ports.
+type Route struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// DistanceNM is the length of the route, in nautical miles.
+	DistanceNM float64 `json:"distanceNm"`
+	// Waypoints holds the IDs of the shipping lanes waypoints sailed through.
+	Waypoints []string `json:"waypoints"`
+	// Geometry is the route as a GeoJSON line string, from the first port to
+	// the second one.
+	Geometry *Geometry `json:"geometry"`
+}
+
+// NewLineString returns a GeoJSON line string through the given points.
+func NewLineString(points []Coordinates) (*Geometry, error) {
+	coordinates, err := json.Marshal(points)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Geometry{
+		Type:        GeoJSONLineString,
+		Coordinates: coordinates,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+)
+
+type (
+	// PortCounts holds the number of ports of a group of ports, along with
+	// the last time the group was updated.
+	PortCounts struct {
+		Ports              int        `json:"ports"`
+		MissingCoordinates int        `json:"missingCoordinates"`
+		UpdatedAt          *time.Time `json:"updatedAt,omitempty"`
+	}
+
+	// CountryStats are the counts of the ports of a country, by its
+	// ISO 3166-1 alpha-2 code.
+	CountryStats struct {
+		Country string `json:"country"`
+		PortCounts
+	}
+
+	// RegionStats are the counts of the ports of a region.
+	RegionStats struct {
+		Region string `json:"region"`
+		PortCounts
+	}
+
+	// TimezoneStats are the counts of the ports of a timezone.
+	TimezoneStats struct {
+		Timezone string `json:"timezone"`
+		PortCounts
+	}
+
+	// Stats are the counts of all the ports, along with the ones by country,
+	// region and timezone, each sorted by its key. Ports without a country,
+	// region or timezone are only counted on the totals.
+	Stats struct {
+		PortCounts
+		Countries []CountryStats
+		Regions   []RegionStats
+		Timezones []TimezoneStats
+	}
+
+	// StatsSummary are the counts of all the ports, along with the number of
+	// countries, regions and timezones they are at.
+	StatsSummary struct {
+		PortCounts
+		Countries int `json:"countries"`
+		Regions   int `json:"regions"`
+		Timezones int `json:"timezones"`
+	}
+)
+
+// Summary returns the totals of the stats.
+func (s *Stats) Summary() StatsSummary {
+	return StatsSummary{
+		PortCounts: s.PortCounts,
+		Countries:  len(s.Countries),
+		Regions:    len(s.Regions),
+		Timezones:  len(s.Timezones),
+	}
+}
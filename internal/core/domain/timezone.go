@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	// timezones are loaded from the embedded database, so that they don't
+	// depend on the host one.
+	_ "time/tzdata"
+)
+
+const (
+	// BusinessHoursStart is the local hour business hours start at, on
+	// weekdays.
+	BusinessHoursStart = 9
+	// BusinessHoursEnd is the local hour business hours end at, on weekdays.
+	BusinessHoursEnd = 17
+)
+
+// utcOffsetPattern matches a UTC offset, like +04:00, -0330 or +4.
+var utcOffsetPattern = regexp.MustCompile(`^([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+// locations caches the loaded timezones, by name.
+var locations sync.Map
+
+type (
+	// LocalTime is the current time at a timezone.
+	LocalTime struct {
+		// UTCOffset is the offset from UTC, like +04:00.
+		UTCOffset string `json:"utcOffset"`
+		// DST reports whether daylight saving time is in effect.
+		DST bool `json:"dst"`
+		// LocalTime is the local time, in RFC 3339.
+		LocalTime string `json:"localTime"`
+		// BusinessHours reports whether it is between 9:00 and 17:00 on a
+		// weekday.
+		BusinessHours bool `json:"businessHours"`
+	}
+
+	// TimezoneSummary is a timezone along with its current time and its
+	// number of ports.
+	TimezoneSummary struct {
+		Timezone string `json:"timezone"`
+		LocalTime
+		Ports int `json:"ports"`
+	}
+)
+
+// LoadTimezone returns the timezone with the given IANA name, like
+// Asia/Dubai, from the embedded tz database.
+func LoadTimezone(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	// the host timezone is not a port one, and empty names are UTC ones
+	if strings.TrimSpace(name) == "" || name == "Local" {
+		return nil, fmt.Errorf("unknown timezone '%s'", name)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone '%s'", name)
+	}
+
+	locations.Store(name, loc)
+
+	return loc, nil
+}
+
+// LocalTimeAt returns the time t at the port timezone, or false when the
+// timezone is missing or unknown.
+func (p *Port) LocalTimeAt(t time.Time) (LocalTime, bool) {
+	loc, err := LoadTimezone(p.Timezone)
+	if err != nil {
+		return LocalTime{}, false
+	}
+
+	return NewLocalTime(t.In(loc)), true
+}
+
+// NewLocalTime returns the local time of t, at its location.
+func NewLocalTime(t time.Time) LocalTime {
+	_, offset := t.Zone()
+	weekday := t.Weekday()
+
+	return LocalTime{
+		UTCOffset: FormatUTCOffset(offset),
+		DST:       t.IsDST(),
+		LocalTime: t.Format(time.RFC3339),
+		BusinessHours: weekday != time.Saturday && weekday != time.Sunday &&
+			t.Hour() >= BusinessHoursStart && t.Hour() < BusinessHoursEnd,
+	}
+}
+
+// FormatUTCOffset formats an offset from UTC, in seconds, like +04:00.
+func FormatUTCOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+
+	return fmt.Sprintf("%c%02d:%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// ParseUTCOffset parses an offset from UTC, like +04:00, -0330, +4 or Z,
+// into seconds. A leading space is read as a plus sign, for offsets given
+// on an URL query without escaping it.
+func ParseUTCOffset(s string) (int, error) {
+	if strings.HasPrefix(s, " ") {
+		s = "+" + strings.TrimLeft(s, " ")
+	}
+
+	if s == "Z" {
+		return 0, nil
+	}
+
+	m := utcOffsetPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("invalid UTC offset '%s'", s)
+	}
+
+	hours, _ := strconv.Atoi(m[2])
+	minutes := 0
+
+	if m[3] != "" {
+		minutes, _ = strconv.Atoi(m[3])
+	}
+
+	if hours > 14 || minutes > 59 {
+		return 0, fmt.Errorf("UTC offset '%s' out of range", s)
+	}
+
+	offset := hours*3600 + minutes*60
+	if m[1] == "-" {
+		offset = -offset
+	}
+
+	return offset, nil
+}
+
+// AtUTCOffset returns the ports whose timezone is at the given offset from
+// UTC, in seconds, at time t.
+func (ports Ports) AtUTCOffset(offset int, t time.Time) Ports {
+	filtered := Ports{}
+
+	for idx := range ports {
+		loc, err := LoadTimezone(ports[idx].Timezone)
+		if err != nil {
+			continue
+		}
+
+		if _, o := t.In(loc).Zone(); o == offset {
+			filtered = append(filtered, ports[idx])
+		}
+	}
+
+	return filtered
+}
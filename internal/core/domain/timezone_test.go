@@ -0,0 +1,157 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	loc, err := domain.LoadTimezone("Asia/Dubai")
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Dubai", loc.String())
+
+	for _, name := range []string{"", " ", "Local", "Asia/Ajman", "../etc/passwd"} {
+		_, err := domain.LoadTimezone(name)
+		assert.EqualError(t, err, "unknown timezone '"+name+"'")
+	}
+}
+
+func TestParseUTCOffset(t *testing.T) {
+	tests := map[string]struct {
+		offset int
+		err    string
+	}{
+		"+04:00":  {offset: 4 * 3600},
+		"+0400":   {offset: 4 * 3600},
+		"+4":      {offset: 4 * 3600},
+		" 04:00":  {offset: 4 * 3600},
+		"-03:30":  {offset: -(3*3600 + 30*60)},
+		"+05:45":  {offset: 5*3600 + 45*60},
+		"+00:00":  {},
+		"Z":       {},
+		"04:00":   {err: "invalid UTC offset '04:00'"},
+		"+4:5":    {err: "invalid UTC offset '+4:5'"},
+		"UTC+4":   {err: "invalid UTC offset 'UTC+4'"},
+		"+15:00":  {err: "UTC offset '+15:00' out of range"},
+		"+04:60":  {err: "UTC offset '+04:60' out of range"},
+		"":        {err: "invalid UTC offset ''"},
+		"+04:00x": {err: "invalid UTC offset '+04:00x'"},
+	}
+
+	for s, tc := range tests {
+		t.Run(s, func(t *testing.T) {
+			offset, err := domain.ParseUTCOffset(s)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.offset, offset)
+		})
+	}
+}
+
+func TestFormatUTCOffset(t *testing.T) {
+	assert.Equal(t, "+04:00", domain.FormatUTCOffset(4*3600))
+	assert.Equal(t, "-03:30", domain.FormatUTCOffset(-(3*3600 + 30*60)))
+	assert.Equal(t, "+00:00", domain.FormatUTCOffset(0))
+}
+
+func TestPort_LocalTimeAt(t *testing.T) {
+	// a Monday
+	monday := time.Date(2026, time.January, 5, 6, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		timezone string
+		t        time.Time
+		expected domain.LocalTime
+		ok       bool
+	}{
+		"business hours": {
+			timezone: "Asia/Dubai",
+			t:        monday,
+			expected: domain.LocalTime{
+				UTCOffset:     "+04:00",
+				LocalTime:     "2026-01-05T10:30:00+04:00",
+				BusinessHours: true,
+			},
+			ok: true,
+		},
+		"after business hours": {
+			timezone: "Asia/Dubai",
+			t:        monday.Add(7 * time.Hour),
+			expected: domain.LocalTime{
+				UTCOffset: "+04:00",
+				LocalTime: "2026-01-05T17:30:00+04:00",
+			},
+			ok: true,
+		},
+		"weekend": {
+			timezone: "America/Sao_Paulo",
+			t:        monday.Add(-24 * time.Hour),
+			expected: domain.LocalTime{
+				UTCOffset: "-03:00",
+				LocalTime: "2026-01-04T03:30:00-03:00",
+			},
+			ok: true,
+		},
+		"daylight saving time": {
+			timezone: "Europe/Amsterdam",
+			t:        monday.AddDate(0, 6, 0),
+			expected: domain.LocalTime{
+				UTCOffset:     "+02:00",
+				DST:           true,
+				LocalTime:     "2026-07-05T08:30:00+02:00",
+				BusinessHours: false,
+			},
+			ok: true,
+		},
+		"missing timezone": {},
+		"unknown timezone": {
+			timezone: "Asia/Ajman",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := domain.Port{ID: "AEAJM", Timezone: tc.timezone}
+
+			lt, ok := p.LocalTimeAt(tc.t)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, lt)
+		})
+	}
+}
+
+func TestPorts_AtUTCOffset(t *testing.T) {
+	ports := domain.Ports{
+		{ID: "AEAJM", Timezone: "Asia/Dubai"},
+		{ID: "OMSLL", Timezone: "Asia/Muscat"},
+		{ID: "NLRTM", Timezone: "Europe/Amsterdam"},
+		{ID: "XXXXX", Timezone: "Asia/Ajman"},
+		{ID: "YYYYY"},
+	}
+
+	winter := time.Date(2026, time.January, 5, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2026, time.July, 5, 12, 0, 0, 0, time.UTC)
+
+	ids := func(ports domain.Ports) []string {
+		ids := []string{}
+		for _, p := range ports {
+			ids = append(ids, p.ID)
+		}
+
+		return ids
+	}
+
+	assert.Equal(t, []string{"AEAJM", "OMSLL"}, ids(ports.AtUTCOffset(4*3600, winter)))
+	assert.Equal(t, []string{"NLRTM"}, ids(ports.AtUTCOffset(3600, winter)))
+	assert.Equal(t, []string{"NLRTM"}, ids(ports.AtUTCOffset(2*3600, summer)))
+	assert.Empty(t, ports.AtUTCOffset(0, winter))
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// unlocPattern matches a UN/LOCODE: a country code followed by a 3 characters location code.
+var unlocPattern = regexp.MustCompile(`^[A-Z]{2}[A-Z2-9]{3}$`)
+
+type (
+	// FieldError is a validation error on a single port field.
+	FieldError struct {
+		// Field is the JSON name of the invalid field.
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	}
+
+	// ValidationError holds all the field errors found on a port.
+	ValidationError struct {
+		Errors []FieldError `json:"errors"`
+	}
+
+	// InvalidPort is a port failing validation, along with its field errors.
+	InvalidPort struct {
+		ID     string       `json:"id"`
+		Errors []FieldError `json:"errors"`
+	}
+
+	// PortsValidationError holds the ports failing validation, in the order
+	// they were given.
+	PortsValidationError struct {
+		Ports []InvalidPort `json:"ports"`
+	}
+)
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		msgs = append(msgs, fe.Error())
+	}
+
+	return "invalid port: " + strings.Join(msgs, "; ")
+}
+
+func (e *PortsValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Ports))
+
+	for _, p := range e.Ports {
+		fields := make([]string, 0, len(p.Errors))
+		for _, fe := range p.Errors {
+			fields = append(fields, fe.Error())
+		}
+
+		msgs = append(msgs, fmt.Sprintf("port '%s': %s", p.ID, strings.Join(fields, ", ")))
+	}
+
+	return "invalid ports: " + strings.Join(msgs, "; ")
+}
+
+// Validate checks the data of all the ports, returning a
+// *PortsValidationError with the invalid ones, or nil if they are all valid.
+func (ports Ports) Validate() error {
+	var invalid []InvalidPort
+
+	for idx := range ports {
+		var vErr *ValidationError
+		if errors.As(ports[idx].Validate(), &vErr) {
+			invalid = append(invalid, InvalidPort{ID: ports[idx].ID, Errors: vErr.Errors})
+		}
+	}
+
+	if len(invalid) > 0 {
+		return &PortsValidationError{Ports: invalid}
+	}
+
+	return nil
+}
+
+// Validate checks the port data, returning a *ValidationError with all the
+// invalid fields, or nil if the port is valid.
+func (p *Port) Validate() error {
+	var errs []FieldError
+
+	add := func(field, format string, args ...any) {
+		errs = append(errs, FieldError{
+			Field:   field,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if strings.TrimSpace(p.ID) == "" {
+		add("id", "is required")
+	}
+
+	if strings.TrimSpace(p.Name) == "" {
+		add("name", "is required")
+	}
+
+	if err := p.Coordinates.Validate(); err != nil {
+		add("coordinates", "%s", err)
+	}
+
+	if p.Timezone != "" {
+		if _, err := LoadTimezone(p.Timezone); err != nil {
+			add("timezone", "%s", err)
+		}
+	}
+
+	for _, u := range p.Unlocs {
+		if !unlocPattern.MatchString(u) {
+			add("unlocs", "invalid UN/LOCODE '%s'", u)
+		} else if p.CountryCode != "" && u[:2] != p.CountryCode {
+			add("unlocs", "UN/LOCODE '%s' doesn't match country code '%s'", u, p.CountryCode)
+		}
+	}
+
+	if p.CountryCode != "" {
+		if _, ok := CountryByCode(p.CountryCode); !ok || p.CountryCode != strings.ToUpper(p.CountryCode) {
+			add("countryCode", "unknown country code '%s'", p.CountryCode)
+		} else if c, ok := CountryByName(p.Country); ok && c.Code != p.CountryCode {
+			add("countryCode", "'%s' doesn't match country '%s' (%s)", p.CountryCode, p.Country, c.Code)
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPort_Validate(t *testing.T) {
+	tcs := []struct {
+		name        string
+		port        domain.Port
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			port: domain.Port{
+				ID:          "AEAJM",
+				Name:        "Ajman",
+				Coordinates: []float64{55.5136433, 25.4052165},
+				Timezone:    "Asia/Dubai",
+				Unlocs:      []string{"AEAJM"},
+			},
+		},
+		{
+			name: "valid without coordinates",
+			port: domain.Port{
+				ID:   "AEAJM",
+				Name: "Ajman",
+			},
+		},
+		{
+			name:        "missing fields",
+			port:        domain.Port{},
+			expectedErr: "invalid port: id: is required; name: is required",
+		},
+		{
+			name: "invalid coordinates length",
+			port: domain.Port{
+				ID:          "AEAJM",
+				Name:        "Ajman",
+				Coordinates: []float64{55.5136433},
+			},
+			expectedErr: "invalid port: coordinates: must have 2 values, got 1",
+		},
+		{
+			name: "invalid latitude",
+			port: domain.Port{
+				ID:          "AEAJM",
+				Name:        "Ajman",
+				Coordinates: []float64{25.4052165, 155.5136433},
+			},
+			expectedErr: "invalid port: coordinates: latitude 155.5136433 out of range",
+		},
+		{
+			name: "invalid unlocs",
+			port: domain.Port{
+				ID:     "AEAJM",
+				Name:   "Ajman",
+				Unlocs: []string{"AEAJM", "ae1"},
+			},
+			expectedErr: "invalid port: unlocs: invalid UN/LOCODE 'ae1'",
+		},
+		{
+			name: "unknown timezone",
+			port: domain.Port{
+				ID:       "AEAJM",
+				Name:     "Ajman",
+				Timezone: "Asia/Ajman",
+			},
+			expectedErr: "invalid port: timezone: unknown timezone 'Asia/Ajman'",
+		},
+		{
+			name: "valid country code",
+			port: domain.Port{
+				ID:          "AEAJM",
+				Name:        "Ajman",
+				Country:     "United Arab Emirates",
+				CountryCode: "AE",
+				Unlocs:      []string{"AEAJM"},
+			},
+		},
+		{
+			name: "unknown country code",
+			port: domain.Port{
+				ID:          "AEAJM",
+				Name:        "Ajman",
+				CountryCode: "XX",
+			},
+			expectedErr: "invalid port: countryCode: unknown country code 'XX'",
+		},
+		{
+			name: "country code mismatch",
+			port: domain.Port{
+				ID:          "ANEUX",
+				Name:        "Sint Eustatius",
+				Country:     "Netherlands",
+				CountryCode: "AN",
+				Unlocs:      []string{"ANEUX", "NLEUX"},
+			},
+			expectedErr: "invalid port: unlocs: UN/LOCODE 'NLEUX' doesn't match country code 'AN'; " +
+				"countryCode: 'AN' doesn't match country 'Netherlands' (NL)",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.port.Validate()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPorts_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		ports := domain.Ports{
+			{ID: "AEAJM", Name: "Ajman"},
+		}
+
+		assert.NoError(t, ports.Validate())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ports := domain.Ports{
+			{ID: "AEAJM", Name: "Ajman", CountryCode: "XX"},
+			{ID: "AEAUH", Name: "Abu Dhabi"},
+			{ID: "AEDXB", Timezone: "Mars/Olympus"},
+		}
+
+		err := ports.Validate()
+		assert.EqualError(t, err, "invalid ports: "+
+			"port 'AEAJM': countryCode: unknown country code 'XX'; "+
+			"port 'AEDXB': name: is required, timezone: unknown timezone 'Mars/Olympus'")
+
+		var pvErr *domain.PortsValidationError
+		if assert.ErrorAs(t, err, &pvErr) {
+			assert.Equal(t, []domain.InvalidPort{
+				{
+					ID: "AEAJM",
+					Errors: []domain.FieldError{
+						{Field: "countryCode", Message: "unknown country code 'XX'"},
+					},
+				},
+				{
+					ID: "AEDXB",
+					Errors: []domain.FieldError{
+						{Field: "name", Message: "is required"},
+						{Field: "timezone", Message: "unknown timezone 'Mars/Olympus'"},
+					},
+				},
+			}, pvErr.Ports)
+		}
+	})
+}
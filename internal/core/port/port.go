@@ -3,23 +3,162 @@ package port
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/quality"
 )
 
-var ErrPortNotFound = errors.New("port not found")
+var (
+	ErrPortNotFound    = errors.New("port not found")
+	ErrCountryNotFound = errors.New("country not found")
+	ErrInvalidTile     = errors.New("invalid tile")
+	ErrNoCoordinates   = errors.New("port has no coordinates")
+	ErrNoRoute         = errors.New("no sea route found")
+	ErrRegionNotFound  = errors.New("region not found")
+	ErrInvalidRegions  = errors.New("invalid regions")
+	// ErrPortsRejected is matched by the errors rejecting the ports written,
+	// which would be rejected again, unlike the failures to write them.
+	ErrPortsRejected = errors.New("ports rejected")
+
+	ErrEmptyDistanceMatrix    = errors.New("both origins and destinations are required")
+	ErrDistanceMatrixTooLarge = errors.New("distance matrix too large")
+)
+
+// UnlocatedPortsError reports the ports which could not be located, as they
+// were not found, or have no coordinates.
+type UnlocatedPortsError struct {
+	NotFound      []string
+	NoCoordinates []string
+}
+
+func (e *UnlocatedPortsError) Error() string {
+	var parts []string
+
+	if len(e.NotFound) > 0 {
+		parts = append(parts, "ports not found: "+strings.Join(e.NotFound, ", "))
+	}
+
+	if len(e.NoCoordinates) > 0 {
+		parts = append(parts, "ports without coordinates: "+strings.Join(e.NoCoordinates, ", "))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// UnknownRegionsError reports the regions referenced by ports which are not
+// in the region catalogue.
+type UnknownRegionsError struct {
+	Regions []string
+}
+
+func (e *UnknownRegionsError) Error() string {
+	return "unknown regions: " + strings.Join(e.Regions, ", ")
+}
+
+// RegionsInUseError reports the regions which can't be removed from the
+// region catalogue, as ports still reference them.
+type RegionsInUseError struct {
+	Regions []string
+}
+
+func (e *RegionsInUseError) Error() string {
+	return "regions referenced by ports: " + strings.Join(e.Regions, ", ")
+}
+
+// AmbiguousPortError reports a secondary key value claimed by several ports.
+type AmbiguousPortError struct {
+	Key   domain.PortKey
+	Value string
+	Ports []string
+}
+
+func (e *AmbiguousPortError) Error() string {
+	return fmt.Sprintf("%s '%s' is claimed by several ports: %s", e.Key, e.Value, strings.Join(e.Ports, ", "))
+}
 
 //go:generate mockgen -source=port.go -destination=porttest/port_mock.go -package=porttest
 type (
 	// PortRepository is an interface for interacting with port-related data.
+	// Ports may only reference regions of the region catalogue, which is
+	// enforced along with the writes: BulkUpsert fails with an
+	// *UnknownRegionsError, and ReplaceRegions with a *RegionsInUseError.
 	PortRepository interface {
 		Get(ctx context.Context, id string) (*domain.Port, error)
+		List(ctx context.Context) (domain.Ports, error)
+		Each(ctx context.Context, fn func(*domain.Port) error) error
 		BulkUpsert(ctx context.Context, ports domain.Ports) error
+		BulkDelete(ctx context.Context, ids []string) error
+		FindByKey(ctx context.Context, key domain.PortKey, value string) (domain.Ports, error)
+		Stats(ctx context.Context) (*domain.Stats, error)
+		Regions(ctx context.Context) (domain.Regions, error)
+		ReplaceRegions(ctx context.Context, regions domain.Regions) error
 	}
 
 	// PortService is an interface for interacting with port-related business logic.
 	PortService interface {
 		Get(context.Context, string) (*domain.Port, error)
+		List(context.Context) (domain.Ports, error)
 		BulkUpsert(context.Context, domain.Ports) error
+		BulkDelete(context.Context, []string) error
+	}
+
+	// StreamService is an interface for going through all the ports one at a
+	// time, without listing them all first.
+	StreamService interface {
+		Each(ctx context.Context, fn func(*domain.Port) error) error
+	}
+
+	// LookupService is an interface for looking ports up by their secondary
+	// keys.
+	LookupService interface {
+		ByUnloc(context.Context, string) (*domain.Port, error)
+		ByCode(context.Context, string) (*domain.Port, error)
+		Resolve(context.Context, string) (*domain.Port, error)
+	}
+
+	// CountryService is an interface for looking up countries and their ports.
+	CountryService interface {
+		Countries(context.Context) ([]domain.CountrySummary, error)
+		CountryPorts(context.Context, string) (domain.Ports, error)
+	}
+
+	// RegionService is an interface for managing the region catalogue and
+	// looking up the ports of its regions.
+	RegionService interface {
+		Regions(context.Context) (domain.Regions, error)
+		ReplaceRegions(context.Context, domain.Regions) error
+		RegionPorts(ctx context.Context, id string, descendants bool) (domain.Ports, error)
+	}
+
+	// TileService is an interface for rendering the ports as vector tiles.
+	TileService interface {
+		Tile(ctx context.Context, z, x, y int) ([]byte, error)
+	}
+
+	// RouteService is an interface for computing sea routes between ports.
+	RouteService interface {
+		Route(ctx context.Context, from, to string) (*domain.Route, error)
+	}
+
+	// DistanceService is an interface for computing distances between ports.
+	DistanceService interface {
+		DistanceMatrix(context.Context, domain.DistanceMatrixRequest) (*domain.DistanceMatrix, error)
+	}
+
+	// TimezoneService is an interface for looking up the timezones of the ports.
+	TimezoneService interface {
+		Timezones(context.Context) ([]domain.TimezoneSummary, error)
+	}
+
+	// StatsService is an interface for looking up aggregations of the ports.
+	StatsService interface {
+		Stats(context.Context) (*domain.Stats, error)
+	}
+
+	// QualityService is an interface for analysing the quality of the ports data.
+	QualityService interface {
+		Quality(context.Context) (*quality.Report, error)
 	}
 )
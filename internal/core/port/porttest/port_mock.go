@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/rafaeltg/goports/internal/core/domain"
+	quality "github.com/rafaeltg/goports/internal/core/quality"
 )
 
 // MockPortRepository is a mock of PortRepository interface.
@@ -35,6 +36,20 @@ func (m *MockPortRepository) EXPECT() *MockPortRepositoryMockRecorder {
 	return m.recorder
 }
 
+// BulkDelete mocks base method.
+func (m *MockPortRepository) BulkDelete(ctx context.Context, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDelete", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkDelete indicates an expected call of BulkDelete.
+func (mr *MockPortRepositoryMockRecorder) BulkDelete(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*MockPortRepository)(nil).BulkDelete), ctx, ids)
+}
+
 // BulkUpsert mocks base method.
 func (m *MockPortRepository) BulkUpsert(ctx context.Context, ports domain.Ports) error {
 	m.ctrl.T.Helper()
@@ -49,6 +64,35 @@ func (mr *MockPortRepositoryMockRecorder) BulkUpsert(ctx, ports interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsert", reflect.TypeOf((*MockPortRepository)(nil).BulkUpsert), ctx, ports)
 }
 
+// Each mocks base method.
+func (m *MockPortRepository) Each(ctx context.Context, fn func(*domain.Port) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Each", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Each indicates an expected call of Each.
+func (mr *MockPortRepositoryMockRecorder) Each(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Each", reflect.TypeOf((*MockPortRepository)(nil).Each), ctx, fn)
+}
+
+// FindByKey mocks base method.
+func (m *MockPortRepository) FindByKey(ctx context.Context, key domain.PortKey, value string) (domain.Ports, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByKey", ctx, key, value)
+	ret0, _ := ret[0].(domain.Ports)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByKey indicates an expected call of FindByKey.
+func (mr *MockPortRepositoryMockRecorder) FindByKey(ctx, key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByKey", reflect.TypeOf((*MockPortRepository)(nil).FindByKey), ctx, key, value)
+}
+
 // Get mocks base method.
 func (m *MockPortRepository) Get(ctx context.Context, id string) (*domain.Port, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +108,65 @@ func (mr *MockPortRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPortRepository)(nil).Get), ctx, id)
 }
 
+// List mocks base method.
+func (m *MockPortRepository) List(ctx context.Context) (domain.Ports, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].(domain.Ports)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPortRepositoryMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPortRepository)(nil).List), ctx)
+}
+
+// Regions mocks base method.
+func (m *MockPortRepository) Regions(ctx context.Context) (domain.Regions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Regions", ctx)
+	ret0, _ := ret[0].(domain.Regions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Regions indicates an expected call of Regions.
+func (mr *MockPortRepositoryMockRecorder) Regions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Regions", reflect.TypeOf((*MockPortRepository)(nil).Regions), ctx)
+}
+
+// ReplaceRegions mocks base method.
+func (m *MockPortRepository) ReplaceRegions(ctx context.Context, regions domain.Regions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRegions", ctx, regions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRegions indicates an expected call of ReplaceRegions.
+func (mr *MockPortRepositoryMockRecorder) ReplaceRegions(ctx, regions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRegions", reflect.TypeOf((*MockPortRepository)(nil).ReplaceRegions), ctx, regions)
+}
+
+// Stats mocks base method.
+func (m *MockPortRepository) Stats(ctx context.Context) (*domain.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx)
+	ret0, _ := ret[0].(*domain.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockPortRepositoryMockRecorder) Stats(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockPortRepository)(nil).Stats), ctx)
+}
+
 // MockPortService is a mock of PortService interface.
 type MockPortService struct {
 	ctrl     *gomock.Controller
@@ -87,6 +190,20 @@ func (m *MockPortService) EXPECT() *MockPortServiceMockRecorder {
 	return m.recorder
 }
 
+// BulkDelete mocks base method.
+func (m *MockPortService) BulkDelete(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDelete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkDelete indicates an expected call of BulkDelete.
+func (mr *MockPortServiceMockRecorder) BulkDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*MockPortService)(nil).BulkDelete), arg0, arg1)
+}
+
 // BulkUpsert mocks base method.
 func (m *MockPortService) BulkUpsert(arg0 context.Context, arg1 domain.Ports) error {
 	m.ctrl.T.Helper()
@@ -115,3 +232,471 @@ func (mr *MockPortServiceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPortService)(nil).Get), arg0, arg1)
 }
+
+// List mocks base method.
+func (m *MockPortService) List(arg0 context.Context) (domain.Ports, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].(domain.Ports)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPortServiceMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPortService)(nil).List), arg0)
+}
+
+// MockStreamService is a mock of StreamService interface.
+type MockStreamService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStreamServiceMockRecorder
+}
+
+// MockStreamServiceMockRecorder is the mock recorder for MockStreamService.
+type MockStreamServiceMockRecorder struct {
+	mock *MockStreamService
+}
+
+// NewMockStreamService creates a new mock instance.
+func NewMockStreamService(ctrl *gomock.Controller) *MockStreamService {
+	mock := &MockStreamService{ctrl: ctrl}
+	mock.recorder = &MockStreamServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStreamService) EXPECT() *MockStreamServiceMockRecorder {
+	return m.recorder
+}
+
+// Each mocks base method.
+func (m *MockStreamService) Each(ctx context.Context, fn func(*domain.Port) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Each", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Each indicates an expected call of Each.
+func (mr *MockStreamServiceMockRecorder) Each(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Each", reflect.TypeOf((*MockStreamService)(nil).Each), ctx, fn)
+}
+
+// MockLookupService is a mock of LookupService interface.
+type MockLookupService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLookupServiceMockRecorder
+}
+
+// MockLookupServiceMockRecorder is the mock recorder for MockLookupService.
+type MockLookupServiceMockRecorder struct {
+	mock *MockLookupService
+}
+
+// NewMockLookupService creates a new mock instance.
+func NewMockLookupService(ctrl *gomock.Controller) *MockLookupService {
+	mock := &MockLookupService{ctrl: ctrl}
+	mock.recorder = &MockLookupServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLookupService) EXPECT() *MockLookupServiceMockRecorder {
+	return m.recorder
+}
+
+// ByCode mocks base method.
+func (m *MockLookupService) ByCode(arg0 context.Context, arg1 string) (*domain.Port, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ByCode", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Port)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ByCode indicates an expected call of ByCode.
+func (mr *MockLookupServiceMockRecorder) ByCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByCode", reflect.TypeOf((*MockLookupService)(nil).ByCode), arg0, arg1)
+}
+
+// ByUnloc mocks base method.
+func (m *MockLookupService) ByUnloc(arg0 context.Context, arg1 string) (*domain.Port, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ByUnloc", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Port)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ByUnloc indicates an expected call of ByUnloc.
+func (mr *MockLookupServiceMockRecorder) ByUnloc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByUnloc", reflect.TypeOf((*MockLookupService)(nil).ByUnloc), arg0, arg1)
+}
+
+// Resolve mocks base method.
+func (m *MockLookupService) Resolve(arg0 context.Context, arg1 string) (*domain.Port, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Port)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockLookupServiceMockRecorder) Resolve(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockLookupService)(nil).Resolve), arg0, arg1)
+}
+
+// MockCountryService is a mock of CountryService interface.
+type MockCountryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCountryServiceMockRecorder
+}
+
+// MockCountryServiceMockRecorder is the mock recorder for MockCountryService.
+type MockCountryServiceMockRecorder struct {
+	mock *MockCountryService
+}
+
+// NewMockCountryService creates a new mock instance.
+func NewMockCountryService(ctrl *gomock.Controller) *MockCountryService {
+	mock := &MockCountryService{ctrl: ctrl}
+	mock.recorder = &MockCountryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCountryService) EXPECT() *MockCountryServiceMockRecorder {
+	return m.recorder
+}
+
+// Countries mocks base method.
+func (m *MockCountryService) Countries(arg0 context.Context) ([]domain.CountrySummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Countries", arg0)
+	ret0, _ := ret[0].([]domain.CountrySummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Countries indicates an expected call of Countries.
+func (mr *MockCountryServiceMockRecorder) Countries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Countries", reflect.TypeOf((*MockCountryService)(nil).Countries), arg0)
+}
+
+// CountryPorts mocks base method.
+func (m *MockCountryService) CountryPorts(arg0 context.Context, arg1 string) (domain.Ports, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountryPorts", arg0, arg1)
+	ret0, _ := ret[0].(domain.Ports)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountryPorts indicates an expected call of CountryPorts.
+func (mr *MockCountryServiceMockRecorder) CountryPorts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountryPorts", reflect.TypeOf((*MockCountryService)(nil).CountryPorts), arg0, arg1)
+}
+
+// MockRegionService is a mock of RegionService interface.
+type MockRegionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegionServiceMockRecorder
+}
+
+// MockRegionServiceMockRecorder is the mock recorder for MockRegionService.
+type MockRegionServiceMockRecorder struct {
+	mock *MockRegionService
+}
+
+// NewMockRegionService creates a new mock instance.
+func NewMockRegionService(ctrl *gomock.Controller) *MockRegionService {
+	mock := &MockRegionService{ctrl: ctrl}
+	mock.recorder = &MockRegionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegionService) EXPECT() *MockRegionServiceMockRecorder {
+	return m.recorder
+}
+
+// RegionPorts mocks base method.
+func (m *MockRegionService) RegionPorts(ctx context.Context, id string, descendants bool) (domain.Ports, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegionPorts", ctx, id, descendants)
+	ret0, _ := ret[0].(domain.Ports)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegionPorts indicates an expected call of RegionPorts.
+func (mr *MockRegionServiceMockRecorder) RegionPorts(ctx, id, descendants interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegionPorts", reflect.TypeOf((*MockRegionService)(nil).RegionPorts), ctx, id, descendants)
+}
+
+// Regions mocks base method.
+func (m *MockRegionService) Regions(arg0 context.Context) (domain.Regions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Regions", arg0)
+	ret0, _ := ret[0].(domain.Regions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Regions indicates an expected call of Regions.
+func (mr *MockRegionServiceMockRecorder) Regions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Regions", reflect.TypeOf((*MockRegionService)(nil).Regions), arg0)
+}
+
+// ReplaceRegions mocks base method.
+func (m *MockRegionService) ReplaceRegions(arg0 context.Context, arg1 domain.Regions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRegions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRegions indicates an expected call of ReplaceRegions.
+func (mr *MockRegionServiceMockRecorder) ReplaceRegions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRegions", reflect.TypeOf((*MockRegionService)(nil).ReplaceRegions), arg0, arg1)
+}
+
+// MockTileService is a mock of TileService interface.
+type MockTileService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTileServiceMockRecorder
+}
+
+// MockTileServiceMockRecorder is the mock recorder for MockTileService.
+type MockTileServiceMockRecorder struct {
+	mock *MockTileService
+}
+
+// NewMockTileService creates a new mock instance.
+func NewMockTileService(ctrl *gomock.Controller) *MockTileService {
+	mock := &MockTileService{ctrl: ctrl}
+	mock.recorder = &MockTileServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTileService) EXPECT() *MockTileServiceMockRecorder {
+	return m.recorder
+}
+
+// Tile mocks base method.
+func (m *MockTileService) Tile(ctx context.Context, z, x, y int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tile", ctx, z, x, y)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tile indicates an expected call of Tile.
+func (mr *MockTileServiceMockRecorder) Tile(ctx, z, x, y interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tile", reflect.TypeOf((*MockTileService)(nil).Tile), ctx, z, x, y)
+}
+
+// MockRouteService is a mock of RouteService interface.
+type MockRouteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRouteServiceMockRecorder
+}
+
+// MockRouteServiceMockRecorder is the mock recorder for MockRouteService.
+type MockRouteServiceMockRecorder struct {
+	mock *MockRouteService
+}
+
+// NewMockRouteService creates a new mock instance.
+func NewMockRouteService(ctrl *gomock.Controller) *MockRouteService {
+	mock := &MockRouteService{ctrl: ctrl}
+	mock.recorder = &MockRouteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRouteService) EXPECT() *MockRouteServiceMockRecorder {
+	return m.recorder
+}
+
+// Route mocks base method.
+func (m *MockRouteService) Route(ctx context.Context, from, to string) (*domain.Route, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Route", ctx, from, to)
+	ret0, _ := ret[0].(*domain.Route)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Route indicates an expected call of Route.
+func (mr *MockRouteServiceMockRecorder) Route(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Route", reflect.TypeOf((*MockRouteService)(nil).Route), ctx, from, to)
+}
+
+// MockDistanceService is a mock of DistanceService interface.
+type MockDistanceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDistanceServiceMockRecorder
+}
+
+// MockDistanceServiceMockRecorder is the mock recorder for MockDistanceService.
+type MockDistanceServiceMockRecorder struct {
+	mock *MockDistanceService
+}
+
+// NewMockDistanceService creates a new mock instance.
+func NewMockDistanceService(ctrl *gomock.Controller) *MockDistanceService {
+	mock := &MockDistanceService{ctrl: ctrl}
+	mock.recorder = &MockDistanceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDistanceService) EXPECT() *MockDistanceServiceMockRecorder {
+	return m.recorder
+}
+
+// DistanceMatrix mocks base method.
+func (m *MockDistanceService) DistanceMatrix(arg0 context.Context, arg1 domain.DistanceMatrixRequest) (*domain.DistanceMatrix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DistanceMatrix", arg0, arg1)
+	ret0, _ := ret[0].(*domain.DistanceMatrix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DistanceMatrix indicates an expected call of DistanceMatrix.
+func (mr *MockDistanceServiceMockRecorder) DistanceMatrix(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistanceMatrix", reflect.TypeOf((*MockDistanceService)(nil).DistanceMatrix), arg0, arg1)
+}
+
+// MockTimezoneService is a mock of TimezoneService interface.
+type MockTimezoneService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimezoneServiceMockRecorder
+}
+
+// MockTimezoneServiceMockRecorder is the mock recorder for MockTimezoneService.
+type MockTimezoneServiceMockRecorder struct {
+	mock *MockTimezoneService
+}
+
+// NewMockTimezoneService creates a new mock instance.
+func NewMockTimezoneService(ctrl *gomock.Controller) *MockTimezoneService {
+	mock := &MockTimezoneService{ctrl: ctrl}
+	mock.recorder = &MockTimezoneServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimezoneService) EXPECT() *MockTimezoneServiceMockRecorder {
+	return m.recorder
+}
+
+// Timezones mocks base method.
+func (m *MockTimezoneService) Timezones(arg0 context.Context) ([]domain.TimezoneSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Timezones", arg0)
+	ret0, _ := ret[0].([]domain.TimezoneSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Timezones indicates an expected call of Timezones.
+func (mr *MockTimezoneServiceMockRecorder) Timezones(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Timezones", reflect.TypeOf((*MockTimezoneService)(nil).Timezones), arg0)
+}
+
+// MockStatsService is a mock of StatsService interface.
+type MockStatsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsServiceMockRecorder
+}
+
+// MockStatsServiceMockRecorder is the mock recorder for MockStatsService.
+type MockStatsServiceMockRecorder struct {
+	mock *MockStatsService
+}
+
+// NewMockStatsService creates a new mock instance.
+func NewMockStatsService(ctrl *gomock.Controller) *MockStatsService {
+	mock := &MockStatsService{ctrl: ctrl}
+	mock.recorder = &MockStatsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsService) EXPECT() *MockStatsServiceMockRecorder {
+	return m.recorder
+}
+
+// Stats mocks base method.
+func (m *MockStatsService) Stats(arg0 context.Context) (*domain.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", arg0)
+	ret0, _ := ret[0].(*domain.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockStatsServiceMockRecorder) Stats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockStatsService)(nil).Stats), arg0)
+}
+
+// MockQualityService is a mock of QualityService interface.
+type MockQualityService struct {
+	ctrl     *gomock.Controller
+	recorder *MockQualityServiceMockRecorder
+}
+
+// MockQualityServiceMockRecorder is the mock recorder for MockQualityService.
+type MockQualityServiceMockRecorder struct {
+	mock *MockQualityService
+}
+
+// NewMockQualityService creates a new mock instance.
+func NewMockQualityService(ctrl *gomock.Controller) *MockQualityService {
+	mock := &MockQualityService{ctrl: ctrl}
+	mock.recorder = &MockQualityServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQualityService) EXPECT() *MockQualityServiceMockRecorder {
+	return m.recorder
+}
+
+// Quality mocks base method.
+func (m *MockQualityService) Quality(arg0 context.Context) (*quality.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quality", arg0)
+	ret0, _ := ret[0].(*quality.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quality indicates an expected call of Quality.
+func (mr *MockQualityServiceMockRecorder) Quality(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quality", reflect.TypeOf((*MockQualityService)(nil).Quality), arg0)
+}
@@ -0,0 +1,23 @@
+package quality
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+)
+
+//go:embed report.html
+var reportHTML string
+
+var reportTemplate = template.Must(template.New("report").Parse(reportHTML))
+
+// WriteHTML writes the report as an HTML page.
+func (r *Report) WriteHTML(w io.Writer) error {
+	return reportTemplate.Execute(w, struct {
+		*Report
+		Checks []Check
+	}{
+		Report: r,
+		Checks: Checks(),
+	})
+}
@@ -0,0 +1,359 @@
+package quality
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+)
+
+const (
+	// CheckMissingCoordinates flags ports without coordinates.
+	CheckMissingCoordinates Check = "missing-coordinates"
+	// CheckSwappedCoordinates flags ports whose latitude and longitude seem
+	// to be swapped.
+	CheckSwappedCoordinates Check = "swapped-coordinates"
+	// CheckUnknownCountry flags ports whose country is not an ISO 3166 one.
+	CheckUnknownCountry Check = "unknown-country"
+	// CheckCountryMismatch flags ports whose UN/LOCODEs don't start with
+	// their country code.
+	CheckCountryMismatch Check = "country-mismatch"
+	// CheckDuplicateName flags ports sharing their name with other ports of
+	// the same city, province and country.
+	CheckDuplicateName Check = "duplicate-name"
+	// CheckSuspiciousEncoding flags text fields which seem to be wrongly
+	// encoded, like UTF-8 text read as Latin-1.
+	CheckSuspiciousEncoding Check = "suspicious-encoding"
+	// CheckInvalidTimezone flags ports with a missing or unknown timezone.
+	CheckInvalidTimezone Check = "invalid-timezone"
+)
+
+const (
+	// minCountryPorts is the number of ports with coordinates a country
+	// must have for its ports to be checked for swapped coordinates.
+	minCountryPorts = 3
+	// minSwapDistance is the distance, in degrees, from the country median
+	// from which the coordinates of a port are suspicious.
+	minSwapDistance = 10
+	// swapRatio is how much closer to the country median the swapped
+	// coordinates must be for them to be reported.
+	swapRatio = 4
+)
+
+type (
+	// Check is a data quality check.
+	Check string
+
+	// Issue is a data quality issue found on a port.
+	Issue struct {
+		PortID string `json:"portId"`
+		Check  Check  `json:"check"`
+		// Field is the JSON name of the field the issue was found on, if any.
+		Field   string `json:"field,omitempty"`
+		Message string `json:"message"`
+	}
+
+	// Report is the outcome of analysing a set of ports.
+	Report struct {
+		GeneratedAt     time.Time     `json:"generatedAt"`
+		Ports           int           `json:"ports"`
+		PortsWithIssues int           `json:"portsWithIssues"`
+		Counts          map[Check]int `json:"counts"`
+		Issues          []Issue       `json:"issues"`
+	}
+
+	analyser struct {
+		issues  []Issue
+		medians map[string][2]float64
+	}
+)
+
+// Checks returns all the data quality checks, in the order they are run.
+func Checks() []Check {
+	return []Check{
+		CheckMissingCoordinates,
+		CheckSwappedCoordinates,
+		CheckUnknownCountry,
+		CheckCountryMismatch,
+		CheckDuplicateName,
+		CheckSuspiciousEncoding,
+		CheckInvalidTimezone,
+	}
+}
+
+// Analyse runs all the data quality checks over the ports. Issues are
+// sorted by port ID.
+func Analyse(ports domain.Ports) *Report {
+	a := &analyser{medians: countryMedians(ports)}
+
+	for idx := range ports {
+		p := &ports[idx]
+
+		a.coordinates(p)
+		a.country(p)
+		a.encoding(p)
+		a.timezone(p)
+	}
+
+	a.duplicateNames(ports)
+
+	sort.SliceStable(a.issues, func(i, j int) bool {
+		return a.issues[i].PortID < a.issues[j].PortID
+	})
+
+	r := &Report{
+		GeneratedAt: time.Now().UTC(),
+		Ports:       len(ports),
+		Counts:      make(map[Check]int, len(Checks())),
+		Issues:      a.issues,
+	}
+
+	for _, c := range Checks() {
+		r.Counts[c] = 0
+	}
+
+	withIssues := make(map[string]bool)
+
+	for _, issue := range r.Issues {
+		r.Counts[issue.Check]++
+		withIssues[issue.PortID] = true
+	}
+
+	r.PortsWithIssues = len(withIssues)
+
+	if r.Issues == nil {
+		r.Issues = []Issue{}
+	}
+
+	return r
+}
+
+func (a *analyser) add(p *domain.Port, check Check, field, format string, args ...any) {
+	a.issues = append(a.issues, Issue{
+		PortID:  p.ID,
+		Check:   check,
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (a *analyser) coordinates(p *domain.Port) {
+	if len(p.Coordinates) == 0 {
+		a.add(p, CheckMissingCoordinates, "coordinates", "missing coordinates")
+		return
+	}
+
+	if len(p.Coordinates) != 2 {
+		return
+	}
+
+	lon, lat := p.Coordinates.Lon(), p.Coordinates.Lat()
+
+	if math.Abs(lat) > 90 && math.Abs(lon) <= 90 {
+		a.add(p, CheckSwappedCoordinates, "coordinates",
+			"latitude %g out of range, while longitude %g would be a valid one", lat, lon)
+
+		return
+	}
+
+	median, ok := a.medians[countryKey(p.Country)]
+	if !ok {
+		return
+	}
+
+	d := distance(lon, lat, median[0], median[1])
+	swapped := distance(lat, lon, median[0], median[1])
+
+	if d >= minSwapDistance && swapped*swapRatio < d {
+		a.add(p, CheckSwappedCoordinates, "coordinates",
+			"coordinates [%g, %g] are far from the other %s ports, unlike [%g, %g]", lon, lat, p.Country, lat, lon)
+	}
+}
+
+func (a *analyser) country(p *domain.Port) {
+	c, ok := domain.CountryByName(p.Country)
+	if !ok {
+		a.add(p, CheckUnknownCountry, "country", "unknown country '%s'", p.Country)
+		return
+	}
+
+	for _, unloc := range p.Unlocs {
+		if len(unloc) >= 2 && !strings.EqualFold(unloc[:2], c.Code) {
+			a.add(p, CheckCountryMismatch, "unlocs",
+				"UN/LOCODE %s doesn't match the %s country code %s", unloc, p.Country, c.Code)
+		}
+	}
+}
+
+func (a *analyser) encoding(p *domain.Port) {
+	check := func(field, s string) {
+		if suspiciousEncoding(s) {
+			a.add(p, CheckSuspiciousEncoding, field, "suspicious encoding of '%s'", s)
+		}
+	}
+
+	check("name", p.Name)
+	check("city", p.City)
+	check("province", p.Province)
+
+	for _, s := range p.Alias {
+		check("alias", s)
+	}
+
+	for _, s := range p.Regions {
+		check("regions", s)
+	}
+}
+
+func (a *analyser) timezone(p *domain.Port) {
+	if strings.TrimSpace(p.Timezone) == "" {
+		a.add(p, CheckInvalidTimezone, "timezone", "missing timezone")
+		return
+	}
+
+	if _, err := domain.LoadTimezone(p.Timezone); err != nil {
+		a.add(p, CheckInvalidTimezone, "timezone", "%s", err)
+	}
+}
+
+func (a *analyser) duplicateNames(ports domain.Ports) {
+	groups := make(map[string][]string)
+
+	for idx := range ports {
+		p := &ports[idx]
+
+		if strings.TrimSpace(p.Name) == "" {
+			continue
+		}
+
+		key := nameKey(p)
+		groups[key] = append(groups[key], p.ID)
+	}
+
+	for idx := range ports {
+		p := &ports[idx]
+
+		ids := groups[nameKey(p)]
+		if len(ids) < 2 {
+			continue
+		}
+
+		others := make([]string, 0, len(ids)-1)
+		for _, id := range ids {
+			if id != p.ID {
+				others = append(others, id)
+			}
+		}
+
+		a.add(p, CheckDuplicateName, "name", "name '%s' is shared with %s in %s",
+			p.Name, strings.Join(others, ", "), p.City)
+	}
+}
+
+// countryMedians returns the median longitude and latitude of the ports of
+// each country with enough of them.
+func countryMedians(ports domain.Ports) map[string][2]float64 {
+	lons := make(map[string][]float64)
+	lats := make(map[string][]float64)
+
+	for _, p := range ports {
+		if len(p.Coordinates) != 2 {
+			continue
+		}
+
+		key := countryKey(p.Country)
+		lons[key] = append(lons[key], p.Coordinates.Lon())
+		lats[key] = append(lats[key], p.Coordinates.Lat())
+	}
+
+	medians := make(map[string][2]float64, len(lons))
+
+	for key := range lons {
+		if len(lons[key]) < minCountryPorts {
+			continue
+		}
+
+		medians[key] = [2]float64{median(lons[key]), median(lats[key])}
+	}
+
+	return medians
+}
+
+func median(values []float64) float64 {
+	sort.Float64s(values)
+
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+
+	return values[mid]
+}
+
+// distance returns the euclidean distance, in degrees, between two points,
+// which is enough to compare how far points are.
+func distance(lon1, lat1, lon2, lat2 float64) float64 {
+	dLon := math.Abs(lon1 - lon2)
+	if dLon > 180 {
+		dLon = 360 - dLon
+	}
+
+	return math.Hypot(dLon, lat1-lat2)
+}
+
+// nameKey returns the key of the port name within its city.
+func nameKey(p *domain.Port) string {
+	return strings.Join([]string{
+		countryKey(p.Country),
+		countryKey(p.Province),
+		countryKey(p.City),
+		countryKey(p.Name),
+	}, "|")
+}
+
+func countryKey(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// suspiciousEncoding reports whether a text seems to be wrongly encoded:
+// invalid UTF-8, replacement or control characters, UTF-8 sequences read as
+// Latin-1 ("Ã©") or spacing diacritics next to letters ("Z¸aby").
+func suspiciousEncoding(s string) bool {
+	if !utf8.ValidString(s) {
+		return true
+	}
+
+	runes := []rune(s)
+
+	for idx, r := range runes {
+		switch {
+		case r == utf8.RuneError, r >= 0x80 && r <= 0x9f:
+			return true
+		case r == 'Ã' || r == 'Â':
+			if idx+1 < len(runes) && runes[idx+1] >= 0xa0 && runes[idx+1] <= 0xbf {
+				return true
+			}
+		case isSpacingDiacritic(r):
+			if idx > 0 && unicode.IsLetter(runes[idx-1]) ||
+				idx+1 < len(runes) && unicode.IsLetter(runes[idx+1]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isSpacingDiacritic(r rune) bool {
+	switch r {
+	case '¨', '¯', '´', '¸', '˘', '˙', '˚', '˛', '˜', '˝', 'ˆ', 'ˇ':
+		return true
+	default:
+		return false
+	}
+}
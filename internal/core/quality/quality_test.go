@@ -0,0 +1,115 @@
+package quality_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/quality"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func uaePort(id, name string, coordinates ...float64) domain.Port {
+	return domain.Port{
+		ID:          id,
+		Name:        name,
+		City:        name,
+		Country:     "United Arab Emirates",
+		Coordinates: coordinates,
+		Province:    name,
+		Timezone:    "Asia/Dubai",
+		Unlocs:      []string{id},
+	}
+}
+
+func TestAnalyse(t *testing.T) {
+	t.Run("clean", func(t *testing.T) {
+		r := quality.Analyse(domain.Ports{
+			uaePort("AEAJM", "Ajman", 55.5136433, 25.4052165),
+			uaePort("AEDXB", "Dubai", 55.27, 25.25),
+		})
+
+		assert.Equal(t, 2, r.Ports)
+		assert.Equal(t, 0, r.PortsWithIssues)
+		assert.Empty(t, r.Issues)
+		assert.Len(t, r.Counts, len(quality.Checks()))
+	})
+
+	t.Run("issues", func(t *testing.T) {
+		missing := uaePort("AEFJR", "Al Fujayrah")
+		missing.Timezone = ""
+
+		swapped := uaePort("AEKLF", "Khor al Fakkan", 25.33, 56.35)
+
+		outOfRange := uaePort("AEPRA", "Port Rashid", 25.27, 155.27)
+		outOfRange.Timezone = "Asia/Nowhere"
+
+		mojibake := uaePort("AEAUH", "Abu Dhabi", 54.37, 24.47)
+		mojibake.Province = "Abu Z¸aby [Abu Dhabi]"
+		mojibake.Alias = []string{"AbÃ» Dhabi"}
+
+		mismatch := uaePort("AEQIW", "Umm al Qaiwain", 55.55, 25.56)
+		mismatch.Unlocs = []string{"AEQIW", "OMQIW"}
+
+		unknown := uaePort("XXABC", "Nowhere", 10, 10)
+		unknown.Country = "Atlantis"
+
+		duplicate := uaePort("AEJEA", "Dubai", 55.06, 25.01)
+		duplicate.Province = "Dubai"
+
+		r := quality.Analyse(domain.Ports{
+			uaePort("AEAJM", "Ajman", 55.5136433, 25.4052165),
+			uaePort("AEDXB", "Dubai", 55.27, 25.25),
+			missing,
+			swapped,
+			outOfRange,
+			mojibake,
+			mismatch,
+			unknown,
+			duplicate,
+		})
+
+		assert.Equal(t, 9, r.Ports)
+		assert.Equal(t, 8, r.PortsWithIssues)
+		assert.Equal(t, map[quality.Check]int{
+			quality.CheckMissingCoordinates: 1,
+			quality.CheckSwappedCoordinates: 2,
+			quality.CheckUnknownCountry:     1,
+			quality.CheckCountryMismatch:    1,
+			quality.CheckDuplicateName:      2,
+			quality.CheckSuspiciousEncoding: 2,
+			quality.CheckInvalidTimezone:    2,
+		}, r.Counts)
+
+		assert.Equal(t, []quality.Issue{
+			{PortID: "AEAUH", Check: quality.CheckSuspiciousEncoding, Field: "province", Message: "suspicious encoding of 'Abu Z¸aby [Abu Dhabi]'"},
+			{PortID: "AEAUH", Check: quality.CheckSuspiciousEncoding, Field: "alias", Message: "suspicious encoding of 'AbÃ» Dhabi'"},
+			{PortID: "AEDXB", Check: quality.CheckDuplicateName, Field: "name", Message: "name 'Dubai' is shared with AEJEA in Dubai"},
+			{PortID: "AEFJR", Check: quality.CheckMissingCoordinates, Field: "coordinates", Message: "missing coordinates"},
+			{PortID: "AEFJR", Check: quality.CheckInvalidTimezone, Field: "timezone", Message: "missing timezone"},
+			{PortID: "AEJEA", Check: quality.CheckDuplicateName, Field: "name", Message: "name 'Dubai' is shared with AEDXB in Dubai"},
+			{PortID: "AEKLF", Check: quality.CheckSwappedCoordinates, Field: "coordinates", Message: "coordinates [25.33, 56.35] are far from the other United Arab Emirates ports, unlike [56.35, 25.33]"},
+			{PortID: "AEPRA", Check: quality.CheckSwappedCoordinates, Field: "coordinates", Message: "latitude 155.27 out of range, while longitude 25.27 would be a valid one"},
+			{PortID: "AEPRA", Check: quality.CheckInvalidTimezone, Field: "timezone", Message: "unknown timezone 'Asia/Nowhere'"},
+			{PortID: "AEQIW", Check: quality.CheckCountryMismatch, Field: "unlocs", Message: "UN/LOCODE OMQIW doesn't match the United Arab Emirates country code AE"},
+			{PortID: "XXABC", Check: quality.CheckUnknownCountry, Field: "country", Message: "unknown country 'Atlantis'"},
+		}, r.Issues)
+	})
+}
+
+func TestReport_WriteHTML(t *testing.T) {
+	broken := uaePort("AEAUH", "Abu Dhabi <script>", 54.37, 24.47)
+	broken.Timezone = ""
+
+	r := quality.Analyse(domain.Ports{broken})
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteHTML(&buf))
+
+	html := buf.String()
+	assert.Contains(t, html, "1 of 1 ports with issues")
+	assert.Contains(t, html, "<td>invalid-timezone</td><td class=\"count\">1</td>")
+	assert.Contains(t, html, "<td>AEAUH</td><td>invalid-timezone</td><td>timezone</td><td>missing timezone</td>")
+	assert.NotContains(t, html, "<script>")
+}
@@ -0,0 +1,325 @@
+// Package routing computes sea routes over a network of shipping lanes.
+package routing
+
+import (
+	"container/heap"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/rafaeltg/goports/pkg/geo"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// snapCount is the maximum number of waypoints the ends of a route are
+	// joined to.
+	snapCount = 3
+	// snapSlackNM is how much farther than the nearest waypoint, in nautical
+	// miles, the other joined waypoints may be. Farther ones are likely on
+	// the other side of some land, even when they are within twice the
+	// distance to the nearest one.
+	snapSlackNM = 50
+)
+
+//go:embed lanes.yaml
+var lanesYAML []byte
+
+var defaultGraph = sync.OnceValue(func() *Graph {
+	g, err := Parse(lanesYAML)
+	if err != nil {
+		panic(fmt.Sprintf("routing: invalid embedded lanes: %s", err))
+	}
+
+	return g
+})
+
+type (
+	// Waypoint is a point of the shipping lanes network, like a strait or
+	// a cape.
+	Waypoint struct {
+		ID   string  `yaml:"id"`
+		Name string  `yaml:"name"`
+		Lat  float64 `yaml:"lat"`
+		Lon  float64 `yaml:"lon"`
+	}
+
+	// Graph is a network of waypoints joined by shipping lanes, sailed along
+	// great circles.
+	Graph struct {
+		waypoints []Waypoint
+		edges     [][]edge
+	}
+
+	// Route is the shortest sea route between two points.
+	Route struct {
+		// DistanceNM is the length of the route, in nautical miles.
+		DistanceNM float64
+		// Waypoints holds the IDs of the waypoints sailed through.
+		Waypoints []string
+		// Path holds the points of the route, from its start to its end.
+		// Longitudes are unwrapped, so that a route crossing the
+		// antimeridian has longitudes beyond ±180 instead of jumping across
+		// the whole map.
+		Path []geo.Point
+	}
+
+	edge struct {
+		to int
+		nm float64
+	}
+
+	lanesFile struct {
+		Waypoints []Waypoint `yaml:"waypoints"`
+		Lanes     [][]string `yaml:"lanes"`
+	}
+)
+
+// Default returns the graph of the embedded shipping lanes network, which
+// covers the main lanes, straits and canals of the world.
+func Default() *Graph {
+	return defaultGraph()
+}
+
+// Parse parses a YAML shipping lanes network, made of waypoints and of the
+// lanes joining pairs of them:
+//
+//	waypoints:
+//	  - {id: dover, name: Strait of Dover, lat: 51.0, lon: 1.45}
+//	  - {id: english-channel, name: English Channel, lat: 50.0, lon: -2.0}
+//	lanes:
+//	  - [dover, english-channel]
+func Parse(b []byte) (*Graph, error) {
+	var f lanesFile
+
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("invalid lanes: %w", err)
+	}
+
+	g := &Graph{
+		waypoints: f.Waypoints,
+		edges:     make([][]edge, len(f.Waypoints)),
+	}
+
+	index := make(map[string]int, len(f.Waypoints))
+
+	var errs []error
+
+	for idx, w := range f.Waypoints {
+		switch {
+		case w.ID == "":
+			errs = append(errs, fmt.Errorf("waypoint %d: missing id", idx))
+		case w.Lat < -90 || w.Lat > 90 || w.Lon < -180 || w.Lon > 180:
+			errs = append(errs, fmt.Errorf("waypoint '%s': coordinates out of range", w.ID))
+		}
+
+		if _, ok := index[w.ID]; ok {
+			errs = append(errs, fmt.Errorf("waypoint '%s': duplicated id", w.ID))
+		}
+
+		index[w.ID] = idx
+	}
+
+	for idx, lane := range f.Lanes {
+		if len(lane) != 2 {
+			errs = append(errs, fmt.Errorf("lane %d: must join 2 waypoints, got %d", idx, len(lane)))
+			continue
+		}
+
+		a, aOK := index[lane[0]]
+		b, bOK := index[lane[1]]
+
+		if !aOK || !bOK {
+			errs = append(errs, fmt.Errorf("lane %d: unknown waypoint", idx))
+			continue
+		}
+
+		nm := geo.DistanceNM(g.waypoints[a].point(), g.waypoints[b].point())
+		g.edges[a] = append(g.edges[a], edge{to: b, nm: nm})
+		g.edges[b] = append(g.edges[b], edge{to: a, nm: nm})
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid lanes: %w", err)
+	}
+
+	return g, nil
+}
+
+// Waypoints returns the waypoints of the network.
+func (g *Graph) Waypoints() []Waypoint {
+	return g.waypoints
+}
+
+// Route returns the shortest sea route between two points, found with A*.
+// Both ends are snapped to their nearest waypoints, and are sailed to
+// directly when they are closer to each other than to the network. It
+// returns false when the ends are not connected by the network.
+func (g *Graph) Route(from, to geo.Point) (*Route, bool) {
+	n := len(g.waypoints)
+	start, end := n, n+1
+
+	// the ends are added as extra nodes, the start being only left, and the
+	// end only reached
+	startEdges, startSnap := g.snap(from)
+	endEdges, endSnap := g.snap(to)
+
+	toEnd := make(map[int]float64, len(endEdges))
+	for _, e := range endEdges {
+		toEnd[e.to] = e.nm
+	}
+
+	if direct := geo.DistanceNM(from, to); direct <= math.Max(startSnap, endSnap) {
+		startEdges = append(startEdges, edge{to: end, nm: direct})
+	}
+
+	point := func(node int) geo.Point {
+		switch node {
+		case start:
+			return from
+		case end:
+			return to
+		default:
+			return g.waypoints[node].point()
+		}
+	}
+
+	dist := map[int]float64{start: 0}
+	prev := map[int]int{}
+	done := map[int]bool{}
+
+	queue := &nodeQueue{{node: start, priority: geo.DistanceNM(from, to)}}
+
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(queued).node
+		if done[current] {
+			continue
+		}
+
+		done[current] = true
+
+		if current == end {
+			return g.route(dist[end], prev, start, end, point), true
+		}
+
+		next := startEdges
+		if current != start {
+			next = g.edges[current]
+
+			if nm, ok := toEnd[current]; ok {
+				next = append(next[:len(next):len(next)], edge{to: end, nm: nm})
+			}
+		}
+
+		for _, e := range next {
+			d := dist[current] + e.nm
+
+			if old, ok := dist[e.to]; ok && old <= d {
+				continue
+			}
+
+			dist[e.to] = d
+			prev[e.to] = current
+
+			heap.Push(queue, queued{
+				node:     e.to,
+				priority: d + geo.DistanceNM(point(e.to), to),
+			})
+		}
+	}
+
+	return nil, false
+}
+
+// snap returns the edges from a point to its nearest waypoints, which are
+// the nearest one and those not much farther, along with the distance to
+// the nearest one.
+func (g *Graph) snap(p geo.Point) ([]edge, float64) {
+	edges := make([]edge, 0, len(g.waypoints))
+	for idx, w := range g.waypoints {
+		edges = append(edges, edge{to: idx, nm: geo.DistanceNM(p, w.point())})
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i].nm < edges[j].nm
+	})
+
+	if len(edges) == 0 {
+		return nil, 0
+	}
+
+	nearest := edges[0].nm
+	reach := math.Max(2*nearest, nearest+snapSlackNM)
+
+	n := 1
+	for n < min(snapCount, len(edges)) && edges[n].nm <= reach {
+		n++
+	}
+
+	return edges[:n], nearest
+}
+
+func (g *Graph) route(nm float64, prev map[int]int, start, end int, point func(int) geo.Point) *Route {
+	nodes := []int{end}
+	for node := end; node != start; {
+		node = prev[node]
+		nodes = append(nodes, node)
+	}
+
+	r := &Route{
+		DistanceNM: nm,
+		Path:       make([]geo.Point, 0, len(nodes)),
+	}
+
+	for idx := len(nodes) - 1; idx >= 0; idx-- {
+		node := nodes[idx]
+
+		if node != start && node != end {
+			r.Waypoints = append(r.Waypoints, g.waypoints[node].ID)
+		}
+
+		p := point(node)
+
+		if len(r.Path) > 0 {
+			last := r.Path[len(r.Path)-1].Lon
+			p.Lon += 360 * math.Round((last-p.Lon)/360)
+		}
+
+		r.Path = append(r.Path, p)
+	}
+
+	return r
+}
+
+func (w Waypoint) point() geo.Point {
+	return geo.Point{Lat: w.Lat, Lon: w.Lon}
+}
+
+type (
+	queued struct {
+		node     int
+		priority float64
+	}
+
+	// nodeQueue is a min-heap of nodes by priority.
+	nodeQueue []queued
+)
+
+func (q nodeQueue) Len() int           { return len(q) }
+func (q nodeQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q nodeQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *nodeQueue) Push(x any) {
+	*q = append(*q, x.(queued))
+}
+
+func (q *nodeQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return item
+}
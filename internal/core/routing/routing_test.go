@@ -0,0 +1,147 @@
+package routing_test
+
+import (
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/core/routing"
+	"github.com/rafaeltg/goports/pkg/geo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	jebelAli  = geo.Point{Lat: 25.01, Lon: 55.06}
+	rotterdam = geo.Point{Lat: 51.95, Lon: 4.05}
+	antwerp   = geo.Point{Lat: 51.23, Lon: 4.4}
+	shanghai  = geo.Point{Lat: 31.23, Lon: 121.8}
+	longBeach = geo.Point{Lat: 33.73, Lon: -118.26}
+	genoa     = geo.Point{Lat: 44.4, Lon: 8.92}
+	venice    = geo.Point{Lat: 45.44, Lon: 12.33}
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		lanes string
+		err   string
+	}{
+		"missing id": {
+			lanes: "waypoints: [{name: A, lat: 1, lon: 1}]",
+			err:   "waypoint 0: missing id",
+		},
+		"coordinates out of range": {
+			lanes: "waypoints: [{id: a, lat: 91, lon: 1}]",
+			err:   "waypoint 'a': coordinates out of range",
+		},
+		"duplicated id": {
+			lanes: "waypoints: [{id: a, lat: 1, lon: 1}, {id: a, lat: 2, lon: 2}]",
+			err:   "waypoint 'a': duplicated id",
+		},
+		"lane of a single waypoint": {
+			lanes: "waypoints: [{id: a, lat: 1, lon: 1}]\nlanes: [[a]]",
+			err:   "lane 0: must join 2 waypoints, got 1",
+		},
+		"unknown waypoint": {
+			lanes: "waypoints: [{id: a, lat: 1, lon: 1}]\nlanes: [[a, b]]",
+			err:   "lane 0: unknown waypoint",
+		},
+		"invalid YAML": {
+			lanes: "waypoints: {",
+			err:   "invalid lanes",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := routing.Parse([]byte(tc.lanes))
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestDefault(t *testing.T) {
+	g := routing.Default()
+
+	waypoints := g.Waypoints()
+	require.NotEmpty(t, waypoints)
+
+	from := geo.Point{Lat: waypoints[0].Lat, Lon: waypoints[0].Lon}
+
+	for _, w := range waypoints[1:] {
+		_, ok := g.Route(from, geo.Point{Lat: w.Lat, Lon: w.Lon})
+		assert.True(t, ok, "waypoint '%s' is not connected", w.ID)
+	}
+}
+
+func TestGraph_Route(t *testing.T) {
+	g := routing.Default()
+
+	t.Run("through the Suez Canal", func(t *testing.T) {
+		r, ok := g.Route(jebelAli, rotterdam)
+		require.True(t, ok)
+
+		assert.InDelta(t, 6500, r.DistanceNM, 300)
+		assert.Subset(t, r.Waypoints, []string{"hormuz", "bab-el-mandeb", "suez", "gibraltar", "dover"})
+		assert.Greater(t, r.DistanceNM, geo.DistanceNM(jebelAli, rotterdam))
+
+		require.Len(t, r.Path, len(r.Waypoints)+2)
+		assert.Equal(t, jebelAli, r.Path[0])
+		assert.Equal(t, rotterdam, r.Path[len(r.Path)-1])
+	})
+
+	t.Run("around a peninsula", func(t *testing.T) {
+		r, ok := g.Route(genoa, venice)
+		require.True(t, ok)
+
+		assert.Contains(t, r.Waypoints, "strait-of-sicily")
+		assert.Greater(t, r.DistanceNM, 1000.0)
+	})
+
+	t.Run("across the antimeridian", func(t *testing.T) {
+		r, ok := g.Route(shanghai, longBeach)
+		require.True(t, ok)
+
+		assert.InDelta(t, 5800, r.DistanceNM, 300)
+		assert.Contains(t, r.Waypoints, "north-pacific-dateline")
+
+		// longitudes are unwrapped, instead of jumping from 180 to -180
+		for idx := 1; idx < len(r.Path); idx++ {
+			assert.Less(t, r.Path[idx].Lon-r.Path[idx-1].Lon, 180.0)
+			assert.Greater(t, r.Path[idx].Lon-r.Path[idx-1].Lon, -180.0)
+		}
+
+		assert.InDelta(t, longBeach.Lon+360, r.Path[len(r.Path)-1].Lon, 1e-9)
+	})
+
+	t.Run("direct between nearby ports", func(t *testing.T) {
+		r, ok := g.Route(rotterdam, antwerp)
+		require.True(t, ok)
+
+		assert.Empty(t, r.Waypoints)
+		assert.InDelta(t, geo.DistanceNM(rotterdam, antwerp), r.DistanceNM, 1e-9)
+		assert.Equal(t, []geo.Point{rotterdam, antwerp}, r.Path)
+	})
+
+	t.Run("same point", func(t *testing.T) {
+		r, ok := g.Route(rotterdam, rotterdam)
+		require.True(t, ok)
+
+		assert.Zero(t, r.DistanceNM)
+	})
+
+	t.Run("not connected", func(t *testing.T) {
+		g, err := routing.Parse([]byte(`
+waypoints:
+  - {id: a, lat: 0, lon: 0}
+  - {id: b, lat: 0, lon: 1}
+  - {id: c, lat: 0, lon: 50}
+  - {id: d, lat: 0, lon: 51}
+lanes:
+  - [a, b]
+  - [c, d]
+`))
+		require.NoError(t, err)
+
+		_, ok := g.Route(geo.Point{Lat: 0.1, Lon: 0}, geo.Point{Lat: 0.1, Lon: 51})
+		assert.False(t, ok)
+	})
+}
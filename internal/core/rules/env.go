@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+)
+
+// newEnv returns the CEL environment the rule expressions are compiled on.
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("port", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Function("inBox",
+			cel.Overload("inBox_list_dyn_dyn_dyn_dyn",
+				[]*cel.Type{cel.ListType(cel.DynType), cel.DynType, cel.DynType, cel.DynType, cel.DynType},
+				cel.BoolType,
+				cel.FunctionBinding(inBox),
+			),
+		),
+	)
+}
+
+// compile compiles a boolean expression.
+func compile(env *cel.Env, expr string) (cel.Program, error) {
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+
+	if !ast.OutputType().IsExactType(cel.BoolType) && !ast.OutputType().IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("expression must be a boolean, got %s", ast.OutputType())
+	}
+
+	return env.Program(ast)
+}
+
+// inBox reports whether the coordinates, a [longitude, latitude] list, fall
+// within the box given by its min longitude, min latitude, max longitude and
+// max latitude. Missing coordinates are not within any box.
+func inBox(args ...ref.Val) ref.Val {
+	coordinates, ok := args[0].(traits.Lister)
+	if !ok {
+		return types.NewErr("inBox: coordinates must be a list")
+	}
+
+	if coordinates.Size() != types.Int(2) {
+		return types.False
+	}
+
+	values := make([]float64, 0, 6)
+
+	for idx := 0; idx < 2; idx++ {
+		v, err := toFloat(coordinates.Get(types.Int(idx)))
+		if err != nil {
+			return types.NewErr("inBox: %s", err)
+		}
+
+		values = append(values, v)
+	}
+
+	for _, arg := range args[1:] {
+		v, err := toFloat(arg)
+		if err != nil {
+			return types.NewErr("inBox: %s", err)
+		}
+
+		values = append(values, v)
+	}
+
+	lon, lat := values[0], values[1]
+	minLon, minLat, maxLon, maxLat := values[2], values[3], values[4], values[5]
+
+	return types.Bool(lon >= minLon && lon <= maxLon && lat >= minLat && lat <= maxLat)
+}
+
+func toFloat(v ref.Val) (float64, error) {
+	switch v := v.(type) {
+	case types.Double:
+		return float64(v), nil
+	case types.Int:
+		return float64(v), nil
+	case types.Uint:
+		return float64(v), nil
+	default:
+		return 0, errors.New("expected a number, got " + v.Type().TypeName())
+	}
+}
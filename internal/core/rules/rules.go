@@ -0,0 +1,273 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// SeverityError makes a failed rule to reject the port.
+	SeverityError Severity = "error"
+	// SeverityWarn makes a failed rule to be only reported.
+	SeverityWarn Severity = "warn"
+)
+
+type (
+	// Severity sets how a failed rule is handled.
+	Severity string
+
+	// Rule is a check over a port, declared as a CEL expression which must
+	// evaluate to true for the port to pass. The port is available as the
+	// "port" variable, keyed by its JSON field names:
+	//
+	//	port.countryCode != "AE" || port.province != ""
+	//	inBox(port.coordinates, 51.5, 22.6, 56.4, 26.1)
+	Rule struct {
+		ID          string   `yaml:"id"`
+		Description string   `yaml:"description"`
+		Expression  string   `yaml:"expression"`
+		Severity    Severity `yaml:"severity"`
+		// Message is the message reported when the rule fails. It defaults
+		// to the description or, when missing, to the expression.
+		Message string `yaml:"message"`
+	}
+
+	// Violation is a rule failed by a port.
+	Violation struct {
+		RuleID   string   `json:"rule"`
+		Severity Severity `json:"severity"`
+		PortID   string   `json:"portId,omitempty"`
+		Message  string   `json:"message"`
+	}
+
+	// ViolationError holds the error severity violations which made ports
+	// to be rejected.
+	ViolationError struct {
+		Violations []Violation
+	}
+
+	// Engine evaluates a set of rules over ports. It is safe for concurrent use.
+	Engine struct {
+		rules []compiledRule
+	}
+
+	compiledRule struct {
+		Rule
+		prg cel.Program
+	}
+
+	file struct {
+		Rules []Rule `yaml:"rules"`
+	}
+)
+
+// New compiles the given rules into an Engine.
+func New(rules []Rule) (*Engine, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Engine{rules: make([]compiledRule, 0, len(rules))}
+	ids := make(map[string]bool, len(rules))
+
+	var errs []error
+
+	for _, r := range rules {
+		if err := r.validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if ids[r.ID] {
+			errs = append(errs, fmt.Errorf("%s: duplicated rule id", r.ID))
+			continue
+		}
+
+		ids[r.ID] = true
+
+		if r.Severity == "" {
+			r.Severity = SeverityError
+		}
+
+		prg, err := compile(env, r.Expression)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.ID, err))
+			continue
+		}
+
+		e.rules = append(e.rules, compiledRule{Rule: r, prg: prg})
+	}
+
+	if err = errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Load loads the rules from a YAML, or JSON, file holding a list of rules:
+//
+//	rules:
+//	  - id: province-required
+//	    description: ports in the United Arab Emirates must have a province
+//	    expression: port.country != "United Arab Emirates" || port.province != ""
+//	    severity: warn
+func Load(path string) (*Engine, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	var f file
+
+	if err = yaml.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("invalid rules file: %w", err)
+	}
+
+	e, err := New(f.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rules file: %w", err)
+	}
+
+	return e, nil
+}
+
+func (r *Rule) validate() error {
+	if strings.TrimSpace(r.ID) == "" {
+		return fmt.Errorf("rule with expression '%s': missing id", r.Expression)
+	}
+
+	if strings.TrimSpace(r.Expression) == "" {
+		return fmt.Errorf("%s: missing expression", r.ID)
+	}
+
+	switch r.Severity {
+	case "", SeverityError, SeverityWarn:
+	default:
+		return fmt.Errorf("%s: unknown severity '%s'", r.ID, r.Severity)
+	}
+
+	return nil
+}
+
+func (r *Rule) message() string {
+	switch {
+	case r.Message != "":
+		return r.Message
+	case r.Description != "":
+		return r.Description
+	default:
+		return "failed " + r.Expression
+	}
+}
+
+// Evaluate evaluates all the rules over a port, returning the failed ones.
+// A rule whose evaluation fails, e.g. on indexing an empty list, is
+// reported as failed too.
+func (e *Engine) Evaluate(p *domain.Port) []Violation {
+	vars := map[string]any{"port": portVar(p)}
+
+	var violations []Violation
+
+	for _, r := range e.rules {
+		msg := ""
+
+		out, _, err := r.prg.Eval(vars)
+		switch {
+		case err != nil:
+			msg = fmt.Sprintf("%s: %s", r.message(), err)
+		case out.Value() != true:
+			msg = r.message()
+		default:
+			continue
+		}
+
+		violations = append(violations, Violation{
+			RuleID:   r.ID,
+			Severity: r.Severity,
+			PortID:   p.ID,
+			Message:  msg,
+		})
+	}
+
+	return violations
+}
+
+// Check evaluates all the rules over the ports, returning a *ViolationError
+// with the error severity violations, if any, and the warnings.
+func (e *Engine) Check(ports domain.Ports) (warnings []Violation, err error) {
+	var errs []Violation
+
+	for idx := range ports {
+		for _, v := range e.Evaluate(&ports[idx]) {
+			if v.Severity == SeverityWarn {
+				warnings = append(warnings, v)
+			} else {
+				errs = append(errs, v)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return warnings, &ViolationError{Violations: errs}
+	}
+
+	return warnings, nil
+}
+
+func (v Violation) Error() string {
+	if v.PortID == "" {
+		return fmt.Sprintf("%s: %s", v.RuleID, v.Message)
+	}
+
+	return fmt.Sprintf("port '%s': %s: %s", v.PortID, v.RuleID, v.Message)
+}
+
+func (e *ViolationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.Error())
+	}
+
+	return "rules violated: " + strings.Join(msgs, "; ")
+}
+
+// portVar returns the port as the value of the "port" variable. Empty
+// lists are kept, so that expressions may rely on them.
+func portVar(p *domain.Port) map[string]any {
+	list := func(l []string) []string {
+		if l == nil {
+			return []string{}
+		}
+
+		return l
+	}
+
+	coordinates := []float64(p.Coordinates)
+	if coordinates == nil {
+		coordinates = []float64{}
+	}
+
+	return map[string]any{
+		"id":          p.ID,
+		"name":        p.Name,
+		"city":        p.City,
+		"country":     p.Country,
+		"countryCode": p.CountryCode,
+		"alias":       list(p.Alias),
+		"regions":     list(p.Regions),
+		"coordinates": coordinates,
+		"province":    p.Province,
+		"timezone":    p.Timezone,
+		"unlocs":      list(p.Unlocs),
+		"code":        p.Code,
+	}
+}
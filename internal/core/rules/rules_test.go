@@ -0,0 +1,182 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		e, err := rules.Load("testdata/rules.yaml")
+		require.NoError(t, err)
+		assert.NotNil(t, e)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := rules.Load("testdata/missing.yaml")
+		assert.ErrorContains(t, err, "failed to read rules file")
+	})
+}
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		rules []rules.Rule
+		err   string
+	}{
+		"missing id": {
+			rules: []rules.Rule{{Expression: "true"}},
+			err:   "rule with expression 'true': missing id",
+		},
+		"missing expression": {
+			rules: []rules.Rule{{ID: "r1"}},
+			err:   "r1: missing expression",
+		},
+		"unknown severity": {
+			rules: []rules.Rule{{ID: "r1", Expression: "true", Severity: "fatal"}},
+			err:   "r1: unknown severity 'fatal'",
+		},
+		"duplicated id": {
+			rules: []rules.Rule{{ID: "r1", Expression: "true"}, {ID: "r1", Expression: "false"}},
+			err:   "r1: duplicated rule id",
+		},
+		"non boolean expression": {
+			rules: []rules.Rule{{ID: "r1", Expression: "size(port.unlocs)"}},
+			err:   "r1: expression must be a boolean",
+		},
+		"syntax error": {
+			rules: []rules.Rule{{ID: "r1", Expression: "port.name =="}},
+			err:   "r1: ",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := rules.New(tc.rules)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestEngine_Evaluate(t *testing.T) {
+	e, err := rules.Load("testdata/rules.yaml")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		port       domain.Port
+		violations []rules.Violation
+	}{
+		"valid": {
+			port: domain.Port{
+				ID:          "AEAJM",
+				Country:     "United Arab Emirates",
+				Province:    "Ajman",
+				Coordinates: []float64{55.5136433, 25.4052165},
+				Unlocs:      []string{"AEAJM"},
+			},
+		},
+		"other country": {
+			port: domain.Port{
+				ID:          "BRSSZ",
+				Country:     "Brazil",
+				Coordinates: []float64{-46.33, -23.96},
+				Unlocs:      []string{"BRSSZ"},
+			},
+		},
+		"violations": {
+			port: domain.Port{
+				ID:          "AEXXX",
+				Country:     "United Arab Emirates",
+				Coordinates: []float64{-46.33, -23.96},
+			},
+			violations: []rules.Violation{
+				{
+					RuleID:   "uae-province",
+					Severity: rules.SeverityError,
+					PortID:   "AEXXX",
+					Message:  "ports in the United Arab Emirates must have a province",
+				},
+				{
+					RuleID:   "uae-bbox",
+					Severity: rules.SeverityError,
+					PortID:   "AEXXX",
+					Message:  "coordinates must fall within the United Arab Emirates",
+				},
+				{
+					RuleID:   "has-unloc",
+					Severity: rules.SeverityWarn,
+					PortID:   "AEXXX",
+					Message:  "port has no UN/LOCODE",
+				},
+			},
+		},
+		"missing coordinates": {
+			port: domain.Port{
+				ID:       "AEXXX",
+				Country:  "United Arab Emirates",
+				Province: "Ajman",
+				Unlocs:   []string{"AEXXX"},
+			},
+			violations: []rules.Violation{
+				{
+					RuleID:   "uae-bbox",
+					Severity: rules.SeverityError,
+					PortID:   "AEXXX",
+					Message:  "coordinates must fall within the United Arab Emirates",
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.violations, e.Evaluate(&tc.port))
+		})
+	}
+
+	t.Run("evaluation error", func(t *testing.T) {
+		e, err := rules.New([]rules.Rule{{ID: "lon", Expression: "port.coordinates[0] > 0.0"}})
+		require.NoError(t, err)
+
+		violations := e.Evaluate(&domain.Port{ID: "ABC"})
+		require.Len(t, violations, 1)
+		assert.Contains(t, violations[0].Message, "failed port.coordinates[0] > 0.0: ")
+	})
+}
+
+func TestEngine_Check(t *testing.T) {
+	e, err := rules.Load("testdata/rules.yaml")
+	require.NoError(t, err)
+
+	warnings, err := e.Check(domain.Ports{
+		{ID: "BRSSZ", Country: "Brazil"},
+		{ID: "AEXXX", Country: "United Arab Emirates", Province: "Ajman", Coordinates: []float64{0, 0}},
+	})
+
+	assert.EqualError(t, err, "rules violated: port 'AEXXX': uae-bbox: coordinates must fall within the United Arab Emirates")
+	assert.Len(t, warnings, 2)
+}
+
+func TestEngine_Evaluate_CountryCode(t *testing.T) {
+	e, err := rules.New([]rules.Rule{
+		{
+			ID:         "ae-province",
+			Expression: `port.countryCode != "AE" || port.province != ""`,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, e.Evaluate(&domain.Port{ID: "BRSSZ", CountryCode: "BR"}))
+	assert.Empty(t, e.Evaluate(&domain.Port{ID: "AEAJM", CountryCode: "AE", Province: "Ajman"}))
+	assert.Equal(t, []rules.Violation{
+		{
+			RuleID:   "ae-province",
+			Severity: rules.SeverityError,
+			PortID:   "AEXXX",
+			Message:  `failed port.countryCode != "AE" || port.province != ""`,
+		},
+	}, e.Evaluate(&domain.Port{ID: "AEXXX", CountryCode: "AE"}))
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/geo"
+)
+
+// distanceMatrixMaxCellsDefault is the largest number of distances of a
+// distance matrix by default, like 500 origins by 500 destinations.
+const distanceMatrixMaxCellsDefault = 250_000
+
+// DistanceMatrix returns the distances between each origin port and each
+// destination port, computed from their coordinates and rounded to metres.
+// It fails with a *port.UnlocatedPortsError listing all the ports not found,
+// or without coordinates, and with port.ErrDistanceMatrixTooLarge when there
+// are more distances than allowed.
+func (svc *PortService) DistanceMatrix(ctx context.Context, req domain.DistanceMatrixRequest) (*domain.DistanceMatrix, error) {
+	svc.logger.DebugContext(ctx,
+		"[PortService.DistanceMatrix] executing",
+		slog.Int("origins", len(req.Origins)),
+		slog.Int("destinations", len(req.Destinations)),
+		slog.String("method", string(req.Method)),
+	)
+
+	if len(req.Origins) == 0 || len(req.Destinations) == 0 {
+		return nil, port.ErrEmptyDistanceMatrix
+	}
+
+	if cells := len(req.Origins) * len(req.Destinations); cells > svc.distanceMatrixMaxCells {
+		return nil, fmt.Errorf("%w: %d distances requested, at most %d allowed",
+			port.ErrDistanceMatrixTooLarge, cells, svc.distanceMatrixMaxCells)
+	}
+
+	method := req.Method
+	if method == "" {
+		method = domain.DistanceHaversine
+	}
+
+	points, err := svc.locate(ctx, append(req.Origins[:len(req.Origins):len(req.Origins)], req.Destinations...))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &domain.DistanceMatrix{
+		Origins:      req.Origins,
+		Destinations: req.Destinations,
+		Method:       method,
+		Distances:    make([][]domain.Distance, len(req.Origins)),
+	}
+
+	// rows are computed concurrently, each worker taking the next one left,
+	// until the context is done
+	rows := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < min(runtime.GOMAXPROCS(0), len(req.Origins)); w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range rows {
+				m.Distances[i] = distanceRow(points[req.Origins[i]], req.Destinations, points, method)
+			}
+		}()
+	}
+
+	for i := 0; i < len(req.Origins) && ctx.Err() == nil; i++ {
+		select {
+		case <-ctx.Done():
+		case rows <- i:
+		}
+	}
+
+	close(rows)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// locate returns the points of the ports with the given IDs, by ID.
+func (svc *PortService) locate(ctx context.Context, ids []string) (map[string]geo.Point, error) {
+	points := make(map[string]geo.Point, len(ids))
+	unlocated := &port.UnlocatedPortsError{}
+	seen := make(map[string]bool, len(ids))
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+
+		seen[id] = true
+
+		p, err := svc.productRepo.Get(ctx, id)
+
+		switch {
+		case err == port.ErrPortNotFound:
+			unlocated.NotFound = append(unlocated.NotFound, id)
+		case err != nil:
+			return nil, err
+		case !p.Coordinates.Valid():
+			unlocated.NoCoordinates = append(unlocated.NoCoordinates, id)
+		default:
+			points[id] = geo.Point{Lat: p.Coordinates.Lat(), Lon: p.Coordinates.Lon()}
+		}
+	}
+
+	if len(unlocated.NotFound) > 0 || len(unlocated.NoCoordinates) > 0 {
+		return nil, unlocated
+	}
+
+	return points, nil
+}
+
+func distanceRow(from geo.Point, destinations []string, points map[string]geo.Point, method domain.DistanceMethod) []domain.Distance {
+	row := make([]domain.Distance, len(destinations))
+
+	for j, id := range destinations {
+		to := points[id]
+
+		km := geo.DistanceKm(from, to)
+
+		if method == domain.DistanceVincenty {
+			// nearly antipodal points keep their haversine distance
+			if v, ok := geo.VincentyKm(from, to); ok {
+				km = v
+			}
+		}
+
+		// rounded to metres, far below the accuracy of port coordinates
+		row[j] = domain.Distance{
+			Km: math.Round(km*1000) / 1000,
+			NM: math.Round(km/geo.KmPerNauticalMile*1000) / 1000,
+		}
+	}
+
+	return row
+}
+
+// WithDistanceMatrixMaxCells sets the largest number of distances, origins
+// times destinations, of a distance matrix.
+func WithDistanceMatrixMaxCells(cells int) PortServiceOption {
+	return func(svc *PortService) {
+		svc.distanceMatrixMaxCells = cells
+	}
+}
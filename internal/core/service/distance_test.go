@@ -0,0 +1,175 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/rafaeltg/goports/internal/core/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortService_DistanceMatrix(t *testing.T) {
+	ports := map[string]*domain.Port{
+		"AEAJM": {ID: "AEAJM", Name: "Ajman", Coordinates: domain.NewCoordinates(25.4052165, 55.5136433)},
+		"AEDXB": {ID: "AEDXB", Name: "Dubai", Coordinates: domain.NewCoordinates(25.2048, 55.2708)},
+		"BRSSZ": {ID: "BRSSZ", Name: "Santos", Coordinates: domain.NewCoordinates(-23.9608, -46.3336)},
+		"XXXXX": {ID: "XXXXX", Name: "Nowhere"},
+	}
+
+	newRepo := func(ctrl *gomock.Controller) *porttest.MockPortRepository {
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			Get(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, id string) (*domain.Port, error) {
+				if p, ok := ports[id]; ok {
+					return p, nil
+				}
+
+				return nil, port.ErrPortNotFound
+			}).
+			AnyTimes()
+
+		return mockedPortRepo
+	}
+
+	t.Run("haversine", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := service.NewPortService(newRepo(ctrl), loggerTest)
+
+		m, err := svc.DistanceMatrix(context.Background(), domain.DistanceMatrixRequest{
+			Origins:      []string{"AEAJM", "AEDXB"},
+			Destinations: []string{"AEDXB", "BRSSZ", "AEAJM"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.DistanceHaversine, m.Method)
+		assert.Equal(t, []string{"AEAJM", "AEDXB"}, m.Origins)
+		assert.Equal(t, []string{"AEDXB", "BRSSZ", "AEAJM"}, m.Destinations)
+
+		require.Len(t, m.Distances, 2)
+		require.Len(t, m.Distances[0], 3)
+		require.Len(t, m.Distances[1], 3)
+
+		assert.Equal(t, 33.054, m.Distances[0][0].Km)
+		assert.Equal(t, 17.848, m.Distances[0][0].NM)
+		assert.InDelta(t, 12243, m.Distances[0][1].Km, 1)
+		assert.Zero(t, m.Distances[0][2].Km)
+		assert.Zero(t, m.Distances[1][0].Km)
+		assert.Equal(t, m.Distances[0][0], m.Distances[1][2])
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := service.NewPortService(newRepo(ctrl), loggerTest)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		m, err := svc.DistanceMatrix(ctx, domain.DistanceMatrixRequest{
+			Origins:      []string{"AEAJM", "AEDXB"},
+			Destinations: []string{"BRSSZ"},
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, m)
+	})
+
+	t.Run("vincenty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := service.NewPortService(newRepo(ctrl), loggerTest)
+
+		req := domain.DistanceMatrixRequest{
+			Origins:      []string{"AEAJM"},
+			Destinations: []string{"BRSSZ"},
+		}
+
+		haversine, err := svc.DistanceMatrix(context.Background(), req)
+		require.NoError(t, err)
+
+		req.Method = domain.DistanceVincenty
+
+		vincenty, err := svc.DistanceMatrix(context.Background(), req)
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.DistanceVincenty, vincenty.Method)
+		assert.NotEqual(t, haversine.Distances[0][0].Km, vincenty.Distances[0][0].Km)
+		assert.InDelta(t, haversine.Distances[0][0].Km, vincenty.Distances[0][0].Km, 0.005*haversine.Distances[0][0].Km)
+	})
+
+	t.Run("unlocated ports", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := service.NewPortService(newRepo(ctrl), loggerTest)
+
+		_, err := svc.DistanceMatrix(context.Background(), domain.DistanceMatrixRequest{
+			Origins:      []string{"AEAJM", "NLXXX", "XXXXX"},
+			Destinations: []string{"XXXXX", "BRXXX"},
+		})
+
+		var uErr *port.UnlocatedPortsError
+		require.ErrorAs(t, err, &uErr)
+		assert.Equal(t, []string{"NLXXX", "BRXXX"}, uErr.NotFound)
+		assert.Equal(t, []string{"XXXXX"}, uErr.NoCoordinates)
+		assert.EqualError(t, err, "ports not found: NLXXX, BRXXX; ports without coordinates: XXXXX")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			Get(gomock.Any(), "AEAJM").
+			Return(nil, errors.New("get err"))
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		_, err := svc.DistanceMatrix(context.Background(), domain.DistanceMatrixRequest{
+			Origins:      []string{"AEAJM"},
+			Destinations: []string{"AEDXB"},
+		})
+		assert.EqualError(t, err, "get err")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := service.NewPortService(porttest.NewMockPortRepository(ctrl), loggerTest)
+
+		_, err := svc.DistanceMatrix(context.Background(), domain.DistanceMatrixRequest{
+			Origins: []string{"AEAJM"},
+		})
+		assert.ErrorIs(t, err, port.ErrEmptyDistanceMatrix)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := service.NewPortService(
+			porttest.NewMockPortRepository(ctrl),
+			loggerTest,
+			service.WithDistanceMatrixMaxCells(4),
+		)
+
+		_, err := svc.DistanceMatrix(context.Background(), domain.DistanceMatrixRequest{
+			Origins:      []string{"AEAJM", "AEDXB"},
+			Destinations: []string{"AEAJM", "AEDXB", "BRSSZ"},
+		})
+		assert.ErrorIs(t, err, port.ErrDistanceMatrixTooLarge)
+		assert.EqualError(t, err, "distance matrix too large: 6 distances requested, at most 4 allowed")
+	})
+}
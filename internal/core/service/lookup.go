@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+)
+
+// ByUnloc returns the port with the given UN/LOCODE. UN/LOCODEs claimed by
+// several ports are rejected with a *port.AmbiguousPortError.
+func (svc *PortService) ByUnloc(ctx context.Context, unloc string) (*domain.Port, error) {
+	svc.logger.DebugContext(ctx,
+		"[PortService.ByUnloc] executing",
+		slog.String("unloc", unloc),
+	)
+
+	return svc.findByKey(ctx, domain.PortKeyUnloc, unloc)
+}
+
+// ByCode returns the port with the given customs code. Codes claimed by
+// several ports are rejected with a *port.AmbiguousPortError.
+func (svc *PortService) ByCode(ctx context.Context, code string) (*domain.Port, error) {
+	svc.logger.DebugContext(ctx,
+		"[PortService.ByCode] executing",
+		slog.String("code", code),
+	)
+
+	return svc.findByKey(ctx, domain.PortKeyCode, code)
+}
+
+// Resolve returns the port known by q, tried as its ID, then as one of its
+// UN/LOCODEs, its customs code and one of its aliases, in that order. The
+// first key q is claimed on decides, so that values claimed by several ports
+// are rejected with a *port.AmbiguousPortError.
+func (svc *PortService) Resolve(ctx context.Context, q string) (*domain.Port, error) {
+	svc.logger.DebugContext(ctx,
+		"[PortService.Resolve] executing",
+		slog.String("q", q),
+	)
+
+	p, err := svc.productRepo.Get(ctx, q)
+	if !errors.Is(err, port.ErrPortNotFound) {
+		return p, err
+	}
+
+	for _, k := range domain.PortKeys() {
+		p, err := svc.findByKey(ctx, k, q)
+		if !errors.Is(err, port.ErrPortNotFound) {
+			return p, err
+		}
+	}
+
+	return nil, port.ErrPortNotFound
+}
+
+func (svc *PortService) findByKey(ctx context.Context, key domain.PortKey, value string) (*domain.Port, error) {
+	ports, err := svc.productRepo.FindByKey(ctx, key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(ports) {
+	case 0:
+		return nil, port.ErrPortNotFound
+	case 1:
+		return &ports[0], nil
+	default:
+		ids := make([]string, 0, len(ports))
+		for idx := range ports {
+			ids = append(ids, ports[idx].ID)
+		}
+
+		return nil, &port.AmbiguousPortError{Key: key, Value: value, Ports: ids}
+	}
+}
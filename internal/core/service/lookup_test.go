@@ -0,0 +1,223 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/rafaeltg/goports/internal/core/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortService_ByUnloc(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			FindByKey(gomock.Any(), domain.PortKeyUnloc, "USLAX").
+			Return(domain.Ports{{ID: "USLGB"}}, nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		p, err := svc.ByUnloc(context.Background(), "USLAX")
+		require.NoError(t, err)
+		assert.Equal(t, "USLGB", p.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			FindByKey(gomock.Any(), domain.PortKeyUnloc, "NLXXX").
+			Return(domain.Ports{}, nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		_, err := svc.ByUnloc(context.Background(), "NLXXX")
+		assert.ErrorIs(t, err, port.ErrPortNotFound)
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			FindByKey(gomock.Any(), domain.PortKeyUnloc, "CNDLC").
+			Return(domain.Ports{{ID: "CNDAL"}, {ID: "CNDLC"}}, nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		_, err := svc.ByUnloc(context.Background(), "CNDLC")
+
+		var aErr *port.AmbiguousPortError
+		require.ErrorAs(t, err, &aErr)
+		assert.Equal(t, []string{"CNDAL", "CNDLC"}, aErr.Ports)
+		assert.EqualError(t, err, "unloc 'CNDLC' is claimed by several ports: CNDAL, CNDLC")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			FindByKey(gomock.Any(), domain.PortKeyUnloc, "USLAX").
+			Return(nil, errors.New("find err"))
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		_, err := svc.ByUnloc(context.Background(), "USLAX")
+		assert.EqualError(t, err, "find err")
+	})
+}
+
+func TestPortService_ByCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+	mockedPortRepo.EXPECT().
+		FindByKey(gomock.Any(), domain.PortKeyCode, "2709").
+		Return(domain.Ports{{ID: "USLGB"}}, nil)
+
+	svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+	p, err := svc.ByCode(context.Background(), "2709")
+	require.NoError(t, err)
+	assert.Equal(t, "USLGB", p.ID)
+}
+
+func TestPortService_Resolve(t *testing.T) {
+	t.Run("by id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			Get(gomock.Any(), "USLGB").
+			Return(&domain.Port{ID: "USLGB"}, nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		p, err := svc.Resolve(context.Background(), "USLGB")
+		require.NoError(t, err)
+		assert.Equal(t, "USLGB", p.ID)
+	})
+
+	t.Run("by alias", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		gomock.InOrder(
+			mockedPortRepo.EXPECT().
+				Get(gomock.Any(), "long beach").
+				Return(nil, port.ErrPortNotFound),
+			mockedPortRepo.EXPECT().
+				FindByKey(gomock.Any(), domain.PortKeyUnloc, "long beach").
+				Return(domain.Ports{}, nil),
+			mockedPortRepo.EXPECT().
+				FindByKey(gomock.Any(), domain.PortKeyCode, "long beach").
+				Return(domain.Ports{}, nil),
+			mockedPortRepo.EXPECT().
+				FindByKey(gomock.Any(), domain.PortKeyAlias, "long beach").
+				Return(domain.Ports{{ID: "USLGB"}}, nil),
+		)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		p, err := svc.Resolve(context.Background(), "long beach")
+		require.NoError(t, err)
+		assert.Equal(t, "USLGB", p.ID)
+	})
+
+	t.Run("wrapped not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		gomock.InOrder(
+			mockedPortRepo.EXPECT().
+				Get(gomock.Any(), "long beach").
+				Return(nil, fmt.Errorf("failed to get port: %w", port.ErrPortNotFound)),
+			mockedPortRepo.EXPECT().
+				FindByKey(gomock.Any(), domain.PortKeyUnloc, "long beach").
+				Return(domain.Ports{{ID: "USLGB"}}, nil),
+		)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		p, err := svc.Resolve(context.Background(), "long beach")
+		require.NoError(t, err)
+		assert.Equal(t, "USLGB", p.ID)
+	})
+
+	t.Run("first claimed key decides", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			Get(gomock.Any(), "57000").
+			Return(nil, port.ErrPortNotFound)
+		mockedPortRepo.EXPECT().
+			FindByKey(gomock.Any(), domain.PortKeyUnloc, "57000").
+			Return(domain.Ports{}, nil)
+		mockedPortRepo.EXPECT().
+			FindByKey(gomock.Any(), domain.PortKeyCode, "57000").
+			Return(domain.Ports{{ID: "CNDAL"}, {ID: "CNDLC"}}, nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		_, err := svc.Resolve(context.Background(), "57000")
+
+		var aErr *port.AmbiguousPortError
+		require.ErrorAs(t, err, &aErr)
+		assert.Equal(t, domain.PortKeyCode, aErr.Key)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			Get(gomock.Any(), "nowhere").
+			Return(nil, port.ErrPortNotFound)
+		mockedPortRepo.EXPECT().
+			FindByKey(gomock.Any(), gomock.Any(), "nowhere").
+			Return(domain.Ports{}, nil).
+			Times(3)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		_, err := svc.Resolve(context.Background(), "nowhere")
+		assert.ErrorIs(t, err, port.ErrPortNotFound)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			Get(gomock.Any(), "USLGB").
+			Return(nil, errors.New("get err"))
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		_, err := svc.Resolve(context.Background(), "USLGB")
+		assert.EqualError(t, err, "get err")
+	})
+}
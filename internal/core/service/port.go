@@ -3,21 +3,45 @@ package service
 import (
 	"context"
 	"log/slog"
+	"strings"
 
 	"github.com/rafaeltg/goports/internal/core/domain"
 	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/quality"
+	"github.com/rafaeltg/goports/internal/core/routing"
+	"github.com/rafaeltg/goports/internal/core/rules"
+	"github.com/rafaeltg/goports/pkg/mvt"
 )
 
-type PortService struct {
-	productRepo port.PortRepository
-	logger      *slog.Logger
-}
+type (
+	PortService struct {
+		productRepo port.PortRepository
+		logger      *slog.Logger
+		rules       *rules.Engine
+		keepRaw     bool
+		tiles       *mvt.Cache
+		lanes       *routing.Graph
+
+		distanceMatrixMaxCells int
+	}
+
+	PortServiceOption func(*PortService)
+)
 
-func NewPortService(repo port.PortRepository, logger *slog.Logger) *PortService {
-	return &PortService{
+func NewPortService(repo port.PortRepository, logger *slog.Logger, opts ...PortServiceOption) *PortService {
+	svc := &PortService{
 		productRepo: repo,
 		logger:      logger,
+		tiles:       mvt.NewCache(tileCacheSizeDefault),
+
+		distanceMatrixMaxCells: distanceMatrixMaxCellsDefault,
+	}
+
+	for _, opt := range opts {
+		opt(svc)
 	}
+
+	return svc
 }
 
 func (svc *PortService) Get(ctx context.Context, id string) (*domain.Port, error) {
@@ -29,11 +53,193 @@ func (svc *PortService) Get(ctx context.Context, id string) (*domain.Port, error
 	return svc.productRepo.Get(ctx, id)
 }
 
+func (svc *PortService) List(ctx context.Context) (domain.Ports, error) {
+	svc.logger.DebugContext(ctx, "[PortService.List] executing")
+
+	return svc.productRepo.List(ctx)
+}
+
+// Each calls fn on every port, sorted by ID, stopping at the first error.
+func (svc *PortService) Each(ctx context.Context, fn func(*domain.Port) error) error {
+	svc.logger.DebugContext(ctx, "[PortService.Each] executing")
+
+	return svc.productRepo.Each(ctx, fn)
+}
+
+// BulkUpsert normalises and writes the ports. Ports failing validation are
+// rejected with a *domain.PortsValidationError.
 func (svc *PortService) BulkUpsert(ctx context.Context, ports domain.Ports) error {
 	svc.logger.DebugContext(ctx,
 		"[PortService.BulkUpsert] executing",
 		slog.Any("ports", ports),
 	)
 
-	return svc.productRepo.BulkUpsert(ctx, ports)
+	normalized := svc.normalize(ports)
+
+	if err := sentFields(ports, normalized).Validate(); err != nil {
+		return err
+	}
+
+	ports = normalized
+
+	if svc.rules != nil {
+		warnings, err := svc.rules.Check(ports)
+
+		for _, w := range warnings {
+			svc.logger.WarnContext(ctx,
+				"[PortService.BulkUpsert] rule violated",
+				slog.String("id", w.PortID),
+				slog.String("rule", w.RuleID),
+				slog.String("message", w.Message),
+			)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := svc.productRepo.BulkUpsert(ctx, ports); err != nil {
+		return err
+	}
+
+	svc.invalidateTiles(ports, nil)
+
+	return nil
+}
+
+// normalize returns a normalised copy of the ports, along with their raw
+// originals when set to keep them.
+func (svc *PortService) normalize(ports domain.Ports) domain.Ports {
+	normalized := make(domain.Ports, len(ports))
+
+	for idx, p := range ports {
+		p.Raw = nil
+		raw := p
+
+		if p.Normalize() && svc.keepRaw {
+			p.Raw = &raw
+		}
+
+		normalized[idx] = p
+	}
+
+	return normalized
+}
+
+// sentFields returns the normalised ports without the country codes derived
+// by the normalisation, so that only the codes sent are checked against the
+// country names and the UN/LOCODEs.
+func sentFields(sent, normalized domain.Ports) domain.Ports {
+	ports := make(domain.Ports, len(normalized))
+
+	for idx := range normalized {
+		ports[idx] = normalized[idx]
+
+		if strings.TrimSpace(sent[idx].CountryCode) == "" {
+			ports[idx].CountryCode = ""
+		}
+	}
+
+	return ports
+}
+
+func (svc *PortService) BulkDelete(ctx context.Context, ids []string) error {
+	svc.logger.DebugContext(ctx,
+		"[PortService.BulkDelete] executing",
+		slog.Any("ids", ids),
+	)
+
+	if err := svc.productRepo.BulkDelete(ctx, ids); err != nil {
+		return err
+	}
+
+	svc.invalidateTiles(nil, ids)
+
+	return nil
+}
+
+// Countries returns all the known countries, sorted by code, along with
+// their number of ports.
+func (svc *PortService) Countries(ctx context.Context) ([]domain.CountrySummary, error) {
+	svc.logger.DebugContext(ctx, "[PortService.Countries] executing")
+
+	ports, err := svc.productRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for idx := range ports {
+		counts[ports[idx].CountryCode]++
+	}
+
+	countries := domain.Countries()
+	summaries := make([]domain.CountrySummary, 0, len(countries))
+
+	for _, c := range countries {
+		summaries = append(summaries, domain.CountrySummary{
+			Country: c,
+			Ports:   counts[c.Code],
+		})
+	}
+
+	return summaries, nil
+}
+
+// CountryPorts returns the ports of the country with the given ISO 3166-1
+// alpha-2 code, sorted by ID.
+func (svc *PortService) CountryPorts(ctx context.Context, code string) (domain.Ports, error) {
+	svc.logger.DebugContext(ctx,
+		"[PortService.CountryPorts] executing",
+		slog.String("code", code),
+	)
+
+	c, ok := domain.CountryByCode(code)
+	if !ok {
+		return nil, port.ErrCountryNotFound
+	}
+
+	ports, err := svc.productRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	countryPorts := domain.Ports{}
+
+	for idx := range ports {
+		if ports[idx].CountryCode == c.Code {
+			countryPorts = append(countryPorts, ports[idx])
+		}
+	}
+
+	return countryPorts, nil
+}
+
+func (svc *PortService) Quality(ctx context.Context) (*quality.Report, error) {
+	svc.logger.DebugContext(ctx, "[PortService.Quality] executing")
+
+	ports, err := svc.productRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return quality.Analyse(ports), nil
+}
+
+// WithRules sets the rules the ports must pass to be written. Ports failing
+// error severity rules are rejected with a *rules.ViolationError, while
+// warnings are only logged.
+func WithRules(e *rules.Engine) PortServiceOption {
+	return func(svc *PortService) {
+		svc.rules = e
+	}
+}
+
+// WithKeepRaw sets whether the ports changed by the normalisation applied on
+// writes keep their raw original, as written, alongside the normalised one.
+func WithKeepRaw(keep bool) PortServiceOption {
+	return func(svc *PortService) {
+		svc.keepRaw = keep
+	}
 }
@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"log/slog"
 	"os"
@@ -10,9 +11,12 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/rafaeltg/goports/internal/core/domain"
 	"github.com/rafaeltg/goports/internal/core/domain/domaintest"
+	"github.com/rafaeltg/goports/internal/core/port"
 	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/rafaeltg/goports/internal/core/rules"
 	"github.com/rafaeltg/goports/internal/core/service"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var loggerTest = slog.New(slog.NewJSONHandler(os.Stdout, nil))
@@ -58,6 +62,86 @@ func TestPortService_Get(t *testing.T) {
 	})
 }
 
+func TestPortService_List(t *testing.T) {
+	tcs := []struct {
+		name          string
+		repoPorts     domain.Ports
+		repoError     error
+		expectedPorts domain.Ports
+		expectedErr   string
+	}{
+		{
+			name:        "database error",
+			repoError:   errors.New("list err"),
+			expectedErr: "list err",
+		},
+		{
+			name:          "empty",
+			repoPorts:     domain.Ports{},
+			expectedPorts: domain.Ports{},
+		},
+		{
+			name: "success",
+			repoPorts: domain.Ports{
+				{ID: "ABC", Name: "Test"},
+				{ID: "DEF", Name: "Test 1"},
+			},
+			expectedPorts: domain.Ports{
+				{ID: "ABC", Name: "Test"},
+				{ID: "DEF", Name: "Test 1"},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+			mockedPortRepo.EXPECT().
+				List(gomock.Any()).
+				Return(tc.repoPorts, tc.repoError)
+
+			svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+			ports, err := svc.List(context.Background())
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				assert.Nil(t, ports)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedPorts, ports)
+		})
+	}
+}
+
+func TestPortService_Each(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+	mockedPortRepo.EXPECT().
+		Each(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(*domain.Port) error) error {
+			return fn(&domain.Port{ID: "ABC"})
+		})
+
+	svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+	var ids []string
+
+	err := svc.Each(context.Background(), func(p *domain.Port) error {
+		ids = append(ids, p.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ABC"}, ids)
+}
+
 func TestPortService_BulkUpsert(t *testing.T) {
 	ports := domain.Ports{
 		{
@@ -105,4 +189,360 @@ func TestPortService_BulkUpsert(t *testing.T) {
 		err := svc.BulkUpsert(context.Background(), ports)
 		assert.NoError(t, err)
 	})
+
+	engine, err := rules.New([]rules.Rule{
+		{
+			ID:         "name-prefix",
+			Expression: `port.name.startsWith("Test")`,
+		},
+		{
+			ID:         "city-required",
+			Expression: `port.city != ""`,
+			Severity:   rules.SeverityWarn,
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("rules warnings", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			BulkUpsert(
+				gomock.Any(),
+				domaintest.PortsMatcher(ports),
+			).
+			Return(nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest, service.WithRules(engine))
+
+		err := svc.BulkUpsert(context.Background(), ports)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rules violated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest, service.WithRules(engine))
+
+		invalid := append(domain.Ports{{ID: "GHI", Name: "Other", City: "City"}}, ports...)
+
+		err := svc.BulkUpsert(context.Background(), invalid)
+
+		var vErr *rules.ViolationError
+		require.ErrorAs(t, err, &vErr)
+		assert.Equal(t, []rules.Violation{
+			{
+				RuleID:   "name-prefix",
+				Severity: rules.SeverityError,
+				PortID:   "GHI",
+				Message:  `failed port.name.startsWith("Test")`,
+			},
+		}, vErr.Violations)
+	})
+
+	t.Run("invalid ports", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest, service.WithRules(engine))
+
+		invalid := append(domain.Ports{{ID: "GHI", Name: "Test 2", CountryCode: "XX"}}, ports...)
+
+		err := svc.BulkUpsert(context.Background(), invalid)
+
+		var pvErr *domain.PortsValidationError
+		require.ErrorAs(t, err, &pvErr)
+		assert.Equal(t, []domain.InvalidPort{
+			{
+				ID: "GHI",
+				Errors: []domain.FieldError{
+					{Field: "countryCode", Message: "unknown country code 'XX'"},
+				},
+			},
+		}, pvErr.Ports)
+	})
+}
+
+func TestPortService_BulkUpsert_DerivedCountryCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	port := domain.Port{
+		ID:      "ANEUX",
+		Name:    "Sint Eustatius",
+		Country: "Netherlands",
+		Unlocs:  []string{"ANEUX"},
+	}
+
+	mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+	mockedPortRepo.EXPECT().
+		BulkUpsert(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, ports domain.Ports) error {
+			// derived from the UN/LOCODE, not sent
+			assert.Equal(t, "AN", ports[0].CountryCode)
+			return nil
+		})
+
+	svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+	err := svc.BulkUpsert(context.Background(), domain.Ports{port})
+	assert.NoError(t, err)
+
+	// the same code is rejected when sent
+	port.CountryCode = "AN"
+
+	err = svc.BulkUpsert(context.Background(), domain.Ports{port})
+
+	var pvErr *domain.PortsValidationError
+	require.ErrorAs(t, err, &pvErr)
+	assert.Equal(t, []domain.InvalidPort{
+		{
+			ID: "ANEUX",
+			Errors: []domain.FieldError{
+				{Field: "countryCode", Message: "'AN' doesn't match country 'Netherlands' (NL)"},
+			},
+		},
+	}, pvErr.Ports)
+}
+
+func TestPortService_BulkUpsert_InvalidCoordinates(t *testing.T) {
+	tcs := []struct {
+		name        string
+		coordinates string
+		expectedErr string
+	}{
+		{
+			name:        "array",
+			coordinates: `[500, 25.4052165]`,
+			expectedErr: "longitude 500 out of range",
+		},
+		{
+			name:        "object",
+			coordinates: `{"lat": 500, "lon": 55.5136433}`,
+			expectedErr: "latitude 500 out of range",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+
+			svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+			var ports domain.Ports
+			require.NoError(t, json.Unmarshal(
+				[]byte(`[{"id": "AEAJM", "name": "Ajman", "coordinates": `+tc.coordinates+`}]`),
+				&ports,
+			))
+
+			err := svc.BulkUpsert(context.Background(), ports)
+
+			var pvErr *domain.PortsValidationError
+			require.ErrorAs(t, err, &pvErr)
+			assert.Equal(t, []domain.InvalidPort{
+				{
+					ID: "AEAJM",
+					Errors: []domain.FieldError{
+						{Field: "coordinates", Message: tc.expectedErr},
+					},
+				},
+			}, pvErr.Ports)
+		})
+	}
+}
+
+func TestPortService_BulkUpsert_Normalize(t *testing.T) {
+	ports := domain.Ports{
+		{
+			ID:      "ABC",
+			Name:    " SANTOS ",
+			Country: "Brazil",
+		},
+		{
+			ID:      "DEF",
+			Name:    "Test",
+			Country: "Brazil",
+		},
+	}
+
+	normalized := domain.Ports{
+		{
+			ID:          "ABC",
+			Name:        "Santos",
+			Country:     "Brazil",
+			CountryCode: "BR",
+		},
+		{
+			ID:          "DEF",
+			Name:        "Test",
+			Country:     "Brazil",
+			CountryCode: "BR",
+		},
+	}
+
+	t.Run("normalized", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			BulkUpsert(gomock.Any(), normalized).
+			Return(nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		err := svc.BulkUpsert(context.Background(), ports)
+		assert.NoError(t, err)
+		assert.Equal(t, " SANTOS ", ports[0].Name)
+	})
+
+	t.Run("keep raw", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		withRaw := append(domain.Ports(nil), normalized...)
+		withRaw[0].Raw = &ports[0]
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			BulkUpsert(gomock.Any(), withRaw).
+			Return(nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest, service.WithKeepRaw(true))
+
+		err := svc.BulkUpsert(context.Background(), ports)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPortService_BulkDelete(t *testing.T) {
+	ids := []string{"ABC", "DEF"}
+
+	t.Run("database error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			BulkDelete(gomock.Any(), ids).
+			Return(errors.New("delete err"))
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		err := svc.BulkDelete(context.Background(), ids)
+		assert.EqualError(t, err, "delete err")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			BulkDelete(gomock.Any(), ids).
+			Return(nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		err := svc.BulkDelete(context.Background(), ids)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPortService_Countries(t *testing.T) {
+	t.Run("database error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			List(gomock.Any()).
+			Return(nil, errors.New("list err"))
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		countries, err := svc.Countries(context.Background())
+		assert.EqualError(t, err, "list err")
+		assert.Nil(t, countries)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			List(gomock.Any()).
+			Return(domain.Ports{
+				{ID: "AEAJM", CountryCode: "AE"},
+				{ID: "AEDXB", CountryCode: "AE"},
+				{ID: "BRSSZ", CountryCode: "BR"},
+			}, nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		countries, err := svc.Countries(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, countries, len(domain.Countries()))
+
+		counts := make(map[string]int)
+		for _, c := range countries {
+			counts[c.Code] = c.Ports
+		}
+
+		assert.Equal(t, 2, counts["AE"])
+		assert.Equal(t, 1, counts["BR"])
+		assert.Equal(t, 0, counts["US"])
+	})
+}
+
+func TestPortService_CountryPorts(t *testing.T) {
+	t.Run("unknown country", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := service.NewPortService(porttest.NewMockPortRepository(ctrl), loggerTest)
+
+		ports, err := svc.CountryPorts(context.Background(), "XX")
+		assert.ErrorIs(t, err, port.ErrCountryNotFound)
+		assert.Nil(t, ports)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			List(gomock.Any()).
+			Return(domain.Ports{
+				{ID: "AEAJM", CountryCode: "AE"},
+				{ID: "AEDXB", CountryCode: "AE"},
+				{ID: "BRSSZ", CountryCode: "BR"},
+			}, nil).
+			Times(2)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		ports, err := svc.CountryPorts(context.Background(), "ae")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Ports{
+			{ID: "AEAJM", CountryCode: "AE"},
+			{ID: "AEDXB", CountryCode: "AE"},
+		}, ports)
+
+		ports, err = svc.CountryPorts(context.Background(), "US")
+		require.NoError(t, err)
+		assert.Empty(t, ports)
+	})
 }
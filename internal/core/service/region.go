@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+)
+
+// Regions returns the region catalogue, sorted by ID.
+func (svc *PortService) Regions(ctx context.Context) (domain.Regions, error) {
+	svc.logger.DebugContext(ctx, "[PortService.Regions] executing")
+
+	return svc.productRepo.Regions(ctx)
+}
+
+// ReplaceRegions replaces the whole region catalogue. Invalid catalogues are
+// rejected with port.ErrInvalidRegions, and the repository rejects the ones
+// missing regions still referenced by ports with a *port.RegionsInUseError.
+func (svc *PortService) ReplaceRegions(ctx context.Context, regions domain.Regions) error {
+	svc.logger.DebugContext(ctx,
+		"[PortService.ReplaceRegions] executing",
+		slog.Any("regions", regions),
+	)
+
+	if err := regions.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", port.ErrInvalidRegions, err)
+	}
+
+	return svc.productRepo.ReplaceRegions(ctx, regions)
+}
+
+// RegionPorts returns the ports of the region with the given ID, sorted by ID,
+// along with the ones of all the regions nested under it when descendants is
+// set.
+func (svc *PortService) RegionPorts(ctx context.Context, id string, descendants bool) (domain.Ports, error) {
+	svc.logger.DebugContext(ctx,
+		"[PortService.RegionPorts] executing",
+		slog.String("id", id),
+		slog.Bool("descendants", descendants),
+	)
+
+	regions, err := svc.productRepo.Regions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := regions.Get(id); !ok {
+		return nil, port.ErrRegionNotFound
+	}
+
+	ids := []string{id}
+	if descendants {
+		ids = regions.Descendants(id)
+	}
+
+	wanted := make(map[string]bool, len(ids))
+	for _, rid := range ids {
+		wanted[rid] = true
+	}
+
+	ports, err := svc.productRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	regionPorts := domain.Ports{}
+
+	for idx := range ports {
+		for _, rid := range ports[idx].Regions {
+			if wanted[rid] {
+				regionPorts = append(regionPorts, ports[idx])
+				break
+			}
+		}
+	}
+
+	return regionPorts, nil
+}
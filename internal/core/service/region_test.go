@@ -0,0 +1,178 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/rafaeltg/goports/internal/core/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var regionsTest = domain.Regions{
+	{ID: "asia", Name: "Asia"},
+	{ID: "gulf", Name: "Persian Gulf", Parent: "middle-east"},
+	{ID: "middle-east", Name: "Middle East", Parent: "asia"},
+	{ID: "red-sea", Name: "Red Sea", Parent: "middle-east"},
+}
+
+func TestPortService_ReplaceRegions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			ReplaceRegions(gomock.Any(), regionsTest).
+			Return(nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		assert.NoError(t, svc.ReplaceRegions(context.Background(), regionsTest))
+	})
+
+	t.Run("invalid regions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := service.NewPortService(porttest.NewMockPortRepository(ctrl), loggerTest)
+
+		err := svc.ReplaceRegions(context.Background(), domain.Regions{
+			{ID: "gulf", Name: "Persian Gulf", Parent: "asia"},
+		})
+		assert.ErrorIs(t, err, port.ErrInvalidRegions)
+		assert.EqualError(t, err, "invalid regions: region 'gulf': unknown parent 'asia'")
+	})
+
+	t.Run("regions in use", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			ReplaceRegions(gomock.Any(), regionsTest).
+			Return(&port.RegionsInUseError{Regions: []string{"baltic", "north-sea"}})
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		err := svc.ReplaceRegions(context.Background(), regionsTest)
+
+		var iuErr *port.RegionsInUseError
+		require.ErrorAs(t, err, &iuErr)
+		assert.Equal(t, []string{"baltic", "north-sea"}, iuErr.Regions)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			ReplaceRegions(gomock.Any(), gomock.Any()).
+			Return(errors.New("replace err"))
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		assert.EqualError(t, svc.ReplaceRegions(context.Background(), regionsTest), "replace err")
+	})
+}
+
+func TestPortService_RegionPorts(t *testing.T) {
+	ports := domain.Ports{
+		{ID: "AEAJM", Regions: []string{"gulf"}},
+		{ID: "EGSUZ", Regions: []string{"red-sea"}},
+		{ID: "INBOM", Regions: []string{"asia"}},
+		{ID: "NLRTM"},
+	}
+
+	tcs := []struct {
+		name        string
+		id          string
+		descendants bool
+		expectedIDs []string
+	}{
+		{
+			name:        "region",
+			id:          "asia",
+			expectedIDs: []string{"INBOM"},
+		},
+		{
+			name:        "with descendants",
+			id:          "asia",
+			descendants: true,
+			expectedIDs: []string{"AEAJM", "EGSUZ", "INBOM"},
+		},
+		{
+			name:        "leaf with descendants",
+			id:          "gulf",
+			descendants: true,
+			expectedIDs: []string{"AEAJM"},
+		},
+		{
+			name:        "no ports",
+			id:          "middle-east",
+			expectedIDs: []string{},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+			mockedPortRepo.EXPECT().Regions(gomock.Any()).Return(regionsTest, nil)
+			mockedPortRepo.EXPECT().List(gomock.Any()).Return(ports, nil)
+
+			svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+			regionPorts, err := svc.RegionPorts(context.Background(), tc.id, tc.descendants)
+			require.NoError(t, err)
+
+			ids := []string{}
+			for _, p := range regionPorts {
+				ids = append(ids, p.ID)
+			}
+
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+
+	t.Run("region not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().Regions(gomock.Any()).Return(regionsTest, nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		_, err := svc.RegionPorts(context.Background(), "europe", false)
+		assert.ErrorIs(t, err, port.ErrRegionNotFound)
+	})
+}
+
+func TestPortService_BulkUpsert_UnknownRegions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+	mockedPortRepo.EXPECT().
+		BulkUpsert(gomock.Any(), gomock.Any()).
+		Return(&port.UnknownRegionsError{Regions: []string{"north-sea"}})
+
+	svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+	err := svc.BulkUpsert(context.Background(), domain.Ports{
+		{ID: "NLRTM", Name: "Rotterdam", Regions: []string{"north-sea"}},
+	})
+
+	var urErr *port.UnknownRegionsError
+	require.ErrorAs(t, err, &urErr)
+	assert.Equal(t, []string{"north-sea"}, urErr.Regions)
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"math"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/routing"
+	"github.com/rafaeltg/goports/pkg/geo"
+)
+
+// Route returns the shortest sea route between two ports, over the shipping
+// lanes network, with its distance rounded to tenths of nautical miles.
+func (svc *PortService) Route(ctx context.Context, from, to string) (*domain.Route, error) {
+	svc.logger.DebugContext(ctx,
+		"[PortService.Route] executing",
+		slog.String("from", from),
+		slog.String("to", to),
+	)
+
+	points := make([]geo.Point, 0, 2)
+
+	for _, id := range []string{from, to} {
+		p, err := svc.productRepo.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.Coordinates.Valid() {
+			return nil, port.ErrNoCoordinates
+		}
+
+		points = append(points, geo.Point{Lat: p.Coordinates.Lat(), Lon: p.Coordinates.Lon()})
+	}
+
+	lanes := svc.lanes
+	if lanes == nil {
+		lanes = routing.Default()
+	}
+
+	r, ok := lanes.Route(points[0], points[1])
+	if !ok {
+		return nil, port.ErrNoRoute
+	}
+
+	path := make([]domain.Coordinates, 0, len(r.Path))
+	for _, p := range r.Path {
+		path = append(path, domain.NewCoordinates(p.Lat, p.Lon))
+	}
+
+	geometry, err := domain.NewLineString(path)
+	if err != nil {
+		return nil, err
+	}
+
+	waypoints := r.Waypoints
+	if waypoints == nil {
+		waypoints = []string{}
+	}
+
+	return &domain.Route{
+		From:       from,
+		To:         to,
+		DistanceNM: math.Round(r.DistanceNM*10) / 10,
+		Waypoints:  waypoints,
+		Geometry:   geometry,
+	}, nil
+}
+
+// WithShippingLanes sets the shipping lanes network the sea routes are
+// computed on, instead of the embedded one.
+func WithShippingLanes(g *routing.Graph) PortServiceOption {
+	return func(svc *PortService) {
+		svc.lanes = g
+	}
+}
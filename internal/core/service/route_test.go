@@ -0,0 +1,98 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/rafaeltg/goports/internal/core/routing"
+	"github.com/rafaeltg/goports/internal/core/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortService_Route(t *testing.T) {
+	jebelAli := &domain.Port{ID: "AEJEA", Name: "Jebel Ali", Coordinates: domain.NewCoordinates(25.01, 55.06)}
+	rotterdam := &domain.Port{ID: "NLRTM", Name: "Rotterdam", Coordinates: domain.NewCoordinates(51.95, 4.05)}
+	nowhere := &domain.Port{ID: "XXXXX", Name: "Nowhere"}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().Get(gomock.Any(), "AEJEA").Return(jebelAli, nil)
+		mockedPortRepo.EXPECT().Get(gomock.Any(), "NLRTM").Return(rotterdam, nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		r, err := svc.Route(context.Background(), "AEJEA", "NLRTM")
+		require.NoError(t, err)
+
+		assert.Equal(t, "AEJEA", r.From)
+		assert.Equal(t, "NLRTM", r.To)
+		assert.InDelta(t, 6500, r.DistanceNM, 300)
+		assert.Contains(t, r.Waypoints, "suez")
+
+		require.NotNil(t, r.Geometry)
+		assert.Equal(t, domain.GeoJSONLineString, r.Geometry.Type)
+
+		var path [][]float64
+		require.NoError(t, json.Unmarshal(r.Geometry.Coordinates, &path))
+		require.Len(t, path, len(r.Waypoints)+2)
+		assert.Equal(t, []float64(jebelAli.Coordinates), path[0])
+		assert.Equal(t, []float64(rotterdam.Coordinates), path[len(path)-1])
+	})
+
+	t.Run("port not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().Get(gomock.Any(), "AEJEA").Return(jebelAli, nil)
+		mockedPortRepo.EXPECT().Get(gomock.Any(), "NLXXX").Return(nil, port.ErrPortNotFound)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		_, err := svc.Route(context.Background(), "AEJEA", "NLXXX")
+		assert.ErrorIs(t, err, port.ErrPortNotFound)
+	})
+
+	t.Run("port without coordinates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().Get(gomock.Any(), "XXXXX").Return(nowhere, nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		_, err := svc.Route(context.Background(), "XXXXX", "NLRTM")
+		assert.ErrorIs(t, err, port.ErrNoCoordinates)
+	})
+
+	t.Run("no route", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().Get(gomock.Any(), "AEJEA").Return(jebelAli, nil)
+		mockedPortRepo.EXPECT().Get(gomock.Any(), "NLRTM").Return(rotterdam, nil)
+
+		lanes, err := routing.Parse([]byte(`
+waypoints:
+  - {id: gulf, lat: 26, lon: 53}
+  - {id: north-sea, lat: 55, lon: 4}
+`))
+		require.NoError(t, err)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest, service.WithShippingLanes(lanes))
+
+		_, err = svc.Route(context.Background(), "AEJEA", "NLRTM")
+		assert.ErrorIs(t, err, port.ErrNoRoute)
+	})
+}
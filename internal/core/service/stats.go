@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+)
+
+// Stats returns the counts of all the ports, along with the ones by country,
+// region and timezone.
+func (svc *PortService) Stats(ctx context.Context) (*domain.Stats, error) {
+	svc.logger.DebugContext(ctx, "[PortService.Stats] executing")
+
+	return svc.productRepo.Stats(ctx)
+}
@@ -0,0 +1,54 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/rafaeltg/goports/internal/core/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortService_Stats(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expected := &domain.Stats{
+			PortCounts: domain.PortCounts{Ports: 2, MissingCoordinates: 1},
+			Countries: []domain.CountryStats{
+				{Country: "AE", PortCounts: domain.PortCounts{Ports: 2, MissingCoordinates: 1}},
+			},
+		}
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			Stats(gomock.Any()).
+			Return(expected, nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		stats, err := svc.Stats(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, expected, stats)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			Stats(gomock.Any()).
+			Return(nil, errors.New("stats err"))
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		_, err := svc.Stats(context.Background())
+		assert.EqualError(t, err, "stats err")
+	})
+}
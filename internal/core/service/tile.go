@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/pkg/mvt"
+)
+
+const (
+	// tileLayer is the name of the vector tiles layer holding the ports.
+	tileLayer = "ports"
+	// tileCacheSizeDefault is the number of tiles cached by default.
+	tileCacheSizeDefault = 1024
+	// clusterMaxZoom is the zoom level from which ports are no longer
+	// clustered.
+	clusterMaxZoom = 8
+	// clusterCell is the side, in extent units, of the grid cells whose
+	// ports are clustered, about 16 pixels on 256 pixels tiles.
+	clusterCell = 256
+)
+
+// cluster is a group of ports falling in the same grid cell of a tile.
+type cluster struct {
+	ports  []*domain.Port
+	sumX   int
+	sumY   int
+	firstX int
+	firstY int
+}
+
+// Tile returns the Mapbox vector tile of the ports falling in the given
+// tile, whose "ports" layer holds a point per port. Below zoom level 8,
+// nearby ports are clustered into points with the "cluster" and
+// "point_count" attributes.
+func (svc *PortService) Tile(ctx context.Context, z, x, y int) ([]byte, error) {
+	svc.logger.DebugContext(ctx,
+		"[PortService.Tile] executing",
+		slog.Int("z", z),
+		slog.Int("x", x),
+		slog.Int("y", y),
+	)
+
+	id := mvt.TileID{Z: z, X: x, Y: y}
+	if !id.Valid() {
+		return nil, port.ErrInvalidTile
+	}
+
+	data, version, ok := svc.tiles.Get(id)
+	if ok {
+		return data, nil
+	}
+
+	ports, err := svc.productRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ids, err := renderTile(id, ports)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.tiles.Put(id, data, ids, version)
+
+	return data, nil
+}
+
+// invalidateTiles drops the cached tiles holding the given ports, or
+// containing their new coordinates. It always bumps the cache version, even
+// when nothing is cached, so that tiles being generated meanwhile aren't put.
+func (svc *PortService) invalidateTiles(ports domain.Ports, ids []string) {
+	for idx := range ports {
+		ids = append(ids, ports[idx].ID)
+
+		if c := ports[idx].Coordinates; c.Valid() {
+			svc.tiles.InvalidatePoint(c.Lon(), c.Lat())
+		}
+	}
+
+	svc.tiles.InvalidateFeatures(ids...)
+}
+
+// renderTile encodes the ports falling in a tile, returning their IDs too.
+func renderTile(id mvt.TileID, ports domain.Ports) ([]byte, []string, error) {
+	inTile := make([]*domain.Port, 0)
+
+	for idx := range ports {
+		c := ports[idx].Coordinates
+		if c.Valid() && id.Contains(c.Lon(), c.Lat()) {
+			inTile = append(inTile, &ports[idx])
+		}
+	}
+
+	sort.Slice(inTile, func(i, j int) bool {
+		return inTile[i].ID < inTile[j].ID
+	})
+
+	layer := mvt.NewLayer(tileLayer, mvt.DefaultExtent)
+	ids := make([]string, 0, len(inTile))
+
+	for _, cl := range clusters(id, inTile) {
+		var err error
+
+		if len(cl.ports) == 1 {
+			err = layer.AddPoint(cl.firstX, cl.firstY, portAttributes(cl.ports[0]))
+		} else {
+			n := len(cl.ports)
+			err = layer.AddPoint(cl.sumX/n, cl.sumY/n, map[string]any{
+				"cluster":     true,
+				"point_count": n,
+			})
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, p := range cl.ports {
+			ids = append(ids, p.ID)
+		}
+	}
+
+	return mvt.Encode(layer), ids, nil
+}
+
+// clusters groups the ports by grid cell below the clustering max zoom, and
+// returns a cluster per port otherwise. Clusters keep the order of their
+// first port.
+func clusters(id mvt.TileID, ports []*domain.Port) []*cluster {
+	var (
+		list  []*cluster
+		cells = make(map[[2]int]*cluster)
+	)
+
+	for idx, p := range ports {
+		x, y := id.Project(p.Coordinates.Lon(), p.Coordinates.Lat(), mvt.DefaultExtent)
+
+		key := [2]int{-1, idx}
+		if id.Z < clusterMaxZoom {
+			key = [2]int{x / clusterCell, y / clusterCell}
+		}
+
+		cl, ok := cells[key]
+		if !ok {
+			cl = &cluster{firstX: x, firstY: y}
+			cells[key] = cl
+			list = append(list, cl)
+		}
+
+		cl.ports = append(cl.ports, p)
+		cl.sumX += x
+		cl.sumY += y
+	}
+
+	return list
+}
+
+// portAttributes returns the attributes of the tile point of a port.
+func portAttributes(p *domain.Port) map[string]any {
+	attrs := map[string]any{
+		"id":          p.ID,
+		"name":        p.Name,
+		"city":        p.City,
+		"country":     p.Country,
+		"countryCode": p.CountryCode,
+	}
+
+	if len(p.Unlocs) > 0 {
+		attrs["unloc"] = p.Unlocs[0]
+	}
+
+	return attrs
+}
+
+// WithTileCacheSize sets the number of vector tiles cached, 1024 by default.
+// Zero disables the cache.
+func WithTileCacheSize(size int) PortServiceOption {
+	return func(svc *PortService) {
+		svc.tiles = mvt.NewCache(size)
+	}
+}
@@ -0,0 +1,173 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/rafaeltg/goports/internal/core/service"
+	"github.com/rafaeltg/goports/pkg/mvt/mvttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortService_Tile(t *testing.T) {
+	ports := domain.Ports{
+		{
+			ID:          "AEAJM",
+			Name:        "Ajman",
+			Country:     "United Arab Emirates",
+			CountryCode: "AE",
+			Coordinates: domain.NewCoordinates(25.4052165, 55.5136433),
+			Unlocs:      []string{"AEAJM"},
+		},
+		{ID: "AEDXB", Name: "Dubai", Coordinates: domain.NewCoordinates(25.2048, 55.2708)},
+		{ID: "AEAUH", Name: "Abu Dhabi", Coordinates: domain.NewCoordinates(24.4539, 54.3773)},
+		{ID: "BRSSZ", Name: "Santos", Coordinates: domain.NewCoordinates(-23.9608, -46.3336)},
+		{ID: "XXXXX", Name: "Nowhere"},
+	}
+
+	decode := func(t *testing.T, tile []byte) []mvttest.Feature {
+		layers, err := mvttest.Decode(tile)
+		require.NoError(t, err)
+		require.Len(t, layers, 1)
+		assert.Equal(t, "ports", layers[0].Name)
+
+		return layers[0].Features
+	}
+
+	t.Run("invalid tile", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := service.NewPortService(porttest.NewMockPortRepository(ctrl), loggerTest)
+
+		_, err := svc.Tile(context.Background(), 1, 2, 0)
+		assert.ErrorIs(t, err, port.ErrInvalidTile)
+	})
+
+	t.Run("clusters at low zoom", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			List(gomock.Any()).
+			Return(ports, nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		tile, err := svc.Tile(context.Background(), 0, 0, 0)
+		require.NoError(t, err)
+
+		features := decode(t, tile)
+		require.Len(t, features, 2)
+		assert.Equal(t, map[string]any{"cluster": true, "point_count": int64(3)}, features[0].Attributes)
+		assert.Equal(t, "BRSSZ", features[1].Attributes["id"])
+	})
+
+	t.Run("ports at high zoom", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			List(gomock.Any()).
+			Return(ports, nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		tile, err := svc.Tile(context.Background(), 8, 167, 109)
+		require.NoError(t, err)
+
+		features := decode(t, tile)
+		require.Len(t, features, 2)
+		assert.Equal(t,
+			map[string]any{
+				"id":          "AEAJM",
+				"name":        "Ajman",
+				"country":     "United Arab Emirates",
+				"countryCode": "AE",
+				"unloc":       "AEAJM",
+			},
+			features[0].Attributes,
+		)
+		assert.Equal(t, "AEDXB", features[1].Attributes["id"])
+	})
+
+	t.Run("cached until ports change", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		moved := ports[1]
+		moved.Coordinates = domain.NewCoordinates(-23.9608, -46.3336)
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		gomock.InOrder(
+			mockedPortRepo.EXPECT().
+				List(gomock.Any()).
+				Return(ports, nil),
+			mockedPortRepo.EXPECT().
+				BulkUpsert(gomock.Any(), gomock.Any()).
+				Return(nil),
+			mockedPortRepo.EXPECT().
+				List(gomock.Any()).
+				Return(domain.Ports{ports[0], moved}, nil),
+		)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		for i := 0; i < 2; i++ {
+			tile, err := svc.Tile(context.Background(), 8, 167, 109)
+			require.NoError(t, err)
+			assert.Len(t, decode(t, tile), 2)
+		}
+
+		// Dubai moves away from the cached tile
+		require.NoError(t, svc.BulkUpsert(context.Background(), domain.Ports{moved}))
+
+		tile, err := svc.Tile(context.Background(), 8, 167, 109)
+		require.NoError(t, err)
+		assert.Len(t, decode(t, tile), 1)
+	})
+
+	t.Run("not cached when ports change while generating it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		moved := ports[1]
+		moved.Coordinates = domain.NewCoordinates(-23.9608, -46.3336)
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		gomock.InOrder(
+			// Dubai moves away once the ports were listed for the tile, on
+			// an empty cache
+			mockedPortRepo.EXPECT().
+				List(gomock.Any()).
+				DoAndReturn(func(ctx context.Context) (domain.Ports, error) {
+					require.NoError(t, svc.BulkUpsert(ctx, domain.Ports{moved}))
+					return ports, nil
+				}),
+			mockedPortRepo.EXPECT().
+				BulkUpsert(gomock.Any(), gomock.Any()).
+				Return(nil),
+			mockedPortRepo.EXPECT().
+				List(gomock.Any()).
+				Return(domain.Ports{ports[0], moved}, nil),
+		)
+
+		tile, err := svc.Tile(context.Background(), 8, 167, 109)
+		require.NoError(t, err)
+		assert.Len(t, decode(t, tile), 2)
+
+		// the stale tile was not cached
+		tile, err = svc.Tile(context.Background(), 8, 167, 109)
+		require.NoError(t, err)
+		assert.Len(t, decode(t, tile), 1)
+	})
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/rafaeltg/goports/internal/core/domain"
+)
+
+// Timezones returns the timezones of the ports, along with their current
+// time and their number of ports, sorted by UTC offset and name. Ports with
+// a missing or unknown timezone are left out.
+func (svc *PortService) Timezones(ctx context.Context) ([]domain.TimezoneSummary, error) {
+	svc.logger.DebugContext(ctx, "[PortService.Timezones] executing")
+
+	ports, err := svc.productRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+
+	for idx := range ports {
+		if _, err := domain.LoadTimezone(ports[idx].Timezone); err == nil {
+			counts[ports[idx].Timezone]++
+		}
+	}
+
+	now := time.Now()
+	summaries := make([]domain.TimezoneSummary, 0, len(counts))
+	offsets := make(map[string]int, len(counts))
+
+	for tz, count := range counts {
+		loc, _ := domain.LoadTimezone(tz)
+		t := now.In(loc)
+
+		_, offsets[tz] = t.Zone()
+
+		summaries = append(summaries, domain.TimezoneSummary{
+			Timezone:  tz,
+			LocalTime: domain.NewLocalTime(t),
+			Ports:     count,
+		})
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i].Timezone, summaries[j].Timezone
+		if offsets[a] != offsets[b] {
+			return offsets[a] < offsets[b]
+		}
+
+		return a < b
+	})
+
+	return summaries, nil
+}
@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rafaeltg/goports/internal/core/domain"
+	"github.com/rafaeltg/goports/internal/core/port/porttest"
+	"github.com/rafaeltg/goports/internal/core/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortService_Timezones(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			List(gomock.Any()).
+			Return(domain.Ports{
+				{ID: "AEAJM", Timezone: "Asia/Dubai"},
+				{ID: "AEDXB", Timezone: "Asia/Dubai"},
+				{ID: "INBOM", Timezone: "Asia/Kolkata"},
+				{ID: "OMSLL", Timezone: "Asia/Muscat"},
+				{ID: "BRSSZ", Timezone: "America/Sao_Paulo"},
+				{ID: "XXXXX", Timezone: "Asia/Ajman"},
+				{ID: "YYYYY"},
+			}, nil)
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		timezones, err := svc.Timezones(context.Background())
+		require.NoError(t, err)
+
+		// none of these timezones has daylight saving time
+		require.Len(t, timezones, 4)
+
+		expected := []struct {
+			timezone  string
+			utcOffset string
+			ports     int
+		}{
+			{timezone: "America/Sao_Paulo", utcOffset: "-03:00", ports: 1},
+			{timezone: "Asia/Dubai", utcOffset: "+04:00", ports: 2},
+			{timezone: "Asia/Muscat", utcOffset: "+04:00", ports: 1},
+			{timezone: "Asia/Kolkata", utcOffset: "+05:30", ports: 1},
+		}
+
+		for idx, e := range expected {
+			assert.Equal(t, e.timezone, timezones[idx].Timezone)
+			assert.Equal(t, e.utcOffset, timezones[idx].UTCOffset)
+			assert.Equal(t, e.ports, timezones[idx].Ports)
+			assert.False(t, timezones[idx].DST)
+			assert.NotEmpty(t, timezones[idx].LocalTime.LocalTime)
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+		mockedPortRepo.EXPECT().
+			List(gomock.Any()).
+			Return(nil, errors.New("list err"))
+
+		svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+		_, err := svc.Timezones(context.Background())
+		assert.EqualError(t, err, "list err")
+	})
+}
+
+func TestPortService_BulkUpsert_UnknownTimezone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockedPortRepo := porttest.NewMockPortRepository(ctrl)
+
+	svc := service.NewPortService(mockedPortRepo, loggerTest)
+
+	err := svc.BulkUpsert(context.Background(), domain.Ports{
+		{ID: "AEDXB", Name: "Dubai", Timezone: "Asia/Dubai"},
+		{ID: "XXOLY", Name: "Olympus", Timezone: "Mars/Olympus"},
+	})
+
+	var pvErr *domain.PortsValidationError
+	require.ErrorAs(t, err, &pvErr)
+	assert.Equal(t, []domain.InvalidPort{
+		{
+			ID: "XXOLY",
+			Errors: []domain.FieldError{
+				{Field: "timezone", Message: "unknown timezone 'Mars/Olympus'"},
+			},
+		},
+	}, pvErr.Ports)
+}
@@ -0,0 +1,39 @@
+// Package geo provides geodesic computations on the Earth sphere.
+package geo
+
+import "math"
+
+const (
+	// EarthRadiusKm is the mean radius of the Earth, in kilometres.
+	EarthRadiusKm = 6371.0088
+	// KmPerNauticalMile is the length of a nautical mile, in kilometres.
+	KmPerNauticalMile = 1.852
+)
+
+// Point is a location on the Earth, in decimal degrees.
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// DistanceKm returns the great-circle distance between two points, in
+// kilometres, computed with the haversine formula.
+func DistanceKm(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLon := radians(b.Lon - a.Lon)
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// DistanceNM returns the great-circle distance between two points, in
+// nautical miles.
+func DistanceNM(a, b Point) float64 {
+	return DistanceKm(a, b) / KmPerNauticalMile
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,46 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/rafaeltg/goports/pkg/geo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	london := geo.Point{Lat: 51.5074, Lon: -0.1278}
+	paris := geo.Point{Lat: 48.8566, Lon: 2.3522}
+
+	assert.InDelta(t, 343.5, geo.DistanceKm(london, paris), 0.5)
+	assert.InDelta(t, 185.5, geo.DistanceNM(london, paris), 0.5)
+	assert.Zero(t, geo.DistanceKm(london, london))
+
+	// across the antimeridian
+	assert.InDelta(t, 111.2, geo.DistanceKm(geo.Point{Lon: 179.5}, geo.Point{Lon: -179.5}), 0.1)
+
+	// antipodes
+	assert.InDelta(t, 20015.1, geo.DistanceKm(geo.Point{Lat: 90}, geo.Point{Lat: -90}), 0.1)
+}
+
+func TestVincentyKm(t *testing.T) {
+	// Flinders Peak to Buninyong, the example of Vincenty's paper
+	flindersPeak := geo.Point{Lat: -(37 + 57/60.0 + 3.72030/3600), Lon: 144 + 25/60.0 + 29.52440/3600}
+	buninyong := geo.Point{Lat: -(37 + 39/60.0 + 10.15610/3600), Lon: 143 + 55/60.0 + 35.38390/3600}
+
+	km, ok := geo.VincentyKm(flindersPeak, buninyong)
+	assert.True(t, ok)
+	assert.InDelta(t, 54.972271, km, 1e-6)
+
+	km, ok = geo.VincentyKm(flindersPeak, flindersPeak)
+	assert.True(t, ok)
+	assert.Zero(t, km)
+
+	// along the equator
+	km, ok = geo.VincentyKm(geo.Point{}, geo.Point{Lon: 1})
+	assert.True(t, ok)
+	assert.InDelta(t, 111.319, km, 1e-3)
+
+	// nearly antipodal points fail to converge
+	_, ok = geo.VincentyKm(geo.Point{}, geo.Point{Lat: 0.5, Lon: 179.7})
+	assert.False(t, ok)
+}
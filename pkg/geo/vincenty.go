@@ -0,0 +1,74 @@
+package geo
+
+import "math"
+
+const (
+	// wgs84A is the semi-major axis of the WGS 84 ellipsoid, in kilometres.
+	wgs84A = 6378.137
+	// wgs84F is the flattening of the WGS 84 ellipsoid.
+	wgs84F = 1 / 298.257223563
+
+	vincentyIterations = 200
+	vincentyTolerance  = 1e-12
+)
+
+// VincentyKm returns the distance between two points on the WGS 84
+// ellipsoid, in kilometres, computed with Vincenty's inverse formula, which
+// is accurate to less than a millimetre. It returns false when the formula
+// fails to converge, which only happens for nearly antipodal points.
+func VincentyKm(a, b Point) (float64, bool) {
+	wgs84B := wgs84A * (1 - wgs84F)
+
+	l := radians(b.Lon - a.Lon)
+	u1 := math.Atan((1 - wgs84F) * math.Tan(radians(a.Lat)))
+	u2 := math.Atan((1 - wgs84F) * math.Tan(radians(b.Lat)))
+
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	lambda := l
+
+	for i := 0; i < vincentyIterations; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+
+		sinSigma := math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			// coincident points
+			return 0, true
+		}
+
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha := 1 - sinAlpha*sinAlpha
+
+		// points on the equator
+		cos2SigmaM := 0.0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+
+		c := wgs84F / 16 * cosSqAlpha * (4 + wgs84F*(4-3*cosSqAlpha))
+
+		prev := lambda
+		lambda = l + (1-c)*wgs84F*sinAlpha*
+			(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda-prev) > vincentyTolerance {
+			continue
+		}
+
+		uSq := cosSqAlpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+		bigA := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+		bigB := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+
+		deltaSigma := bigB * sinSigma * (cos2SigmaM + bigB/4*
+			(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+				bigB/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+		return wgs84B * bigA * (sigma - deltaSigma), true
+	}
+
+	return 0, false
+}
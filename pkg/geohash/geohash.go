@@ -0,0 +1,98 @@
+package geohash
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxPrecision is the maximum number of characters of a geohash.
+const MaxPrecision = 12
+
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// ErrInvalid is returned when decoding an invalid geohash.
+var ErrInvalid = errors.New("invalid geohash")
+
+// Encode returns the geohash of a point with the given number of
+// characters, which is bounded to [1, MaxPrecision].
+func Encode(lat, lon float64, precision int) string {
+	precision = max(1, min(precision, MaxPrecision))
+
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+
+	var sb strings.Builder
+
+	bit, idx, even := 0, 0, true
+
+	for sb.Len() < precision {
+		if even {
+			idx = idx<<1 | bisect(&lonRange, lon)
+		} else {
+			idx = idx<<1 | bisect(&latRange, lat)
+		}
+
+		even = !even
+
+		if bit++; bit == 5 {
+			sb.WriteByte(base32[idx])
+			bit, idx = 0, 0
+		}
+	}
+
+	return sb.String()
+}
+
+// Decode returns the center of the cell of a geohash, along with its
+// latitude and longitude errors, which are half the cell height and width.
+func Decode(hash string) (lat, lon, latErr, lonErr float64, err error) {
+	if hash == "" || len(hash) > MaxPrecision {
+		return 0, 0, 0, 0, fmt.Errorf("%w '%s'", ErrInvalid, hash)
+	}
+
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+	even := true
+
+	for _, c := range strings.ToLower(hash) {
+		idx := strings.IndexRune(base32, c)
+		if idx < 0 {
+			return 0, 0, 0, 0, fmt.Errorf("%w '%s'", ErrInvalid, hash)
+		}
+
+		for mask := 16; mask > 0; mask >>= 1 {
+			r := &latRange
+			if even {
+				r = &lonRange
+			}
+
+			mid := (r[0] + r[1]) / 2
+			if idx&mask != 0 {
+				r[0] = mid
+			} else {
+				r[1] = mid
+			}
+
+			even = !even
+		}
+	}
+
+	lat = (latRange[0] + latRange[1]) / 2
+	lon = (lonRange[0] + lonRange[1]) / 2
+
+	return lat, lon, (latRange[1] - latRange[0]) / 2, (lonRange[1] - lonRange[0]) / 2, nil
+}
+
+// bisect halves the range towards v, returning 1 for its upper half.
+func bisect(r *[2]float64, v float64) int {
+	mid := (r[0] + r[1]) / 2
+	if v >= mid {
+		r[0] = mid
+		return 1
+	}
+
+	r[1] = mid
+
+	return 0
+}
@@ -0,0 +1,34 @@
+package geohash_test
+
+import (
+	"testing"
+
+	"github.com/rafaeltg/goports/pkg/geohash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, "ezs42", geohash.Encode(42.6, -5.6, 5))
+	assert.Equal(t, "u4pruydqqvj", geohash.Encode(57.64911, 10.40744, 11))
+	assert.Equal(t, "u", geohash.Encode(57.64911, 10.40744, 0))
+	assert.Len(t, geohash.Encode(57.64911, 10.40744, 20), geohash.MaxPrecision)
+}
+
+func TestDecode(t *testing.T) {
+	lat, lon, latErr, lonErr, err := geohash.Decode("u4pruydqqvj")
+	require.NoError(t, err)
+	assert.InDelta(t, 57.64911, lat, latErr)
+	assert.InDelta(t, 10.40744, lon, lonErr)
+
+	lat, lon, _, _, err = geohash.Decode(geohash.Encode(25.4052165, 55.5136433, 9))
+	require.NoError(t, err)
+	assert.InDelta(t, 25.4052165, lat, 0.0001)
+	assert.InDelta(t, 55.5136433, lon, 0.0001)
+
+	_, _, _, _, err = geohash.Decode("u4pa")
+	assert.ErrorIs(t, err, geohash.ErrInvalid)
+
+	_, _, _, _, err = geohash.Decode("")
+	assert.ErrorIs(t, err, geohash.ErrInvalid)
+}
@@ -0,0 +1,134 @@
+package mvt
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache is a least recently used cache of encoded tiles, which is safe for
+// concurrent use. Each tile is cached along with the IDs of the features it
+// holds, for it to be invalidated when any of them changes, as well as when
+// a feature is moved into it.
+type Cache struct {
+	mu    sync.Mutex
+	size  int
+	tiles map[TileID]*list.Element
+	// features indexes the cached tiles by the IDs of their features.
+	features map[string]map[TileID]struct{}
+	lru      *list.List
+	version  uint64
+}
+
+type cacheEntry struct {
+	id       TileID
+	data     []byte
+	features []string
+}
+
+// NewCache returns a cache holding up to size tiles. Nothing is cached when
+// size is zero or less.
+func NewCache(size int) *Cache {
+	return &Cache{
+		size:     size,
+		tiles:    make(map[TileID]*list.Element),
+		features: make(map[string]map[TileID]struct{}),
+		lru:      list.New(),
+	}
+}
+
+// Get returns the cached tile, if any, along with the cache version to be
+// given back to Put once the missing tile is generated.
+func (c *Cache) Get(id TileID) ([]byte, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.tiles[id]
+	if !ok {
+		return nil, c.version, false
+	}
+
+	c.lru.MoveToFront(e)
+
+	return e.Value.(*cacheEntry).data, c.version, true
+}
+
+// Put caches a tile holding the given features, generated from the cache
+// version returned by Get. It is dropped when tiles were invalidated in the
+// meantime, as it may be stale already.
+func (c *Cache) Put(id TileID, data []byte, features []string, version uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if version != c.version || c.size <= 0 {
+		return
+	}
+
+	if e, ok := c.tiles[id]; ok {
+		c.remove(e)
+	}
+
+	c.tiles[id] = c.lru.PushFront(&cacheEntry{id: id, data: data, features: features})
+
+	for _, f := range features {
+		if c.features[f] == nil {
+			c.features[f] = make(map[TileID]struct{})
+		}
+
+		c.features[f][id] = struct{}{}
+	}
+
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Len returns the number of cached tiles.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// InvalidateFeatures removes the tiles holding any of the given features.
+func (c *Cache) InvalidateFeatures(ids ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+
+	for _, f := range ids {
+		for id := range c.features[f] {
+			c.remove(c.tiles[id])
+		}
+	}
+}
+
+// InvalidatePoint removes the tiles containing a point, at every zoom level.
+func (c *Cache) InvalidatePoint(lon, lat float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+
+	for z := 0; z <= MaxZoom && c.lru.Len() > 0; z++ {
+		if e, ok := c.tiles[TileAt(lon, lat, z)]; ok {
+			c.remove(e)
+		}
+	}
+}
+
+func (c *Cache) remove(e *list.Element) {
+	entry := e.Value.(*cacheEntry)
+
+	c.lru.Remove(e)
+	delete(c.tiles, entry.id)
+
+	for _, f := range entry.features {
+		delete(c.features[f], entry.id)
+
+		if len(c.features[f]) == 0 {
+			delete(c.features, f)
+		}
+	}
+}
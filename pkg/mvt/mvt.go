@@ -0,0 +1,247 @@
+// Package mvt encodes Mapbox Vector Tiles (MVT) holding points, as specified
+// by https://github.com/mapbox/vector-tile-spec/tree/master/2.1.
+package mvt
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ContentType is the media type of vector tiles.
+const ContentType = "application/vnd.mapbox-vector-tile"
+
+// DefaultExtent is the usual number of units across a tile side.
+const DefaultExtent uint32 = 4096
+
+const version = 2
+
+// Protocol buffer field numbers of the vector tile messages.
+const (
+	tileLayers = 3
+
+	layerName     = 1
+	layerFeatures = 2
+	layerKeys     = 3
+	layerValues   = 4
+	layerExtent   = 5
+	layerVersion  = 15
+
+	featureTags     = 2
+	featureType     = 3
+	featureGeometry = 4
+
+	valueString = 1
+	valueDouble = 3
+	valueInt    = 4
+	valueBool   = 7
+)
+
+const (
+	geomPoint = 1
+	cmdMoveTo = 1
+)
+
+type (
+	// Layer is a named layer of point features.
+	Layer struct {
+		name     string
+		extent   uint32
+		features []feature
+		keys     []string
+		keyIdx   map[string]uint32
+		values   []any
+		valueIdx map[any]uint32
+	}
+
+	feature struct {
+		x, y int
+		tags []uint64
+	}
+)
+
+// NewLayer returns an empty layer with the given name and extent.
+func NewLayer(name string, extent uint32) *Layer {
+	return &Layer{
+		name:     name,
+		extent:   extent,
+		keyIdx:   make(map[string]uint32),
+		valueIdx: make(map[any]uint32),
+	}
+}
+
+// Len returns the number of features of the layer.
+func (l *Layer) Len() int {
+	return len(l.features)
+}
+
+// AddPoint adds a point feature at the given position, in extent units from
+// the tile top left corner, with its attributes. Attribute values must be
+// strings, bools, integers or floats; zero strings are left out.
+func (l *Layer) AddPoint(x, y int, attrs map[string]any) error {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	f := feature{x: x, y: y, tags: make([]uint64, 0, 2*len(keys))}
+
+	for _, k := range keys {
+		v, err := normalizeValue(attrs[k])
+		if err != nil {
+			return fmt.Errorf("attribute '%s': %w", k, err)
+		}
+
+		if v == "" {
+			continue
+		}
+
+		f.tags = append(f.tags, uint64(l.key(k)), uint64(l.value(v)))
+	}
+
+	l.features = append(l.features, f)
+
+	return nil
+}
+
+func (l *Layer) key(k string) uint32 {
+	idx, ok := l.keyIdx[k]
+	if !ok {
+		idx = uint32(len(l.keys))
+		l.keyIdx[k] = idx
+		l.keys = append(l.keys, k)
+	}
+
+	return idx
+}
+
+func (l *Layer) value(v any) uint32 {
+	idx, ok := l.valueIdx[v]
+	if !ok {
+		idx = uint32(len(l.values))
+		l.valueIdx[v] = idx
+		l.values = append(l.values, v)
+	}
+
+	return idx
+}
+
+// normalizeValue converts an attribute value into a string, bool, int64 or
+// float64, which are the types encoded.
+func normalizeValue(v any) (any, error) {
+	switch v := v.(type) {
+	case string, bool, int64, float64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case float32:
+		return float64(v), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+// Encode encodes the layers into a vector tile, leaving out empty ones.
+func Encode(layers ...*Layer) []byte {
+	var b []byte
+
+	for _, l := range layers {
+		if l.Len() == 0 {
+			continue
+		}
+
+		b = protowire.AppendTag(b, tileLayers, protowire.BytesType)
+		b = protowire.AppendBytes(b, l.encode())
+	}
+
+	return b
+}
+
+func (l *Layer) encode() []byte {
+	var b []byte
+
+	b = protowire.AppendTag(b, layerVersion, protowire.VarintType)
+	b = protowire.AppendVarint(b, version)
+
+	b = protowire.AppendTag(b, layerName, protowire.BytesType)
+	b = protowire.AppendString(b, l.name)
+
+	for _, f := range l.features {
+		b = protowire.AppendTag(b, layerFeatures, protowire.BytesType)
+		b = protowire.AppendBytes(b, f.encode())
+	}
+
+	for _, k := range l.keys {
+		b = protowire.AppendTag(b, layerKeys, protowire.BytesType)
+		b = protowire.AppendString(b, k)
+	}
+
+	for _, v := range l.values {
+		b = protowire.AppendTag(b, layerValues, protowire.BytesType)
+		b = protowire.AppendBytes(b, encodeValue(v))
+	}
+
+	b = protowire.AppendTag(b, layerExtent, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(l.extent))
+
+	return b
+}
+
+func (f feature) encode() []byte {
+	var b []byte
+
+	if len(f.tags) > 0 {
+		b = protowire.AppendTag(b, featureTags, protowire.BytesType)
+		b = protowire.AppendBytes(b, packed(f.tags...))
+	}
+
+	b = protowire.AppendTag(b, featureType, protowire.VarintType)
+	b = protowire.AppendVarint(b, geomPoint)
+
+	// a single MoveTo command, relative to the tile origin
+	b = protowire.AppendTag(b, featureGeometry, protowire.BytesType)
+	b = protowire.AppendBytes(b, packed(
+		cmdMoveTo|1<<3,
+		protowire.EncodeZigZag(int64(f.x)),
+		protowire.EncodeZigZag(int64(f.y)),
+	))
+
+	return b
+}
+
+func encodeValue(v any) []byte {
+	var b []byte
+
+	switch v := v.(type) {
+	case string:
+		b = protowire.AppendTag(b, valueString, protowire.BytesType)
+		b = protowire.AppendString(b, v)
+	case float64:
+		b = protowire.AppendTag(b, valueDouble, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, math.Float64bits(v))
+	case int64:
+		b = protowire.AppendTag(b, valueInt, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(v))
+	case bool:
+		b = protowire.AppendTag(b, valueBool, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(v))
+	}
+
+	return b
+}
+
+func packed(values ...uint64) []byte {
+	var b []byte
+
+	for _, v := range values {
+		b = protowire.AppendVarint(b, v)
+	}
+
+	return b
+}
@@ -0,0 +1,119 @@
+package mvt_test
+
+import (
+	"testing"
+
+	"github.com/rafaeltg/goports/pkg/mvt"
+	"github.com/rafaeltg/goports/pkg/mvt/mvttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTileID(t *testing.T) {
+	assert.True(t, mvt.TileID{}.Valid())
+	assert.True(t, mvt.TileID{Z: 2, X: 3, Y: 3}.Valid())
+	assert.False(t, mvt.TileID{Z: 2, X: 4, Y: 0}.Valid())
+	assert.False(t, mvt.TileID{Z: 1, X: 0, Y: -1}.Valid())
+	assert.False(t, mvt.TileID{Z: mvt.MaxZoom + 1}.Valid())
+
+	// Ajman, United Arab Emirates
+	lon, lat := 55.5136433, 25.4052165
+
+	assert.Equal(t, mvt.TileID{Z: 0}, mvt.TileAt(lon, lat, 0))
+	assert.Equal(t, mvt.TileID{Z: 1, X: 1, Y: 0}, mvt.TileAt(lon, lat, 1))
+	assert.Equal(t, mvt.TileID{Z: 10, X: 669, Y: 437}, mvt.TileAt(lon, lat, 10))
+	assert.True(t, mvt.TileID{Z: 10, X: 669, Y: 437}.Contains(lon, lat))
+	assert.False(t, mvt.TileID{Z: 10, X: 670, Y: 437}.Contains(lon, lat))
+
+	// the poles and the antimeridian are clamped to the edge tiles
+	assert.Equal(t, mvt.TileID{Z: 2, X: 3, Y: 0}, mvt.TileAt(180, 90, 2))
+	assert.Equal(t, mvt.TileID{Z: 2, X: 0, Y: 3}, mvt.TileAt(-180, -90, 2))
+
+	x, y := mvt.TileID{Z: 0}.Project(0, 0, mvt.DefaultExtent)
+	assert.Equal(t, 2048, x)
+	assert.Equal(t, 2048, y)
+
+	x, y = mvt.TileID{Z: 1, X: 1, Y: 1}.Project(0, 0, mvt.DefaultExtent)
+	assert.Equal(t, 0, x)
+	assert.Equal(t, 0, y)
+}
+
+func TestEncode(t *testing.T) {
+	l := mvt.NewLayer("ports", mvt.DefaultExtent)
+
+	require.NoError(t, l.AddPoint(10, 20, map[string]any{"name": "Ajman", "count": 2, "empty": ""}))
+	require.NoError(t, l.AddPoint(-5, 4096, map[string]any{"name": "Ajman", "cluster": true, "ratio": 0.5}))
+	assert.EqualError(t, l.AddPoint(0, 0, map[string]any{"list": []string{}}), "attribute 'list': unsupported type []string")
+
+	layers, err := mvttest.Decode(mvt.Encode(l, mvt.NewLayer("empty", mvt.DefaultExtent)))
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]mvttest.Layer{
+			{
+				Name:    "ports",
+				Version: 2,
+				Extent:  4096,
+				Features: []mvttest.Feature{
+					{X: 10, Y: 20, Attributes: map[string]any{"name": "Ajman", "count": int64(2)}},
+					{X: -5, Y: 4096, Attributes: map[string]any{"name": "Ajman", "cluster": true, "ratio": 0.5}},
+				},
+			},
+		},
+		layers,
+	)
+}
+
+func TestCache(t *testing.T) {
+	ajman := mvt.TileAt(55.5136433, 25.4052165, 12)
+	dubai := mvt.TileAt(55.2708, 25.2048, 12)
+
+	c := mvt.NewCache(2)
+
+	_, version, ok := c.Get(ajman)
+	assert.False(t, ok)
+
+	c.Put(ajman, []byte("ajman"), []string{"AEAJM"}, version)
+	c.Put(dubai, []byte("dubai"), []string{"AEDXB"}, version)
+
+	data, _, ok := c.Get(ajman)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("ajman"), data)
+
+	t.Run("evicts least recently used", func(t *testing.T) {
+		c.Put(mvt.TileID{}, []byte("world"), []string{"AEAJM", "AEDXB"}, version)
+		assert.Equal(t, 2, c.Len())
+
+		_, _, ok := c.Get(dubai)
+		assert.False(t, ok)
+	})
+
+	t.Run("invalidates by feature", func(t *testing.T) {
+		c.InvalidateFeatures("AEDXB")
+		assert.Equal(t, 1, c.Len())
+
+		_, _, ok := c.Get(ajman)
+		assert.True(t, ok)
+	})
+
+	t.Run("invalidates by point", func(t *testing.T) {
+		_, version, _ := c.Get(dubai)
+		c.Put(dubai, []byte("dubai"), nil, version)
+
+		c.InvalidatePoint(55.5136433, 25.4052165)
+		assert.Equal(t, 1, c.Len())
+
+		_, _, ok := c.Get(dubai)
+		assert.True(t, ok)
+	})
+
+	t.Run("drops stale tiles", func(t *testing.T) {
+		_, version, _ := c.Get(ajman)
+
+		c.InvalidateFeatures("AEAJM")
+		c.Put(ajman, []byte("stale"), []string{"AEAJM"}, version)
+
+		_, _, ok := c.Get(ajman)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,220 @@
+// Package mvttest decodes the point vector tiles encoded by package mvt, for
+// them to be checked in tests.
+package mvttest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type (
+	// Layer is a decoded vector tile layer.
+	Layer struct {
+		Name     string
+		Version  uint64
+		Extent   uint64
+		Features []Feature
+	}
+
+	// Feature is a decoded point feature.
+	Feature struct {
+		X, Y       int
+		Attributes map[string]any
+	}
+
+	rawFeature struct {
+		tags     []uint64
+		geometry []uint64
+	}
+)
+
+var errMalformed = errors.New("malformed vector tile")
+
+// Decode decodes the layers of a vector tile of points.
+func Decode(b []byte) ([]Layer, error) {
+	var layers []Layer
+
+	err := fields(b, func(num protowire.Number, v []byte, _ uint64) error {
+		if num != 3 {
+			return nil
+		}
+
+		l, err := decodeLayer(v)
+		if err != nil {
+			return err
+		}
+
+		layers = append(layers, l)
+
+		return nil
+	})
+
+	return layers, err
+}
+
+func decodeLayer(b []byte) (Layer, error) {
+	var (
+		l        Layer
+		keys     []string
+		values   []any
+		features []rawFeature
+	)
+
+	err := fields(b, func(num protowire.Number, v []byte, n uint64) error {
+		switch num {
+		case 1:
+			l.Name = string(v)
+		case 2:
+			f, err := decodeFeature(v)
+			if err != nil {
+				return err
+			}
+
+			features = append(features, f)
+		case 3:
+			keys = append(keys, string(v))
+		case 4:
+			value, err := decodeValue(v)
+			if err != nil {
+				return err
+			}
+
+			values = append(values, value)
+		case 5:
+			l.Extent = n
+		case 15:
+			l.Version = n
+		}
+
+		return nil
+	})
+	if err != nil {
+		return l, err
+	}
+
+	for _, f := range features {
+		if len(f.geometry) != 3 || f.geometry[0] != 1|1<<3 || len(f.tags)%2 != 0 {
+			return l, fmt.Errorf("%w: unexpected feature", errMalformed)
+		}
+
+		feature := Feature{
+			X:          int(protowire.DecodeZigZag(f.geometry[1])),
+			Y:          int(protowire.DecodeZigZag(f.geometry[2])),
+			Attributes: make(map[string]any, len(f.tags)/2),
+		}
+
+		for idx := 0; idx < len(f.tags); idx += 2 {
+			if f.tags[idx] >= uint64(len(keys)) || f.tags[idx+1] >= uint64(len(values)) {
+				return l, fmt.Errorf("%w: unknown tag", errMalformed)
+			}
+
+			feature.Attributes[keys[f.tags[idx]]] = values[f.tags[idx+1]]
+		}
+
+		l.Features = append(l.Features, feature)
+	}
+
+	return l, nil
+}
+
+func decodeFeature(b []byte) (rawFeature, error) {
+	var f rawFeature
+
+	err := fields(b, func(num protowire.Number, v []byte, _ uint64) error {
+		var err error
+
+		switch num {
+		case 2:
+			f.tags, err = unpack(v)
+		case 4:
+			f.geometry, err = unpack(v)
+		}
+
+		return err
+	})
+
+	return f, err
+}
+
+func decodeValue(b []byte) (any, error) {
+	var value any
+
+	err := fields(b, func(num protowire.Number, v []byte, n uint64) error {
+		switch num {
+		case 1:
+			value = string(v)
+		case 3:
+			value = math.Float64frombits(n)
+		case 4:
+			value = int64(n)
+		case 7:
+			value = protowire.DecodeBool(n)
+		default:
+			return fmt.Errorf("%w: unexpected value field %d", errMalformed, num)
+		}
+
+		return nil
+	})
+
+	return value, err
+}
+
+// fields calls fn with each field of a message, along with its bytes, when
+// length delimited, or its number.
+func fields(b []byte, fn func(num protowire.Number, v []byte, n uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return errMalformed
+		}
+
+		b = b[n:]
+
+		var (
+			v      []byte
+			scalar uint64
+		)
+
+		switch typ {
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			scalar, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			scalar, n = protowire.ConsumeFixed64(b)
+		default:
+			return fmt.Errorf("%w: unexpected wire type %d", errMalformed, typ)
+		}
+
+		if n < 0 {
+			return errMalformed
+		}
+
+		b = b[n:]
+
+		if err := fn(num, v, scalar); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unpack(b []byte) ([]uint64, error) {
+	var values []uint64
+
+	for len(b) > 0 {
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			return nil, errMalformed
+		}
+
+		values = append(values, v)
+		b = b[n:]
+	}
+
+	return values, nil
+}
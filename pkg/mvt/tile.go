@@ -0,0 +1,79 @@
+package mvt
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// MaxZoom is the highest zoom level supported.
+	MaxZoom = 22
+	// maxLatitude is the highest latitude of the Web Mercator projection.
+	maxLatitude = 85.05112878
+)
+
+// TileID identifies a Web Mercator (XYZ) tile.
+type TileID struct {
+	Z, X, Y int
+}
+
+func (t TileID) String() string {
+	return fmt.Sprintf("%d/%d/%d", t.Z, t.X, t.Y)
+}
+
+// Valid reports whether the tile exists: its zoom is within [0, MaxZoom]
+// and its x and y within [0, 2^z).
+func (t TileID) Valid() bool {
+	if t.Z < 0 || t.Z > MaxZoom {
+		return false
+	}
+
+	n := 1 << t.Z
+
+	return t.X >= 0 && t.X < n && t.Y >= 0 && t.Y < n
+}
+
+// TileAt returns the tile of the given zoom containing a point.
+func TileAt(lon, lat float64, z int) TileID {
+	fx, fy := project(lon, lat, z)
+	n := 1 << z
+
+	return TileID{
+		Z: z,
+		X: clamp(int(math.Floor(fx)), 0, n-1),
+		Y: clamp(int(math.Floor(fy)), 0, n-1),
+	}
+}
+
+// Contains reports whether a point falls in the tile.
+func (t TileID) Contains(lon, lat float64) bool {
+	return TileAt(lon, lat, t.Z) == t
+}
+
+// Project returns the position of a point within the tile, in extent units
+// from its top left corner.
+func (t TileID) Project(lon, lat float64, extent uint32) (int, int) {
+	fx, fy := project(lon, lat, t.Z)
+
+	x := math.Round((fx - float64(t.X)) * float64(extent))
+	y := math.Round((fy - float64(t.Y)) * float64(extent))
+
+	return int(x), int(y)
+}
+
+// project returns the position of a point in tiles of the given zoom,
+// fractional within the tiles.
+func project(lon, lat float64, z int) (float64, float64) {
+	lat = math.Max(-maxLatitude, math.Min(maxLatitude, lat))
+	n := float64(uint(1) << z)
+	rad := lat * math.Pi / 180
+
+	x := (lon + 180) / 360 * n
+	y := (1 - math.Log(math.Tan(rad)+1/math.Cos(rad))/math.Pi) / 2 * n
+
+	return x, y
+}
+
+func clamp(v, low, high int) int {
+	return max(low, min(high, v))
+}